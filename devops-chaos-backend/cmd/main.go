@@ -1,17 +1,34 @@
 package main
 
 import (
+	"context"
 	"devops-chaos-backend/internal/config"
 	"devops-chaos-backend/internal/controllers"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/invites"
+	"devops-chaos-backend/internal/mail"
 	"devops-chaos-backend/internal/middleware"
 	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/realtime"
+	"devops-chaos-backend/internal/services"
+	"devops-chaos-backend/internal/storage"
+	"devops-chaos-backend/internal/workers"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
+// Versión de la API publicada en /health y /openapi.json
+const apiVersion = "1.0.0"
+
 func main() {
 	// Cargar variables de entorno
 	if err := godotenv.Load(); err != nil {
@@ -33,148 +50,215 @@ func main() {
 		&models.Punishment{},
 		&models.Statistic{},
 		&models.Capture{},
+		&models.ReportComment{},
+		&models.ReportStatusChange{},
+		&models.ReportAttachment{},
+		&models.AnonymousReceipt{},
+		&models.SurvivalTip{},
+		&models.ResistanceMeme{},
+		&models.ContentVote{},
+		&models.EmergencyContact{},
+		&models.Team{},
+		&models.TeamMember{},
+		&models.TeamMessage{},
+		&models.TeamContribution{},
+		&models.TeamRescue{},
+		&models.Squad{},
+		&models.SquadMember{},
+		&models.SquadScore{},
+		&models.Notification{},
+		&models.NotificationPreference{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.OutboxEvent{},
+		&models.ProcessedEvent{},
+		&models.AuditLog{},
+		&models.Invite{},
+		&models.Session{},
+		&models.EmailChange{},
 	)
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
-	// Configurar Gin
-	r := gin.Default()
+	// Índice de búsqueda de texto completo sobre reportes
+	if err := dao.NewReportDAO().EnsureSearchIndex(); err != nil {
+		log.Fatal("Failed to create report search index:", err)
+	}
 
-	// Middleware global
-	r.Use(middleware.CORSMiddleware())
-	r.Use(gin.Logger())
-	r.Use(gin.Recovery())
+	// Configuración del servidor HTTP
+	serverConfig := config.LoadServerConfig()
 
-	// Inicializar controllers
-	authController := controllers.NewAuthController()
-	userController := controllers.NewUserController()
-	reportController := controllers.NewReportController()
-	punishmentController := controllers.NewPunishmentController()
-	statisticsController := controllers.NewStatisticsController()
-	dashboardController := controllers.NewDashboardController()
-	resistanceController := controllers.NewResistanceController()
-
-	// Rutas públicas
-	r.GET("/ping", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"message": "Andrei's Chaos Backend is running!",
-		})
-	})
+	// Almacenamiento de adjuntos
+	storageConfig := config.LoadStorageConfig()
+	blobStore, err := storage.NewLocalBlobStore(storageConfig.Dir)
+	if err != nil {
+		log.Fatal("Failed to initialize attachment storage:", err)
+	}
 
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":   "healthy",
-			"database": "connected",
-			"version":  "1.0.0",
-		})
-	})
+	// Perfil propio (avatar, cambio de email)
+	profileConfig := config.LoadProfileConfig()
 
-	// Rutas de autenticación (públicas)
-	auth := r.Group("/api/auth")
-	{
-		auth.POST("/login", authController.Login)
+	// Rutas con un límite de cuerpo propio
+	uploadLimits := map[string]int64{
+		"/api/reports/:id/attachments": storageConfig.MaxUploadBytes + 64<<10, // margen para el envoltorio multipart
+		"/api/resistance/memes":        storageConfig.MaxUploadBytes + 64<<10,
+		"/api/me/avatar":               profileConfig.MaxAvatarBytes + 64<<10,
 	}
 
-	// Rutas protegidas
-	api := r.Group("/api")
-	api.Use(middleware.AuthMiddleware())
+	// Protección del reporte anónimo
+	anonymousGuard := services.NewAnonymousGuard(config.LoadAnonymousConfig())
 
-	// Auth routes (protegidas)
-	authProtected := api.Group("/auth")
-	{
-		authProtected.GET("/me", authController.GetProfile)
-		authProtected.POST("/change-password", authController.ChangePassword)
-		authProtected.POST("/register", middleware.AndreiOnlyMiddleware(), authController.Register)
-	}
+	// Eventos en vivo para los dashboards
+	streamConfig := config.LoadStreamConfig()
+	hub := realtime.NewHub(streamConfig.BufferSize)
+
+	// Eventos de dominio: los servicios los registran en el outbox y las
+	// reacciones se suscriben aquí
+	outboxConfig := config.LoadOutboxConfig()
+	bus := events.NewBus(outboxConfig)
+	services.RegisterEventHandlers(bus, hub)
 
-	// User routes
-	users := api.Group("/users")
-	{
-		users.GET("", userController.GetUsers)
-		users.GET("/:id", userController.GetUserByID)
-		users.PUT("/:id", middleware.AndreiOnlyMiddleware(), userController.UpdateUser)
-		users.DELETE("/:id", middleware.AndreiOnlyMiddleware(), userController.DeleteUser)
-		users.GET("/stats", middleware.AndreiOnlyMiddleware(), userController.GetUserStats)
-		users.POST("/:id/capture", middleware.DaemonOnlyMiddleware(), userController.CaptureNetworkAdmin)
-		users.GET("/:id/captures", middleware.AndreiAndDaemonMiddleware(), userController.GetDaemonCaptures)
-		users.GET("/:id/punishments/active", punishmentController.GetActivePunishments)
+	// Correo saliente; sin SMTP no hay invitaciones por email ni cambio de email
+	mailConfig := config.LoadMailConfig()
+	var mailer mail.Mailer
+	if mailConfig.Enabled() {
+		mailer = mail.NewSMTPMailer(
+			mailConfig.SMTPHost, mailConfig.SMTPPort,
+			mailConfig.SMTPUsername, mailConfig.SMTPPassword, mailConfig.SMTPFrom,
+		)
 	}
 
-	// Capture routes
-	captures := api.Group("/captures")
-	{
-		captures.GET("/all", middleware.AndreiOnlyMiddleware(), userController.GetAllCaptures)
+	// Canales de entrega de invitaciones; el email solo si hay SMTP configurado
+	inviteConfig := config.LoadInviteConfig()
+	inviteChannels := []invites.Channel{invites.NewLinkChannel()}
+	if mailer != nil {
+		inviteChannels = append(inviteChannels, invites.NewEmailChannel(mailer))
 	}
 
-	// Network Admin capture endpoint
-	api.GET("/network-admins/capture", middleware.DaemonOnlyMiddleware(), userController.GetNetworkAdminsForCapture)
-
-	// Report routes
-	reports := api.Group("/reports")
-	{
-		reports.POST("", reportController.CreateReport)
-		reports.GET("", reportController.GetReports)
-		reports.GET("/:id", reportController.GetReportByID)
-		reports.PUT("/:id/status", middleware.AndreiOnlyMiddleware(), reportController.UpdateReportStatus)
-		reports.DELETE("/:id", reportController.DeleteReport)
-		reports.GET("/recent", middleware.AndreiOnlyMiddleware(), reportController.GetRecentReports)
+	// Sesiones; el mismo servicio atiende las rutas y limpia las vencidas
+	sessionService := services.NewSessionService(bus)
+
+	// Papelera: el mismo servicio atiende las rutas y la purga por retención
+	trashConfig := config.LoadTrashConfig()
+	trashService := services.NewTrashService(blobStore, trashConfig, bus)
+
+	// Especificación OpenAPI: documenta y valida todas las rutas
+	apiSpec := controllers.NewAPISpec(apiVersion)
+	docsController, err := controllers.NewDocsController(apiSpec)
+	if err != nil {
+		log.Fatal("Failed to build OpenAPI spec:", err)
 	}
 
-	// Punishment routes
-	punishments := api.Group("/punishments")
-	{
-		punishments.POST("", middleware.AndreiOnlyMiddleware(), punishmentController.CreatePunishment)
-		punishments.GET("", punishmentController.GetPunishments)
-		punishments.GET("/:id", punishmentController.GetPunishmentByID)
-		punishments.PUT("/:id", middleware.AndreiOnlyMiddleware(), punishmentController.UpdatePunishment)
-		punishments.DELETE("/:id", middleware.AndreiOnlyMiddleware(), punishmentController.DeletePunishment)
+	// Configurar Gin
+	r := gin.New()
+
+	// Middleware global
+	r.Use(middleware.CORSMiddleware())
+	r.Use(middleware.ErrorMiddleware())
+	r.Use(middleware.BodyLimitMiddleware(serverConfig.MaxBodyBytes, uploadLimits))
+	r.Use(gin.LoggerWithConfig(gin.LoggerConfig{
+		// No registrar IP ni hora exacta de quien envía un reporte anónimo
+		Skip: middleware.IsAnonymousRequest,
+	}))
+	r.Use(gin.Recovery())
+	r.Use(middleware.OpenAPIValidationMiddleware(apiSpec))
+
+	registerRoutes(r, &routeDeps{
+		Bus:            bus,
+		Hub:            hub,
+		BlobStore:      blobStore,
+		Mailer:         mailer,
+		AnonymousGuard: anonymousGuard,
+		SessionService: sessionService,
+		TrashService:   trashService,
+		DocsController: docsController,
+		ProfileConfig:  profileConfig,
+		StorageConfig:  storageConfig,
+		StreamConfig:   streamConfig,
+		InviteConfig:   inviteConfig,
+		InviteChannels: inviteChannels,
+	})
+
+	// No arrancar si la especificación y el router no coinciden
+	if err := apiSpec.CheckRoutes(registeredRoutes(r)); err != nil {
+		log.Fatal(err)
 	}
 
-	// Statistics routes
-	statistics := api.Group("/statistics")
-	{
-		statistics.GET("/:user_id", statisticsController.GetUserStatistics)
-		statistics.PUT("/:user_id", middleware.AndreiOnlyMiddleware(), statisticsController.UpdateStatistics)
-		statistics.GET("/leaderboard", middleware.AndreiAndDaemonMiddleware(), statisticsController.GetLeaderboard)
-		statistics.POST("/recalculate-rankings", middleware.AndreiOnlyMiddleware(), statisticsController.RecalculateRankings)
+	// Workers en segundo plano
+	backgroundWorkers := workers.NewManager()
+	backgroundWorkers.Register(workers.NewPeriodic("anonymous-guard-cleanup", time.Minute, func(ctx context.Context) {
+		anonymousGuard.Cleanup()
+	}))
+
+	backgroundWorkers.Register(bus)
+	backgroundWorkers.Register(workers.NewPeriodic("outbox-cleanup", time.Hour, bus.Purge))
+
+	backgroundWorkers.Register(workers.NewPeriodic("trash-retention", trashConfig.PurgeInterval, trashService.PurgeExpired))
+	backgroundWorkers.Register(workers.NewPeriodic("session-cleanup", time.Hour, sessionService.PurgeExpired))
+
+	webhookConfig := config.LoadWebhookConfig()
+	webhookDispatcher := services.NewWebhookDispatcher(webhookConfig)
+	backgroundWorkers.Register(workers.NewPeriodic("webhook-dispatcher", webhookConfig.PollInterval, webhookDispatcher.DispatchDue))
+
+	// Cancelar el contexto al recibir SIGINT o SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	backgroundWorkers.Start(ctx)
+
+	// Iniciar servidor
+	server := &http.Server{
+		Addr:              ":" + serverConfig.Port,
+		Handler:           r,
+		ReadTimeout:       serverConfig.ReadTimeout,
+		ReadHeaderTimeout: serverConfig.ReadHeaderTimeout,
+		WriteTimeout:      serverConfig.WriteTimeout,
+		IdleTimeout:       serverConfig.IdleTimeout,
+		MaxHeaderBytes:    serverConfig.MaxHeaderBytes,
 	}
 
-	// Dashboard routes
-	dashboard := api.Group("/dashboard")
-	{
-		dashboard.GET("/andrei", middleware.AndreiOnlyMiddleware(), dashboardController.GetAndreiDashboard)
-		dashboard.GET("/daemon", middleware.DaemonOnlyMiddleware(), dashboardController.GetDaemonDashboard)
+	// Cortar los streams al apagar; Shutdown no espera conexiones de larga vida
+	server.RegisterOnShutdown(hub.Close)
+
+	log.Printf("Server starting on port %s (TLS: %t)", serverConfig.Port, serverConfig.TLSEnabled())
+	log.Printf("API: %s, documented at /openapi.json (UI at /docs)", apiSpec.Summary())
+
+	serverErrors := make(chan error, 1)
+	go func() {
+		var err error
+		if serverConfig.TLSEnabled() {
+			err = server.ListenAndServeTLS(serverConfig.TLSCertFile, serverConfig.TLSKeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErrors:
+		log.Fatal("Failed to start server:", err)
+	case <-ctx.Done():
+		log.Println("Shutdown signal received, draining in-flight requests...")
 	}
 
-	// Resistance routes (para Network Admins)
-	resistance := api.Group("/resistance")
-	{
-		resistance.GET("", middleware.NetworkAdminOnlyMiddleware(), resistanceController.GetResistancePage)
-		resistance.POST("/report", resistanceController.ReportSuspiciousActivity) // Anónimo
+	// Apagado ordenado dentro del plazo configurado
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serverConfig.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP server shutdown error: %v", err)
 	}
 
-	// Iniciar servidor
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	if err := backgroundWorkers.Stop(shutdownCtx); err != nil {
+		log.Printf("Background workers did not stop in time: %v", err)
 	}
 
-	log.Printf("Server starting on port %s", port)
-	log.Printf("Available endpoints:")
-	log.Printf("  GET  /ping")
-	log.Printf("  GET  /health")
-	log.Printf("  POST /api/auth/login")
-	log.Printf("  GET  /api/auth/me")
-	log.Printf("  GET  /api/users")
-	log.Printf("  POST /api/users/:id/capture")
-	log.Printf("  GET  /api/network-admins/capture")
-	log.Printf("  GET  /api/reports")
-	log.Printf("  GET  /api/dashboard/andrei")
-	log.Printf("  GET  /api/dashboard/daemon")
-	log.Printf("  ... and many more!")
-
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	if err := config.CloseDatabase(); err != nil {
+		log.Printf("Failed to close database: %v", err)
 	}
+
+	log.Println("Server stopped")
 }
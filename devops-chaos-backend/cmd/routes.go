@@ -0,0 +1,296 @@
+package main
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/controllers"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/invites"
+	"devops-chaos-backend/internal/mail"
+	"devops-chaos-backend/internal/middleware"
+	"devops-chaos-backend/internal/openapi"
+	"devops-chaos-backend/internal/realtime"
+	"devops-chaos-backend/internal/services"
+	"devops-chaos-backend/internal/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeDeps agrupa lo que los controllers comparten con los workers de main
+type routeDeps struct {
+	Bus            *events.Bus
+	Hub            *realtime.Hub
+	BlobStore      storage.BlobStore
+	Mailer         mail.Mailer // nil sin SMTP
+	AnonymousGuard *services.AnonymousGuard
+	SessionService *services.SessionService
+	TrashService   *services.TrashService
+	DocsController *controllers.DocsController
+	ProfileConfig  *config.ProfileConfig
+	StorageConfig  *config.StorageConfig
+	StreamConfig   *config.StreamConfig
+	InviteConfig   *config.InviteConfig
+	InviteChannels []invites.Channel
+}
+
+// registerRoutes registra todas las rutas de la API; cada una debe estar
+// descrita en controllers.NewAPISpec
+func registerRoutes(r *gin.Engine, deps *routeDeps) {
+	// Inicializar controllers
+	authController := controllers.NewAuthController(deps.Bus)
+	userController := controllers.NewUserController(deps.Bus)
+	profileController := controllers.NewProfileController(deps.ProfileConfig, deps.Mailer, deps.BlobStore, deps.Bus)
+	sessionController := controllers.NewSessionController(deps.SessionService)
+	privacyController := controllers.NewPrivacyController(deps.BlobStore, deps.Bus)
+	reportController := controllers.NewReportController(deps.Bus)
+	attachmentController := controllers.NewAttachmentController(deps.BlobStore, deps.StorageConfig.MaxUploadBytes)
+	punishmentController := controllers.NewPunishmentController(deps.Bus)
+	statisticsController := controllers.NewStatisticsController(deps.Bus)
+	dashboardController := controllers.NewDashboardController()
+	resistanceController := controllers.NewResistanceController(deps.AnonymousGuard, deps.Bus)
+	resistanceContentController := controllers.NewResistanceContentController(deps.BlobStore, deps.StorageConfig.MaxUploadBytes)
+	teamController := controllers.NewTeamController(deps.Bus)
+	squadController := controllers.NewSquadController()
+	streamController := controllers.NewStreamController(deps.Hub, deps.StreamConfig.HeartbeatInterval)
+	notificationController := controllers.NewNotificationController(deps.Hub)
+	webhookController := controllers.NewWebhookController()
+	auditController := controllers.NewAuditController()
+	inviteController := controllers.NewInviteController(deps.InviteConfig, deps.InviteChannels, deps.Bus)
+	trashController := controllers.NewTrashController(deps.TrashService)
+
+	// Rutas públicas
+	r.GET("/ping", func(c *gin.Context) {
+		c.JSON(200, gin.H{
+			"message": "Andrei's Chaos Backend is running!",
+		})
+	})
+
+	r.GET("/health", func(c *gin.Context) {
+		c.JSON(200, gin.H{
+			"status":   "healthy",
+			"database": "connected",
+			"version":  apiVersion,
+		})
+	})
+
+	// Documentación de la API
+	r.GET("/openapi.json", deps.DocsController.GetSpec)
+	r.GET("/docs", deps.DocsController.GetUI)
+
+	// Rutas de autenticación (públicas)
+	auth := r.Group("/api/auth")
+	{
+		auth.POST("/login", authController.Login)
+		auth.POST("/accept-invite", inviteController.AcceptInvite) // Token en el cuerpo, nunca en la URL
+		auth.POST("/verify-email", profileController.VerifyEmail)  // Ídem
+	}
+
+	// Rutas protegidas
+	api := r.Group("/api")
+	api.Use(middleware.AuthMiddleware())
+
+	// Auth routes (protegidas)
+	authProtected := api.Group("/auth")
+	{
+		authProtected.GET("/me", profileController.GetProfile)
+		authProtected.POST("/change-password", authController.ChangePassword)
+		authProtected.POST("/register", middleware.AndreiOnlyMiddleware(), authController.Register)
+	}
+
+	// Self-service del usuario autenticado; rol y status no se editan aquí
+	me := api.Group("/me")
+	{
+		me.PUT("/profile", profileController.UpdateProfile)
+		me.POST("/email", profileController.RequestEmailChange)
+		me.PUT("/avatar", profileController.UploadAvatar)
+		me.DELETE("/avatar", profileController.DeleteAvatar)
+		me.GET("/sessions", sessionController.GetSessions)
+		me.DELETE("/sessions", sessionController.RevokeOtherSessions)
+		me.DELETE("/sessions/:id", sessionController.RevokeSession)
+		me.GET("/export", privacyController.ExportMyData)
+	}
+
+	// User routes
+	users := api.Group("/users")
+	{
+		users.GET("", userController.GetUsers)
+		users.GET("/:id", userController.GetUserByID)
+		users.GET("/:id/avatar", profileController.GetAvatar)
+		users.PUT("/:id", middleware.AndreiOnlyMiddleware(), userController.UpdateUser)
+		users.DELETE("/:id", middleware.AndreiOnlyMiddleware(), userController.DeleteUser)
+		users.GET("/:id/deletion-preview", middleware.AndreiOnlyMiddleware(), userController.PreviewDeletion)
+		users.POST("/:id/anonymize", middleware.AndreiOnlyMiddleware(), privacyController.AnonymizeUser)
+		users.GET("/stats", middleware.AndreiOnlyMiddleware(), userController.GetUserStats)
+		users.POST("/import", middleware.AndreiOnlyMiddleware(), userController.ImportUsers)
+		users.GET("/export", middleware.AndreiOnlyMiddleware(), userController.ExportUsers)
+		users.POST("/:id/capture", middleware.DaemonOnlyMiddleware(), userController.CaptureNetworkAdmin)
+		users.GET("/:id/captures", middleware.AndreiAndDaemonMiddleware(), userController.GetDaemonCaptures)
+		users.GET("/:id/punishments/active", punishmentController.GetActivePunishments)
+	}
+
+	// Capture routes
+	captures := api.Group("/captures")
+	{
+		captures.GET("/all", middleware.AndreiOnlyMiddleware(), userController.GetAllCaptures)
+	}
+
+	// Network Admin capture endpoint
+	api.GET("/network-admins/capture", middleware.DaemonOnlyMiddleware(), userController.GetNetworkAdminsForCapture)
+
+	// Report routes
+	reports := api.Group("/reports")
+	{
+		reports.POST("", reportController.CreateReport)
+		reports.GET("", reportController.GetReports)
+		reports.GET("/search", reportController.SearchReports)
+		reports.GET("/:id", reportController.GetReportByID)
+		reports.PUT("/:id/status", middleware.AndreiOnlyMiddleware(), reportController.UpdateReportStatus)
+		reports.PUT("/:id/assignee", middleware.AndreiOnlyMiddleware(), reportController.AssignReport)
+		reports.GET("/:id/comments", reportController.GetComments)
+		reports.POST("/:id/comments", reportController.AddComment)
+		reports.GET("/:id/history", reportController.GetReportHistory)
+		reports.GET("/:id/attachments", attachmentController.GetAttachments)
+		reports.POST("/:id/attachments", attachmentController.UploadAttachment)
+		reports.GET("/:id/attachments/:attachmentId", attachmentController.DownloadAttachment)
+		reports.DELETE("/:id/attachments/:attachmentId", attachmentController.DeleteAttachment)
+		reports.DELETE("/:id", reportController.DeleteReport)
+		reports.GET("/recent", middleware.AndreiOnlyMiddleware(), reportController.GetRecentReports)
+	}
+
+	// Punishment routes
+	punishments := api.Group("/punishments")
+	{
+		punishments.POST("", middleware.AndreiOnlyMiddleware(), punishmentController.CreatePunishment)
+		punishments.GET("", punishmentController.GetPunishments)
+		punishments.GET("/:id", punishmentController.GetPunishmentByID)
+		punishments.PUT("/:id", middleware.AndreiOnlyMiddleware(), punishmentController.UpdatePunishment)
+		punishments.DELETE("/:id", middleware.AndreiOnlyMiddleware(), punishmentController.DeletePunishment)
+	}
+
+	// Statistics routes
+	statistics := api.Group("/statistics")
+	{
+		statistics.GET("/:user_id", statisticsController.GetUserStatistics)
+		statistics.PUT("/:user_id", middleware.AndreiOnlyMiddleware(), statisticsController.UpdateStatistics)
+		statistics.GET("/leaderboard", middleware.AndreiAndDaemonMiddleware(), statisticsController.GetLeaderboard)
+		statistics.GET("/leaderboard/squads", middleware.AndreiAndDaemonMiddleware(), statisticsController.GetSquadLeaderboard)
+		statistics.POST("/recalculate-rankings", middleware.AndreiOnlyMiddleware(), statisticsController.RecalculateRankings)
+	}
+
+	// Squad routes (escuadrones de daemons)
+	squads := api.Group("/squads")
+	{
+		squads.POST("", middleware.AndreiOnlyMiddleware(), squadController.CreateSquad)
+		squads.GET("", middleware.AndreiAndDaemonMiddleware(), squadController.GetSquads)
+		squads.GET("/:id", middleware.AndreiAndDaemonMiddleware(), squadController.GetSquad)
+		squads.PUT("/:id", middleware.AndreiOnlyMiddleware(), squadController.UpdateSquad)
+		squads.DELETE("/:id", middleware.AndreiOnlyMiddleware(), squadController.DeleteSquad)
+		squads.POST("/:id/members", middleware.AndreiOnlyMiddleware(), squadController.AddMember)
+		squads.DELETE("/:id/members/:userId", middleware.AndreiOnlyMiddleware(), squadController.RemoveMember)
+	}
+
+	// Eventos en vivo filtrados por rol
+	api.GET("/stream", streamController.Stream)
+
+	// Notification routes (bandeja de cada usuario)
+	notifications := api.Group("/notifications")
+	{
+		notifications.GET("", notificationController.GetNotifications)
+		notifications.GET("/unread-count", notificationController.GetUnreadCount)
+		notifications.PUT("/read-all", notificationController.MarkAllRead)
+		notifications.GET("/preferences", notificationController.GetPreferences)
+		notifications.PUT("/preferences", notificationController.UpdatePreferences)
+		notifications.PUT("/:id/read", notificationController.MarkRead)
+	}
+
+	// Webhook routes (solo Andrei)
+	webhooks := api.Group("/webhooks")
+	webhooks.Use(middleware.AndreiOnlyMiddleware())
+	{
+		webhooks.POST("", webhookController.CreateWebhook)
+		webhooks.GET("", webhookController.GetWebhooks)
+		webhooks.GET("/:id", webhookController.GetWebhook)
+		webhooks.PUT("/:id", webhookController.UpdateWebhook)
+		webhooks.DELETE("/:id", webhookController.DeleteWebhook)
+		webhooks.POST("/:id/secret", webhookController.RotateSecret)
+		webhooks.POST("/:id/ping", webhookController.Ping)
+		webhooks.GET("/:id/deliveries", webhookController.GetDeliveries)
+		webhooks.GET("/:id/deliveries/:deliveryId", webhookController.GetDelivery)
+		webhooks.POST("/:id/deliveries/:deliveryId/redeliver", webhookController.Redeliver)
+	}
+
+	// Invite routes (solo Andrei)
+	invitesGroup := api.Group("/invites")
+	invitesGroup.Use(middleware.AndreiOnlyMiddleware())
+	{
+		invitesGroup.POST("", inviteController.CreateInvite)
+		invitesGroup.GET("", inviteController.GetInvites)
+		invitesGroup.POST("/:id/resend", inviteController.ResendInvite)
+		invitesGroup.DELETE("/:id", inviteController.RevokeInvite)
+	}
+
+	// Trash routes (solo Andrei): usuarios, reportes y castigos eliminados
+	trash := api.Group("/trash")
+	trash.Use(middleware.AndreiOnlyMiddleware())
+	{
+		trash.GET("/:entity", trashController.GetTrash)
+		trash.POST("/:entity/:id/restore", trashController.Restore)
+		trash.DELETE("/:entity/:id", trashController.Purge)
+	}
+
+	// Audit routes (solo Andrei)
+	api.GET("/audit", middleware.AndreiOnlyMiddleware(), auditController.GetAuditLog)
+
+	// Dashboard routes
+	dashboard := api.Group("/dashboard")
+	{
+		dashboard.GET("/andrei", middleware.AndreiOnlyMiddleware(), dashboardController.GetAndreiDashboard)
+		dashboard.GET("/daemon", middleware.DaemonOnlyMiddleware(), dashboardController.GetDaemonDashboard)
+	}
+
+	// Resistance routes (para Network Admins)
+	resistance := api.Group("/resistance")
+	{
+		resistance.GET("", middleware.NetworkAdminOnlyMiddleware(), resistanceController.GetResistancePage)
+		resistance.GET("/report/challenge", resistanceController.GetSubmissionChallenge)
+		resistance.POST("/report", resistanceController.ReportSuspiciousActivity)  // Anónimo
+		resistance.POST("/receipts/lookup", resistanceController.GetReceiptStatus) // Código en el cuerpo, nunca en la URL
+		resistance.POST("/receipts/notes", resistanceController.AddReceiptNote)
+
+		// Consejos y memes de la comunidad
+		resistance.GET("/tips", middleware.ResistanceContentMiddleware(), resistanceContentController.GetTips)
+		resistance.POST("/tips", middleware.NetworkAdminOnlyMiddleware(), resistanceContentController.SubmitTip)
+		resistance.POST("/tips/:id/vote", middleware.NetworkAdminOnlyMiddleware(), resistanceContentController.VoteTip)
+		resistance.PUT("/tips/:id/moderation", middleware.ModeratorMiddleware(), resistanceContentController.ModerateTip)
+		resistance.GET("/memes", middleware.ResistanceContentMiddleware(), resistanceContentController.GetMemes)
+		resistance.POST("/memes", middleware.NetworkAdminOnlyMiddleware(), resistanceContentController.SubmitMeme)
+		resistance.GET("/memes/:id/image", middleware.ResistanceContentMiddleware(), resistanceContentController.GetMemeImage)
+		resistance.POST("/memes/:id/vote", middleware.NetworkAdminOnlyMiddleware(), resistanceContentController.VoteMeme)
+		resistance.PUT("/memes/:id/moderation", middleware.ModeratorMiddleware(), resistanceContentController.ModerateMeme)
+	}
+
+	// Team routes (células de la resistencia, nunca visibles para daemons)
+	teams := api.Group("/teams")
+	teams.Use(middleware.NetworkAdminOnlyMiddleware())
+	{
+		teams.POST("", teamController.CreateTeam)
+		teams.GET("", teamController.GetTeams)
+		teams.GET("/mine", teamController.GetMyTeam)
+		teams.GET("/:id", teamController.GetTeam)
+		teams.PUT("/:id", teamController.UpdateTeam)
+		teams.POST("/:id/members", teamController.AddMember)
+		teams.DELETE("/:id/members/:userId", teamController.RemoveMember)
+		teams.GET("/:id/messages", teamController.GetMessages)
+		teams.POST("/:id/messages", teamController.PostMessage)
+		teams.POST("/:id/contributions", teamController.Contribute)
+		teams.POST("/:id/rescues", teamController.RescueMember)
+	}
+}
+
+// registeredRoutes lista las rutas del router para compararlas con la especificación
+func registeredRoutes(r *gin.Engine) []openapi.Route {
+	routes := make([]openapi.Route, 0, len(r.Routes()))
+	for _, route := range r.Routes() {
+		routes = append(routes, openapi.Route{Method: route.Method, Path: route.Path})
+	}
+	return routes
+}
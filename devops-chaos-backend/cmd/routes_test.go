@@ -0,0 +1,57 @@
+package main
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/controllers"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/invites"
+	"devops-chaos-backend/internal/openapi"
+	"devops-chaos-backend/internal/realtime"
+	"devops-chaos-backend/internal/services"
+	"devops-chaos-backend/internal/storage"
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRoutesMatchOpenAPISpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	spec := controllers.NewAPISpec(apiVersion)
+	docsController, err := controllers.NewDocsController(spec)
+	if err != nil {
+		t.Fatalf("NewDocsController: %v", err)
+	}
+	blobStore, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore: %v", err)
+	}
+	bus := events.NewBus(config.LoadOutboxConfig())
+
+	r := gin.New()
+	registerRoutes(r, &routeDeps{
+		Bus:            bus,
+		Hub:            realtime.NewHub(1),
+		BlobStore:      blobStore,
+		AnonymousGuard: services.NewAnonymousGuard(config.LoadAnonymousConfig()),
+		SessionService: services.NewSessionService(bus),
+		TrashService:   services.NewTrashService(blobStore, config.LoadTrashConfig(), bus),
+		DocsController: docsController,
+		ProfileConfig:  config.LoadProfileConfig(),
+		StorageConfig:  config.LoadStorageConfig(),
+		StreamConfig:   config.LoadStreamConfig(),
+		InviteConfig:   config.LoadInviteConfig(),
+		InviteChannels: []invites.Channel{invites.NewLinkChannel()},
+	})
+
+	err = spec.CheckRoutes(registeredRoutes(r))
+	var drift *openapi.DriftError
+	if errors.As(err, &drift) {
+		t.Fatalf("router and OpenAPI spec drifted apart:\n undocumented: %v\n unrouted: %v",
+			drift.Undocumented, drift.Unrouted)
+	}
+	if err != nil {
+		t.Fatalf("CheckRoutes: %v", err)
+	}
+}
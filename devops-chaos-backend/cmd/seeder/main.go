@@ -24,6 +24,9 @@ func main() {
 		&models.Report{},
 		&models.Punishment{},
 		&models.Statistic{},
+		&models.SurvivalTip{},
+		&models.ResistanceMeme{},
+		&models.EmergencyContact{},
 	)
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
@@ -0,0 +1,140 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kind clasifica los errores de dominio
+type Kind string
+
+const (
+	KindNotFound     Kind = "not_found"
+	KindForbidden    Kind = "forbidden"
+	KindUnauthorized Kind = "unauthorized"
+	KindConflict     Kind = "conflict"
+	KindValidation   Kind = "validation"
+	KindTooLarge     Kind = "too_large"
+	KindRateLimited  Kind = "rate_limited"
+	KindUnavailable  Kind = "unavailable"
+	KindInternal     Kind = "internal"
+)
+
+// Error es un error de dominio con un código estable legible por máquinas
+type Error struct {
+	Kind       Kind
+	Code       string
+	Message    string
+	Err        error
+	RetryAfter time.Duration // Solo para KindRateLimited
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Status devuelve el código HTTP asociado al tipo de error
+func (e *Error) Status() int {
+	switch e.Kind {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case KindRateLimited:
+		return http.StatusTooManyRequests
+	case KindUnavailable:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func Validation(code, message string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message}
+}
+
+func TooLarge(code, message string) *Error {
+	return &Error{Kind: KindTooLarge, Code: code, Message: message}
+}
+
+func RateLimited(code, message string) *Error {
+	return &Error{Kind: KindRateLimited, Code: code, Message: message}
+}
+
+// Unavailable indica que falló un servicio externo (SMTP, etc.)
+func Unavailable(code, message string, err error) *Error {
+	return &Error{Kind: KindUnavailable, Code: code, Message: message, Err: err}
+}
+
+// Internal envuelve un error inesperado (base de datos, IO, etc.)
+func Internal(err error) *Error {
+	return &Error{Kind: KindInternal, Code: "internal_error", Message: "An unexpected error occurred", Err: err}
+}
+
+// NotFoundOrInternal traduce un error de búsqueda: registro inexistente -> NotFound,
+// cualquier otro fallo -> Internal
+func NotFoundOrInternal(err error, code, message string) *Error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFound(code, message)
+	}
+	return Internal(err)
+}
+
+// From convierte cualquier error en un *Error, tratando los desconocidos como internos
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return TooLarge("request_too_large", "Request body exceeds the configured limit")
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFound("not_found", "Resource not found")
+	}
+	return Internal(err)
+}
+
+// Is reporta si err es un error de dominio del tipo indicado
+func Is(err error, kind Kind) bool {
+	var appErr *Error
+	return errors.As(err, &appErr) && appErr.Kind == kind
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+// AnonymousConfig agrupa los parámetros de protección del reporte anónimo
+type AnonymousConfig struct {
+	SubmissionLimit  int
+	SubmissionWindow time.Duration
+	ChallengeLimit   int
+	TicketTTL        time.Duration
+	PowDifficulty    int // bits en cero requeridos al inicio del hash
+	DuplicateWindow  time.Duration
+}
+
+// LoadAnonymousConfig lee la configuración desde variables de entorno
+func LoadAnonymousConfig() *AnonymousConfig {
+	return &AnonymousConfig{
+		SubmissionLimit:  int(getInt64Env("ANON_REPORT_LIMIT", 5)),
+		SubmissionWindow: getDurationEnv("ANON_REPORT_WINDOW", time.Hour),
+		ChallengeLimit:   int(getInt64Env("ANON_CHALLENGE_LIMIT", 20)),
+		TicketTTL:        getDurationEnv("ANON_TICKET_TTL", 10*time.Minute),
+		PowDifficulty:    int(getInt64Env("ANON_POW_DIFFICULTY", 18)),
+		DuplicateWindow:  getDurationEnv("ANON_DUPLICATE_WINDOW", 7*24*time.Hour),
+	}
+}
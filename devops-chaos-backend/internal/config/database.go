@@ -40,6 +40,21 @@ func GetDatabase() *gorm.DB {
 	return DB
 }
 
+// CloseDatabase cierra el pool de conexiones subyacente
+func CloseDatabase() error {
+	if DB == nil {
+		return nil
+	}
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+
+	log.Println("Closing database connections")
+	return sqlDB.Close()
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
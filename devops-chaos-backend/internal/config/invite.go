@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// InviteConfig agrupa los parámetros de las invitaciones
+type InviteConfig struct {
+	TTL       time.Duration
+	AcceptURL string // El token va en el fragmento (#token=...), que no llega a logs de servidores
+}
+
+// LoadInviteConfig lee la configuración desde variables de entorno
+func LoadInviteConfig() *InviteConfig {
+	return &InviteConfig{
+		TTL:       getDurationEnv("INVITE_TTL", 72*time.Hour),
+		AcceptURL: getEnv("INVITE_ACCEPT_URL", "http://localhost:3000/accept-invite"),
+	}
+}
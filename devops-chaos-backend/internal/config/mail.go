@@ -0,0 +1,26 @@
+package config
+
+// MailConfig agrupa los parámetros del envío de correo por SMTP
+type MailConfig struct {
+	SMTPHost     string // Sin host no se envían correos
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+}
+
+// LoadMailConfig lee la configuración desde variables de entorno
+func LoadMailConfig() *MailConfig {
+	return &MailConfig{
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     int(getInt64Env("SMTP_PORT", 587)),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", "andrei@devops-chaos.local"),
+	}
+}
+
+// Enabled indica si hay un servidor SMTP configurado
+func (c *MailConfig) Enabled() bool {
+	return c.SMTPHost != ""
+}
@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// OutboxConfig agrupa los parámetros del despacho de eventos de dominio
+type OutboxConfig struct {
+	PollInterval time.Duration // Respaldo si se pierde un aviso de commit
+	BatchSize    int
+	MaxAttempts  int           // Al agotarlos el evento queda como "dead"
+	Retention    time.Duration // Cuánto se guardan los eventos procesados
+}
+
+// LoadOutboxConfig lee la configuración desde variables de entorno
+func LoadOutboxConfig() *OutboxConfig {
+	return &OutboxConfig{
+		PollInterval: getDurationEnv("OUTBOX_POLL_INTERVAL", 2*time.Second),
+		BatchSize:    int(getInt64Env("OUTBOX_BATCH_SIZE", 50)),
+		MaxAttempts:  int(getInt64Env("OUTBOX_MAX_ATTEMPTS", 10)),
+		Retention:    getDurationEnv("OUTBOX_RETENTION", 7*24*time.Hour),
+	}
+}
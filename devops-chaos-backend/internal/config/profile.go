@@ -0,0 +1,19 @@
+package config
+
+import "time"
+
+// ProfileConfig agrupa los parámetros del perfil propio de cada usuario
+type ProfileConfig struct {
+	EmailChangeTTL time.Duration
+	VerifyEmailURL string // El token va en el fragmento (#token=...), igual que en las invitaciones
+	MaxAvatarBytes int64
+}
+
+// LoadProfileConfig lee la configuración desde variables de entorno
+func LoadProfileConfig() *ProfileConfig {
+	return &ProfileConfig{
+		EmailChangeTTL: getDurationEnv("EMAIL_CHANGE_TTL", 24*time.Hour),
+		VerifyEmailURL: getEnv("EMAIL_VERIFY_URL", "http://localhost:3000/verify-email"),
+		MaxAvatarBytes: getInt64Env("PROFILE_MAX_AVATAR_BYTES", 2<<20), // 2 MB
+	}
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"log"
+	"os"
+	"regexp"
+)
+
+var searchLanguagePattern = regexp.MustCompile(`^[a-z_]+$`)
+
+// SearchLanguage devuelve la configuración de texto de Postgres usada en búsquedas
+func SearchLanguage() string {
+	language := getEnv("SEARCH_LANGUAGE", "english")
+	if !searchLanguagePattern.MatchString(language) {
+		log.Printf("Invalid SEARCH_LANGUAGE %q, using english", os.Getenv("SEARCH_LANGUAGE"))
+		return "english"
+	}
+	return language
+}
+
+// IsPostgres indica si la base de datos activa es PostgreSQL
+func IsPostgres() bool {
+	return DB != nil && DB.Dialector.Name() == "postgres"
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ServerConfig agrupa los parámetros del servidor HTTP
+type ServerConfig struct {
+	Port              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+	MaxBodyBytes      int64
+	MaxHeaderBytes    int
+	TLSCertFile       string
+	TLSKeyFile        string
+}
+
+// LoadServerConfig lee la configuración del servidor desde variables de entorno
+func LoadServerConfig() *ServerConfig {
+	return &ServerConfig{
+		Port:              getEnv("PORT", "8080"),
+		ReadTimeout:       getDurationEnv("SERVER_READ_TIMEOUT", 15*time.Second),
+		ReadHeaderTimeout: getDurationEnv("SERVER_READ_HEADER_TIMEOUT", 5*time.Second),
+		WriteTimeout:      getDurationEnv("SERVER_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:       getDurationEnv("SERVER_IDLE_TIMEOUT", 60*time.Second),
+		ShutdownTimeout:   getDurationEnv("SERVER_SHUTDOWN_TIMEOUT", 20*time.Second),
+		MaxBodyBytes:      getInt64Env("SERVER_MAX_BODY_BYTES", 1<<20), // 1 MB
+		MaxHeaderBytes:    int(getInt64Env("SERVER_MAX_HEADER_BYTES", 1<<20)),
+		TLSCertFile:       os.Getenv("TLS_CERT_FILE"),
+		TLSKeyFile:        os.Getenv("TLS_KEY_FILE"),
+	}
+}
+
+// TLSEnabled indica si se configuraron certificado y llave
+func (c *ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s (%q), using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+func getInt64Env(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid value for %s (%q), using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
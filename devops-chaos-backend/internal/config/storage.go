@@ -0,0 +1,15 @@
+package config
+
+// StorageConfig agrupa los parámetros de almacenamiento de adjuntos
+type StorageConfig struct {
+	Dir            string
+	MaxUploadBytes int64
+}
+
+// LoadStorageConfig lee la configuración de almacenamiento desde variables de entorno
+func LoadStorageConfig() *StorageConfig {
+	return &StorageConfig{
+		Dir:            getEnv("STORAGE_DIR", "./uploads"),
+		MaxUploadBytes: getInt64Env("SERVER_MAX_UPLOAD_BYTES", 10<<20), // 10 MB
+	}
+}
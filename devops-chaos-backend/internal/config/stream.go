@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// StreamConfig agrupa los parámetros de /api/stream
+type StreamConfig struct {
+	BufferSize        int           // Eventos pendientes por conexión antes de cortarla
+	HeartbeatInterval time.Duration // Mantiene viva la conexión a través de proxies
+}
+
+// LoadStreamConfig lee la configuración desde variables de entorno
+func LoadStreamConfig() *StreamConfig {
+	return &StreamConfig{
+		BufferSize:        int(getInt64Env("STREAM_BUFFER_SIZE", 32)),
+		HeartbeatInterval: getDurationEnv("STREAM_HEARTBEAT_INTERVAL", 25*time.Second),
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	statsLocation     *time.Location
+	statsLocationOnce sync.Once
+)
+
+// StatsLocation devuelve la zona horaria usada para agrupar estadísticas por día (STATS_TIMEZONE)
+func StatsLocation() *time.Location {
+	statsLocationOnce.Do(func() {
+		name := getEnv("STATS_TIMEZONE", "UTC")
+
+		location, err := time.LoadLocation(name)
+		if err != nil || name == "Local" {
+			log.Printf("Invalid STATS_TIMEZONE %q, using UTC", name)
+			location = time.UTC
+		}
+		statsLocation = location
+	})
+	return statsLocation
+}
@@ -0,0 +1,19 @@
+package config
+
+import "time"
+
+// TrashConfig agrupa los parámetros de la papelera (filas con soft delete)
+type TrashConfig struct {
+	Retention     time.Duration // Antigüedad a partir de la cual se purga; 0 desactiva la purga automática
+	PurgeInterval time.Duration
+	BatchSize     int
+}
+
+// LoadTrashConfig lee la configuración desde variables de entorno
+func LoadTrashConfig() *TrashConfig {
+	return &TrashConfig{
+		Retention:     time.Duration(getInt64Env("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
+		PurgeInterval: getDurationEnv("TRASH_PURGE_INTERVAL", time.Hour),
+		BatchSize:     int(getInt64Env("TRASH_PURGE_BATCH_SIZE", 100)),
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+// WebhookConfig agrupa los parámetros de entrega de webhooks
+type WebhookConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Timeout      time.Duration // Por intento
+	MaxAttempts  int           // Al agotarlos la entrega queda como "dead"
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+}
+
+// LoadWebhookConfig lee la configuración desde variables de entorno
+func LoadWebhookConfig() *WebhookConfig {
+	return &WebhookConfig{
+		PollInterval: getDurationEnv("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+		BatchSize:    int(getInt64Env("WEBHOOK_BATCH_SIZE", 20)),
+		Timeout:      getDurationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+		MaxAttempts:  int(getInt64Env("WEBHOOK_MAX_ATTEMPTS", 8)),
+		BackoffBase:  getDurationEnv("WEBHOOK_BACKOFF_BASE", 30*time.Second),
+		BackoffMax:   getDurationEnv("WEBHOOK_BACKOFF_MAX", time.Hour),
+	}
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/services"
+	"devops-chaos-backend/internal/storage"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttachmentController struct {
+	attachmentService *services.AttachmentService
+}
+
+func NewAttachmentController(store storage.BlobStore, maxUploadBytes int64) *AttachmentController {
+	return &AttachmentController{
+		attachmentService: services.NewAttachmentService(store, maxUploadBytes),
+	}
+}
+
+// POST /reports/:id/attachments (multipart, campos "file" y "receipt_code")
+func (ac *AttachmentController) UploadAttachment(c *gin.Context) {
+	reportID, err := parseIDParam(c, "id", "report")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.UploadAttachmentRequest
+	if err := bindForm(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Con código de recibo es el remitente de un reporte anónimo: ni el log de
+	// acceso ni la sesión deben registrar esta petición
+	if req.ReceiptCode != "" {
+		c.Set("anonymousRequest", true)
+	}
+
+	file, err := formFile(c, "file")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	attachment, err := ac.attachmentService.UploadAttachment(c.Request.Context(), reportID, &req, file, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Attachment uploaded successfully",
+		Data:    attachment,
+	})
+}
+
+// GET /reports/:id/attachments
+func (ac *AttachmentController) GetAttachments(c *gin.Context) {
+	reportID, err := parseIDParam(c, "id", "report")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	attachments, err := ac.attachmentService.GetAttachments(reportID, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Attachments retrieved successfully",
+		Data:    attachments,
+	})
+}
+
+// GET /reports/:id/attachments/:attachmentId
+func (ac *AttachmentController) DownloadAttachment(c *gin.Context) {
+	reportID, err := parseIDParam(c, "id", "report")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	attachmentID, err := parseIDParam(c, "attachmentId", "attachment")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	attachment, reader, err := ac.attachmentService.OpenAttachment(c.Request.Context(), reportID, attachmentID, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer reader.Close()
+
+	// Forzar descarga y evitar que el navegador interprete el contenido
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, reader, map[string]string{
+		"Content-Disposition":     mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options":  "nosniff",
+		"Content-Security-Policy": "default-src 'none'; sandbox",
+		"Cache-Control":           "private, no-store",
+	})
+}
+
+// DELETE /reports/:id/attachments/:attachmentId
+func (ac *AttachmentController) DeleteAttachment(c *gin.Context) {
+	reportID, err := parseIDParam(c, "id", "report")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	attachmentID, err := parseIDParam(c, "attachmentId", "attachment")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	err = ac.attachmentService.DeleteAttachment(reportID, attachmentID, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Attachment deleted successfully",
+	})
+}
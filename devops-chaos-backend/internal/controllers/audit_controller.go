@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditController struct {
+	auditService *services.AuditService
+}
+
+func NewAuditController() *AuditController {
+	return &AuditController{
+		auditService: services.NewAuditService(),
+	}
+}
+
+// GET /audit
+func (ac *AuditController) GetAuditLog(c *gin.Context) {
+	params, err := parseListParams(c, "event", "actor", "subject")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := ac.auditService.GetAuditLog(params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
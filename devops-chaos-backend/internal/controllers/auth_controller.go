@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/services"
 	"net/http"
 
@@ -12,31 +14,23 @@ type AuthController struct {
 	authService *services.AuthService
 }
 
-func NewAuthController() *AuthController {
+func NewAuthController(bus *events.Bus) *AuthController {
 	return &AuthController{
-		authService: services.NewAuthService(),
+		authService: services.NewAuthService(bus),
 	}
 }
 
 // POST /auth/login
 func (ac *AuthController) Login(c *gin.Context) {
 	var req dto.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	response, err := ac.authService.Login(&req)
+	response, err := ac.authService.Login(&req, clientInfo(c))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Success: false,
-			Message: "Authentication failed",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -50,33 +44,21 @@ func (ac *AuthController) Login(c *gin.Context) {
 // POST /auth/register (solo Andrei)
 func (ac *AuthController) Register(c *gin.Context) {
 	var req dto.RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
 	// Obtener rol del usuario actual del contexto
 	currentUserRole, exists := c.Get("userRole")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Success: false,
-			Message: "Unauthorized",
-			Error:   "User role not found in context",
-		})
+		c.Error(apperrors.Unauthorized("missing_role", "User role not found in context"))
 		return
 	}
 
 	userInfo, err := ac.authService.Register(&req, currentUserRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Registration failed",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -90,69 +72,28 @@ func (ac *AuthController) Register(c *gin.Context) {
 // POST /auth/change-password
 func (ac *AuthController) ChangePassword(c *gin.Context) {
 	var req dto.ChangePasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
 	// Obtener ID del usuario del contexto
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Success: false,
-			Message: "Unauthorized",
-			Error:   "User ID not found in context",
-		})
+		c.Error(apperrors.Unauthorized("missing_user", "User ID not found in context"))
 		return
 	}
 
-	err := ac.authService.ChangePassword(userID.(uint), &req)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to change password",
-			Error:   err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, dto.ApiResponse{
-		Success: true,
-		Message: "Password changed successfully",
-	})
-}
-
-// GET /auth/me
-func (ac *AuthController) GetProfile(c *gin.Context) {
-	userID, exists := c.Get("userID")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Success: false,
-			Message: "Unauthorized",
-			Error:   "User ID not found in context",
-		})
-		return
-	}
+	sessionID, _ := c.Get("sessionID")
 
-	// Obtener información del usuario usando el user service
-	userService := services.NewUserService()
-	userInfo, err := userService.GetByID(userID.(uint), "")
+	err := ac.authService.ChangePassword(userID.(uint), sessionID.(uint), &req)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{
-			Success: false,
-			Message: "User not found",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.ApiResponse{
 		Success: true,
-		Message: "Profile retrieved successfully",
-		Data:    userInfo,
+		Message: "Password changed successfully",
 	})
 }
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/dto"
 	"devops-chaos-backend/internal/services"
 	"net/http"
@@ -23,21 +24,13 @@ func (dc *DashboardController) GetAndreiDashboard(c *gin.Context) {
 	userRole, _ := c.Get("userRole")
 
 	if userRole != "andrei" {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   "Only Andrei can access this dashboard",
-		})
+		c.Error(apperrors.Forbidden("insufficient_role", "Only Andrei can access this dashboard"))
 		return
 	}
 
 	response, err := dc.dashboardService.GetAndreiDashboard()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to retrieve dashboard data",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -55,21 +48,13 @@ func (dc *DashboardController) GetDaemonDashboard(c *gin.Context) {
 	userRole, _ := c.Get("userRole")
 
 	if userRole != "daemon" {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   "Only Daemons can access this dashboard",
-		})
+		c.Error(apperrors.Forbidden("insufficient_role", "Only Daemons can access this dashboard"))
 		return
 	}
 
 	response, err := dc.dashboardService.GetDaemonDashboard(userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to retrieve dashboard data",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
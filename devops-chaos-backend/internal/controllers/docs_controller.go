@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/openapi"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Página de Swagger UI; los assets se cargan del CDN para no versionarlos aquí
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>DevOps Chaos API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui", persistAuthorization: true });
+  </script>
+</body>
+</html>`
+
+type DocsController struct {
+	spec []byte
+}
+
+// NewDocsController serializa la especificación una sola vez
+func NewDocsController(doc *openapi.Document) (*DocsController, error) {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &DocsController{spec: spec}, nil
+}
+
+// GET /openapi.json
+func (dc *DocsController) GetSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", dc.spec)
+}
+
+// GET /docs
+func (dc *DocsController) GetUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
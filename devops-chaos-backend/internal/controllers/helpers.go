@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/services"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseIDParam lee un ID numérico de la ruta
+func parseIDParam(c *gin.Context, name, resource string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, apperrors.Validation("invalid_"+resource+"_id", fmt.Sprintf("Invalid %s ID", resource))
+	}
+	return uint(id), nil
+}
+
+// parseOptionalIDQuery lee un ID numérico opcional de la query string
+func parseOptionalIDQuery(c *gin.Context, name, resource string) (*uint, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, apperrors.Validation("invalid_"+resource+"_id", fmt.Sprintf("Invalid %s ID in '%s'", resource, name))
+	}
+	parsed := uint(id)
+	return &parsed, nil
+}
+
+// clientInfo identifica el dispositivo que abre una sesión
+func clientInfo(c *gin.Context) services.ClientInfo {
+	return services.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// bindJSON decodifica y valida el cuerpo JSON de la petición
+func bindJSON(c *gin.Context, req interface{}) error {
+	if err := c.ShouldBindJSON(req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return apperrors.From(err)
+		}
+		return apperrors.Validation("invalid_request", err.Error())
+	}
+	return nil
+}
+
+// bindForm decodifica y valida los campos de un formulario (incluido multipart)
+func bindForm(c *gin.Context, req interface{}) error {
+	if err := c.ShouldBind(req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return apperrors.From(err)
+		}
+		return apperrors.Validation("invalid_request", err.Error())
+	}
+	return nil
+}
+
+// formFile obtiene un archivo multipart obligatorio
+func formFile(c *gin.Context, field string) (*multipart.FileHeader, error) {
+	file, err := c.FormFile(field)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, apperrors.From(err)
+		}
+		return nil, apperrors.Validation("missing_file", fmt.Sprintf("A multipart field named '%s' is required", field))
+	}
+	return file, nil
+}
+
+// parseListParams lee paginación, orden, rango de fechas, búsqueda y los filtros indicados
+func parseListParams(c *gin.Context, filterKeys ...string) (dto.ListParams, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	params := dto.ListParams{
+		Page:    page,
+		Limit:   limit,
+		Cursor:  c.Query("cursor"),
+		Sort:    c.Query("sort"),
+		Order:   c.Query("order"),
+		Search:  c.Query("q"),
+		Filters: make(map[string]string),
+	}
+
+	for _, key := range filterKeys {
+		if value := c.Query(key); value != "" {
+			params.Filters[key] = value
+		}
+	}
+
+	from, err := parseDateQuery(c.Query("from"), false)
+	if err != nil {
+		return params, apperrors.Validation("invalid_from", "Invalid 'from' date, use RFC3339 or YYYY-MM-DD")
+	}
+	to, err := parseDateQuery(c.Query("to"), true)
+	if err != nil {
+		return params, apperrors.Validation("invalid_to", "Invalid 'to' date, use RFC3339 or YYYY-MM-DD")
+	}
+	params.From = from
+	params.To = to
+
+	return params, nil
+}
+
+// parseDateQuery acepta RFC3339 o YYYY-MM-DD; con endOfDay una fecha sola cubre el día completo
+func parseDateQuery(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return &parsed, nil
+	}
+
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		parsed = parsed.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/invites"
+	"devops-chaos-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InviteController struct {
+	inviteService *services.InviteService
+}
+
+func NewInviteController(cfg *config.InviteConfig, channels []invites.Channel, bus *events.Bus) *InviteController {
+	return &InviteController{
+		inviteService: services.NewInviteService(cfg, channels, bus),
+	}
+}
+
+// POST /invites
+func (ic *InviteController) CreateInvite(c *gin.Context) {
+	var req dto.CreateInviteRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	invite, err := ic.inviteService.CreateInvite(&req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Invite sent successfully",
+		Data:    invite,
+	})
+}
+
+// GET /invites
+func (ic *InviteController) GetInvites(c *gin.Context) {
+	params, err := parseListParams(c, "role", "status", "channel")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := ic.inviteService.GetInvites(params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// POST /invites/:id/resend (el cuerpo es opcional)
+func (ic *InviteController) ResendInvite(c *gin.Context) {
+	inviteID, err := parseIDParam(c, "id", "invite")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.ResendInviteRequest
+	if c.Request.ContentLength != 0 {
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	userID, _ := c.Get("userID")
+
+	invite, err := ic.inviteService.ResendInvite(inviteID, &req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Invite resent successfully, the previous link no longer works",
+		Data:    invite,
+	})
+}
+
+// DELETE /invites/:id
+func (ic *InviteController) RevokeInvite(c *gin.Context) {
+	inviteID, err := parseIDParam(c, "id", "invite")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	if err := ic.inviteService.RevokeInvite(inviteID, userID.(uint)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Invite revoked successfully",
+	})
+}
+
+// POST /auth/accept-invite (pública)
+func (ic *InviteController) AcceptInvite(c *gin.Context) {
+	var req dto.AcceptInviteRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := ic.inviteService.AcceptInvite(&req, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Invite accepted, welcome",
+		Data:    response,
+	})
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/realtime"
+	"devops-chaos-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationController struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationController(hub *realtime.Hub) *NotificationController {
+	return &NotificationController{
+		notificationService: services.NewNotificationService(hub),
+	}
+}
+
+// GET /notifications
+func (nc *NotificationController) GetNotifications(c *gin.Context) {
+	params, err := parseListParams(c, "type", "read")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := nc.notificationService.GetNotifications(params, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GET /notifications/unread-count
+func (nc *NotificationController) GetUnreadCount(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	count, err := nc.notificationService.GetUnreadCount(userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Unread count retrieved successfully",
+		Data:    count,
+	})
+}
+
+// PUT /notifications/:id/read
+func (nc *NotificationController) MarkRead(c *gin.Context) {
+	notificationID, err := parseIDParam(c, "id", "notification")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	if err := nc.notificationService.MarkRead(notificationID, userID.(uint)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Notification marked as read",
+	})
+}
+
+// PUT /notifications/read-all
+func (nc *NotificationController) MarkAllRead(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	if err := nc.notificationService.MarkAllRead(userID.(uint)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "All notifications marked as read",
+	})
+}
+
+// GET /notifications/preferences
+func (nc *NotificationController) GetPreferences(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	preferences, err := nc.notificationService.GetPreferences(userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Notification preferences retrieved successfully",
+		Data:    preferences,
+	})
+}
+
+// PUT /notifications/preferences
+func (nc *NotificationController) UpdatePreferences(c *gin.Context) {
+	var req dto.UpdateNotificationPreferencesRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	preferences, err := nc.notificationService.UpdatePreferences(&req, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Notification preferences updated successfully",
+		Data:    preferences,
+	})
+}
@@ -0,0 +1,868 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/openapi"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Roles de los middlewares de autorización
+var (
+	andreiOnly        = []string{models.RoleAndrei}
+	daemonOnly        = []string{models.RoleDaemon}
+	networkAdminOnly  = []string{models.RoleNetworkAdmin}
+	andreiAndDaemon   = []string{models.RoleAndrei, models.RoleDaemon}
+	moderators        = []string{models.RoleAndrei, models.RoleModerator}
+	resistanceContent = []string{models.RoleNetworkAdmin, models.RoleAndrei, models.RoleModerator}
+)
+
+var userStatuses = []string{models.StatusActive, models.StatusCaptured, models.StatusPunished}
+
+// endpoint describe una ruta; add completa parámetros de ruta y respuestas
+type endpoint struct {
+	id          string
+	tag         string
+	summary     string
+	description string
+	public      bool
+	roles       []string
+	params      []openapi.Parameter
+	body        interface{}                // Cuerpo JSON (un DTO)
+	content     map[string]*openapi.Schema // Cuerpo con otros tipos (multipart, CSV)
+	status      int                        // Éxito; 200 si no se indica
+	data        interface{}                // Tipo de data en la respuesta estándar
+	dataSchema  *openapi.Schema            // Ídem cuando no hay un tipo Go
+	page        interface{}                // Tipo de los items de una respuesta paginada
+	raw         *openapi.Response          // Respuesta fuera del envoltorio estándar
+	extra       map[int]*openapi.Response
+}
+
+type apiSpec struct {
+	doc *openapi.Document
+}
+
+// NewAPISpec describe todas las rutas que registra cmd/routes.go. Agregar una
+// ruta sin describirla aquí (o al revés) hace fallar los tests y el arranque
+func NewAPISpec(version string) *openapi.Document {
+	doc := openapi.NewDocument(openapi.Info{
+		Title:       "DevOps Chaos API",
+		Description: "Backend of Andrei's DevOps Chaos. Errors use application/problem+json (RFC 7807) with a stable `code`.",
+		Version:     version,
+	})
+	doc.Security = []openapi.SecurityRequirement{{"bearerAuth": {}}}
+	doc.Components.SecuritySchemes["bearerAuth"] = openapi.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+		Description:  "Token from POST /api/auth/login; it stops working when its session is revoked",
+	}
+
+	problem := map[string]openapi.MediaType{"application/problem+json": {Schema: doc.Schema(dto.ProblemDetails{})}}
+	for name, description := range map[string]string{
+		"BadRequest":   "Invalid parameters or body",
+		"Unauthorized": "Missing, invalid or revoked token",
+		"Forbidden":    "The caller's role cannot use this endpoint",
+		"NotFound":     "Resource not found",
+		"Problem":      "Error",
+	} {
+		doc.Components.Responses[name] = &openapi.Response{Description: description, Content: problem}
+	}
+
+	for _, tag := range []openapi.Tag{
+		{Name: "system", Description: "Health checks and this documentation"},
+		{Name: "auth", Description: "Login, invites and passwords"},
+		{Name: "profile", Description: "Self-service for the authenticated user"},
+		{Name: "users", Description: "User administration"},
+		{Name: "captures", Description: "Daemons capturing network admins"},
+		{Name: "reports", Description: "Reports, review comments and attachments"},
+		{Name: "punishments", Description: "Punishments assigned by Andrei"},
+		{Name: "statistics", Description: "Points, rankings and leaderboards"},
+		{Name: "squads", Description: "Daemon squads"},
+		{Name: "notifications", Description: "Per-user inbox and live events"},
+		{Name: "webhooks", Description: "Outgoing webhooks"},
+		{Name: "invites", Description: "Role-scoped invites"},
+		{Name: "trash", Description: "Soft-deleted users, reports and punishments"},
+		{Name: "audit", Description: "Audit log"},
+		{Name: "dashboard", Description: "Dashboards per role"},
+		{Name: "resistance", Description: "Network admin resistance: anonymous reports, tips and memes"},
+		{Name: "teams", Description: "Resistance teams"},
+	} {
+		doc.Tags = append(doc.Tags, tag)
+	}
+
+	s := &apiSpec{doc: doc}
+	s.system()
+	s.auth()
+	s.profile()
+	s.users()
+	s.reports()
+	s.punishments()
+	s.statistics()
+	s.squads()
+	s.notifications()
+	s.webhooks()
+	s.invites()
+	s.trash()
+	s.dashboard()
+	s.resistance()
+	s.teams()
+	return doc
+}
+
+func (s *apiSpec) system() {
+	s.add("GET", "/ping", endpoint{
+		id: "ping", tag: "system", summary: "Liveness check", public: true,
+		raw: s.jsonResponse("Server is running", openapi.Object(map[string]*openapi.Schema{"message": openapi.String()})),
+	})
+	s.add("GET", "/health", endpoint{
+		id: "health", tag: "system", summary: "Health check", public: true,
+		raw: s.jsonResponse("Server health", openapi.Object(map[string]*openapi.Schema{
+			"status": openapi.String(), "database": openapi.String(), "version": openapi.String(),
+		})),
+	})
+	s.add("GET", "/openapi.json", endpoint{
+		id: "getOpenAPISpec", tag: "system", summary: "This OpenAPI document", public: true,
+		raw: s.jsonResponse("OpenAPI 3 document", openapi.Any()),
+	})
+	s.add("GET", "/docs", endpoint{
+		id: "getAPIDocs", tag: "system", summary: "Swagger UI for this document", public: true,
+		raw: contentResponse("HTML page", "text/html", openapi.String()),
+	})
+}
+
+func (s *apiSpec) auth() {
+	s.add("POST", "/api/auth/login", endpoint{
+		id: "login", tag: "auth", summary: "Log in and open a session", public: true,
+		body: dto.LoginRequest{}, data: dto.LoginResponse{},
+	})
+	s.add("POST", "/api/auth/accept-invite", endpoint{
+		id: "acceptInvite", tag: "auth", summary: "Create an account from an invite", public: true,
+		description: "The invite token travels in the body, never in the URL.",
+		body:        dto.AcceptInviteRequest{}, status: http.StatusCreated, data: dto.LoginResponse{},
+	})
+	s.add("POST", "/api/auth/verify-email", endpoint{
+		id: "verifyEmail", tag: "auth", summary: "Confirm a new email address", public: true,
+		description: "Uses the token from the verification link; no session needed.",
+		body:        dto.VerifyEmailRequest{}, data: dto.ProfileResponse{},
+	})
+	s.add("GET", "/api/auth/me", endpoint{
+		id: "getMe", tag: "auth", summary: "Profile of the authenticated user", data: dto.ProfileResponse{},
+	})
+	s.add("POST", "/api/auth/change-password", endpoint{
+		id: "changePassword", tag: "auth", summary: "Change own password",
+		description: "Closes every other session of the user.",
+		body:        dto.ChangePasswordRequest{},
+	})
+	s.add("POST", "/api/auth/register", endpoint{
+		id: "registerUser", tag: "auth", summary: "Create a user", roles: andreiOnly,
+		body: dto.RegisterRequest{}, status: http.StatusCreated, data: dto.UserInfo{},
+	})
+}
+
+func (s *apiSpec) profile() {
+	s.add("PUT", "/api/me/profile", endpoint{
+		id: "updateProfile", tag: "profile", summary: "Edit display name and bio",
+		body: dto.UpdateProfileRequest{}, data: dto.ProfileResponse{},
+	})
+	s.add("POST", "/api/me/email", endpoint{
+		id: "requestEmailChange", tag: "profile", summary: "Request an email change",
+		description: "Sends a verification link to the new address; the email changes once it is confirmed.",
+		body:        dto.ChangeEmailRequest{}, status: http.StatusAccepted, data: dto.EmailChangeResponse{},
+	})
+	s.add("PUT", "/api/me/avatar", endpoint{
+		id: "uploadAvatar", tag: "profile", summary: "Upload or replace own avatar",
+		content: map[string]*openapi.Schema{"multipart/form-data": s.doc.FormSchema(nil, "avatar")},
+		data:    dto.ProfileResponse{},
+	})
+	s.add("DELETE", "/api/me/avatar", endpoint{
+		id: "deleteAvatar", tag: "profile", summary: "Remove own avatar",
+	})
+	s.add("GET", "/api/me/sessions", endpoint{
+		id: "getSessions", tag: "profile", summary: "Open sessions", data: []dto.SessionResponse{},
+	})
+	s.add("DELETE", "/api/me/sessions", endpoint{
+		id: "revokeOtherSessions", tag: "profile", summary: "Close every session except the current one",
+		data: dto.RevokedSessionsResponse{},
+	})
+	s.add("DELETE", "/api/me/sessions/:id", endpoint{
+		id: "revokeSession", tag: "profile", summary: "Close one session",
+	})
+	s.add("GET", "/api/me/export", endpoint{
+		id: "exportMyData", tag: "profile", summary: "Download own personal data",
+		params: []openapi.Parameter{query("format", openapi.Enum("json", "zip"), "json (default) or a zip with one file per section")},
+		raw: &openapi.Response{Description: "Personal data export", Content: map[string]openapi.MediaType{
+			"application/json": {Schema: s.doc.Schema(dto.PersonalDataExport{})},
+			"application/zip":  {Schema: openapi.Binary()},
+		}},
+	})
+}
+
+func (s *apiSpec) users() {
+	s.add("GET", "/api/users", endpoint{
+		id: "getUsers", tag: "users", summary: "List users", roles: andreiOnly,
+		description: "Only Andrei gets results; other roles receive 403.",
+		params: listParams(
+			query("role", openapi.Enum(models.Roles...), ""),
+			query("status", openapi.Enum(userStatuses...), ""),
+		),
+		page: dto.UserListItem{},
+	})
+	s.add("GET", "/api/users/:id", endpoint{
+		id: "getUser", tag: "users", summary: "Get a user", data: dto.UserInfo{},
+	})
+	s.add("GET", "/api/users/:id/avatar", endpoint{
+		id: "getAvatar", tag: "users", summary: "Avatar image of a user",
+		raw: contentResponse("Avatar image (PNG, JPEG or GIF)", "image/*", openapi.Binary()),
+	})
+	s.add("PUT", "/api/users/:id", endpoint{
+		id: "updateUser", tag: "users", summary: "Change username, email, role or status", roles: andreiOnly,
+		description: "Status must be valid for the resulting role; changing role resets status to active and " +
+			"applies its side effects (statistics, punishments, captures, squad and team membership).",
+		body: dto.UpdateUserRequest{},
+	})
+	s.add("DELETE", "/api/users/:id", endpoint{
+		id: "deleteUser", tag: "users", summary: "Delete a user (to the trash)", roles: andreiOnly,
+		params: []openapi.Parameter{query("reassign_to", openapi.ID(), "Daemon who inherits the user's open reports")},
+	})
+	s.add("GET", "/api/users/:id/deletion-preview", endpoint{
+		id: "previewUserDeletion", tag: "users", summary: "What deleting a user would change", roles: andreiOnly,
+		params: []openapi.Parameter{query("reassign_to", openapi.ID(), "Daemon who would inherit the user's open reports")},
+		data:   dto.UserDeletionPreview{},
+	})
+	s.add("POST", "/api/users/:id/anonymize", endpoint{
+		id: "anonymizeUser", tag: "users", summary: "Replace a user's personal data with a pseudonym", roles: andreiOnly,
+		data: dto.AnonymizeUserResponse{},
+	})
+	s.add("GET", "/api/users/stats", endpoint{
+		id: "getUserStats", tag: "users", summary: "User counts by role and status", roles: andreiOnly,
+		dataSchema: &openapi.Schema{Type: "object", AdditionalProperties: openapi.Integer()},
+	})
+	s.add("POST", "/api/users/import", endpoint{
+		id: "importUsers", tag: "users", summary: "Import users from CSV or JSON", roles: andreiOnly,
+		description: "All rows are validated first; if any is invalid nothing is created and the response is 422.",
+		params: []openapi.Parameter{
+			query("dry_run", openapi.Boolean(), "Validate without creating users"),
+			query("format", openapi.Enum("csv", "json"), "Defaults to the file extension or the Content-Type"),
+		},
+		content: map[string]*openapi.Schema{
+			"application/json": {OneOf: []*openapi.Schema{
+				openapi.ArrayOf(s.doc.Schema(dto.ImportUserRow{})),
+				openapi.Object(map[string]*openapi.Schema{"users": openapi.ArrayOf(s.doc.Schema(dto.ImportUserRow{}))}),
+			}},
+			"text/csv":            openapi.String(),
+			"multipart/form-data": s.doc.FormSchema(nil, "file"),
+		},
+		status: http.StatusCreated, data: dto.ImportUsersResponse{},
+		extra: map[int]*openapi.Response{
+			http.StatusOK:                  s.envelope("Dry run result; no users were created", s.doc.Schema(dto.ImportUsersResponse{})),
+			http.StatusUnprocessableEntity: s.envelope("Some rows are invalid; no users were created", s.doc.Schema(dto.ImportUsersResponse{})),
+		},
+	})
+	s.add("GET", "/api/users/export", endpoint{
+		id: "exportUsers", tag: "users", summary: "Export users as CSV or JSON", roles: andreiOnly,
+		params: []openapi.Parameter{
+			query("format", openapi.Enum("csv", "json"), "csv (default) or json"),
+			query("role", openapi.Enum(models.Roles...), ""),
+			query("status", openapi.Enum(userStatuses...), ""),
+		},
+		raw: &openapi.Response{Description: "Exported users", Content: map[string]openapi.MediaType{
+			"text/csv":         {Schema: openapi.String()},
+			"application/json": {Schema: openapi.ArrayOf(s.doc.Schema(dto.UserListItem{}))},
+		}},
+	})
+	s.add("POST", "/api/users/:id/capture", endpoint{
+		id: "captureNetworkAdmin", tag: "captures", summary: "Capture a network admin", roles: daemonOnly,
+		raw: s.jsonResponse("Capture recorded", s.doc.Schema(dto.CaptureResponse{})),
+	})
+	s.add("GET", "/api/users/:id/captures", endpoint{
+		id: "getDaemonCaptures", tag: "captures", summary: "Captures made by a daemon", roles: andreiAndDaemon,
+		data: []models.Capture{},
+	})
+	s.add("GET", "/api/users/:id/punishments/active", endpoint{
+		id: "getActivePunishments", tag: "punishments", summary: "Active punishments of a user",
+		data: []dto.PunishmentListItem{},
+	})
+	s.add("GET", "/api/captures/all", endpoint{
+		id: "getAllCaptures", tag: "captures", summary: "Every capture", roles: andreiOnly,
+		data: []models.Capture{},
+	})
+	s.add("GET", "/api/network-admins/capture", endpoint{
+		id: "getNetworkAdminsForCapture", tag: "captures", summary: "Network admins that can be captured", roles: daemonOnly,
+		data: []models.User{},
+	})
+}
+
+func (s *apiSpec) reports() {
+	s.add("POST", "/api/reports", endpoint{
+		id: "createReport", tag: "reports", summary: "Create a report",
+		body: dto.CreateReportRequest{}, status: http.StatusCreated, data: dto.ReportResponse{},
+	})
+	s.add("GET", "/api/reports", endpoint{
+		id: "getReports", tag: "reports", summary: "List reports visible to the caller",
+		params: listParams(
+			query("type", openapi.Enum(models.ReportTypeResistance, models.ReportTypeCapture, models.ReportTypeAnonymous), ""),
+			query("status", openapi.Enum(models.ReportStatusPending, models.ReportStatusInReview,
+				models.ReportStatusApproved, models.ReportStatusRejected), ""),
+			query("severity", openapi.Enum(models.ReportSeverityLow, models.ReportSeverityMedium,
+				models.ReportSeverityHigh, models.ReportSeverityCritical), ""),
+			query("author", openapi.ID(), "Author user ID"),
+			query("assignee", openapi.ID(), "Assignee user ID"),
+		),
+		page: dto.ReportListItem{},
+	})
+	s.add("GET", "/api/reports/search", endpoint{
+		id: "searchReports", tag: "reports", summary: "Full-text search over reports",
+		params: []openapi.Parameter{
+			requiredQuery("q", openapi.String(), "Search text"),
+			query("page", openapi.Integer(), ""),
+			query("limit", openapi.Integer(), ""),
+		},
+		page: dto.ReportSearchResult{},
+	})
+	s.add("GET", "/api/reports/:id", endpoint{
+		id: "getReport", tag: "reports", summary: "Get a report", data: dto.ReportResponse{},
+	})
+	s.add("PUT", "/api/reports/:id/status", endpoint{
+		id: "updateReportStatus", tag: "reports", summary: "Move a report through review", roles: andreiOnly,
+		body: dto.UpdateReportRequest{},
+	})
+	s.add("PUT", "/api/reports/:id/assignee", endpoint{
+		id: "assignReport", tag: "reports", summary: "Assign a report to a daemon", roles: andreiOnly,
+		body: dto.AssignReportRequest{}, data: dto.ReportResponse{},
+	})
+	s.add("GET", "/api/reports/:id/comments", endpoint{
+		id: "getReportComments", tag: "reports", summary: "Review comments of a report",
+		data: []dto.ReportCommentResponse{},
+	})
+	s.add("POST", "/api/reports/:id/comments", endpoint{
+		id: "addReportComment", tag: "reports", summary: "Comment on a report",
+		body: dto.CreateReportCommentRequest{}, status: http.StatusCreated, data: dto.ReportCommentResponse{},
+	})
+	s.add("GET", "/api/reports/:id/history", endpoint{
+		id: "getReportHistory", tag: "reports", summary: "Status changes of a report",
+		data: []dto.ReportHistoryItem{},
+	})
+	s.add("GET", "/api/reports/:id/attachments", endpoint{
+		id: "getReportAttachments", tag: "reports", summary: "Attachments of a report",
+		data: []dto.ReportAttachmentResponse{},
+	})
+	s.add("POST", "/api/reports/:id/attachments", endpoint{
+		id: "uploadReportAttachment", tag: "reports", summary: "Attach a file to a report",
+		description: "Network admins can only attach files to an open anonymous report, sending its receipt code.",
+		content:     map[string]*openapi.Schema{"multipart/form-data": s.doc.FormSchema(dto.UploadAttachmentRequest{}, "file")},
+		status:      http.StatusCreated, data: dto.ReportAttachmentResponse{},
+	})
+	s.add("GET", "/api/reports/:id/attachments/:attachmentId", endpoint{
+		id: "downloadReportAttachment", tag: "reports", summary: "Download an attachment",
+		raw: contentResponse("Attachment content", "application/octet-stream", openapi.Binary()),
+	})
+	s.add("DELETE", "/api/reports/:id/attachments/:attachmentId", endpoint{
+		id: "deleteReportAttachment", tag: "reports", summary: "Delete an attachment",
+	})
+	s.add("DELETE", "/api/reports/:id", endpoint{
+		id: "deleteReport", tag: "reports", summary: "Delete a report (to the trash)",
+	})
+	s.add("GET", "/api/reports/recent", endpoint{
+		id: "getRecentReports", tag: "reports", summary: "Latest reports", roles: andreiOnly,
+		params: []openapi.Parameter{query("limit", openapi.Integer(), "Defaults to 5")},
+		data:   []dto.ReportListItem{},
+	})
+}
+
+func (s *apiSpec) punishments() {
+	s.add("POST", "/api/punishments", endpoint{
+		id: "createPunishment", tag: "punishments", summary: "Punish a daemon", roles: andreiOnly,
+		body: dto.CreatePunishmentRequest{}, status: http.StatusCreated, data: dto.PunishmentResponse{},
+	})
+	s.add("GET", "/api/punishments", endpoint{
+		id: "getPunishments", tag: "punishments", summary: "List punishments visible to the caller",
+		params: listParams(
+			query("type", openapi.Enum(models.PunishmentTypeTimeout, models.PunishmentTypeDemotion,
+				models.PunishmentTypeExtraTasks, models.PunishmentTypeReward), ""),
+			query("status", openapi.Enum(models.PunishmentStatusActive, models.PunishmentStatusCompleted,
+				models.PunishmentStatusCancelled), ""),
+			query("target", openapi.ID(), "Punished user ID"),
+			query("assigner", openapi.ID(), "Assigning user ID"),
+		),
+		page: dto.PunishmentListItem{},
+	})
+	s.add("GET", "/api/punishments/:id", endpoint{
+		id: "getPunishment", tag: "punishments", summary: "Get a punishment", data: dto.PunishmentResponse{},
+	})
+	s.add("PUT", "/api/punishments/:id", endpoint{
+		id: "updatePunishment", tag: "punishments", summary: "Update a punishment", roles: andreiOnly,
+		body: dto.UpdatePunishmentRequest{},
+	})
+	s.add("DELETE", "/api/punishments/:id", endpoint{
+		id: "deletePunishment", tag: "punishments", summary: "Delete a punishment (to the trash)", roles: andreiOnly,
+	})
+}
+
+func (s *apiSpec) statistics() {
+	s.add("GET", "/api/statistics/:user_id", endpoint{
+		id: "getUserStatistics", tag: "statistics", summary: "Statistics of a user", data: dto.StatisticResponse{},
+	})
+	s.add("PUT", "/api/statistics/:user_id", endpoint{
+		id: "updateUserStatistics", tag: "statistics", summary: "Adjust a user's statistics", roles: andreiOnly,
+		body: dto.UpdateStatisticRequest{},
+	})
+	s.add("GET", "/api/statistics/leaderboard", endpoint{
+		id: "getLeaderboard", tag: "statistics", summary: "Daemon leaderboard", roles: andreiAndDaemon,
+		params: []openapi.Parameter{query("limit", openapi.Integer(), "Defaults to 10")},
+		data:   []dto.RankingItem{},
+	})
+	s.add("GET", "/api/statistics/leaderboard/squads", endpoint{
+		id: "getSquadLeaderboard", tag: "statistics", summary: "Squad leaderboard", roles: andreiAndDaemon,
+		params: []openapi.Parameter{query("limit", openapi.Integer(), "Defaults to 10")},
+		data:   []dto.SquadRankingItem{},
+	})
+	s.add("POST", "/api/statistics/recalculate-rankings", endpoint{
+		id: "recalculateRankings", tag: "statistics", summary: "Recompute every ranking", roles: andreiOnly,
+	})
+}
+
+func (s *apiSpec) squads() {
+	s.add("POST", "/api/squads", endpoint{
+		id: "createSquad", tag: "squads", summary: "Create a squad", roles: andreiOnly,
+		body: dto.CreateSquadRequest{}, status: http.StatusCreated, data: dto.SquadResponse{},
+	})
+	s.add("GET", "/api/squads", endpoint{
+		id: "getSquads", tag: "squads", summary: "List squads", roles: andreiAndDaemon, data: []dto.SquadResponse{},
+	})
+	s.add("GET", "/api/squads/:id", endpoint{
+		id: "getSquad", tag: "squads", summary: "Get a squad", roles: andreiAndDaemon, data: dto.SquadResponse{},
+	})
+	s.add("PUT", "/api/squads/:id", endpoint{
+		id: "updateSquad", tag: "squads", summary: "Update a squad", roles: andreiOnly,
+		body: dto.UpdateSquadRequest{}, data: dto.SquadResponse{},
+	})
+	s.add("DELETE", "/api/squads/:id", endpoint{
+		id: "deleteSquad", tag: "squads", summary: "Disband a squad", roles: andreiOnly,
+	})
+	s.add("POST", "/api/squads/:id/members", endpoint{
+		id: "addSquadMember", tag: "squads", summary: "Add a daemon to a squad", roles: andreiOnly,
+		body: dto.AddSquadMemberRequest{}, data: dto.SquadResponse{},
+	})
+	s.add("DELETE", "/api/squads/:id/members/:userId", endpoint{
+		id: "removeSquadMember", tag: "squads", summary: "Remove a daemon from a squad", roles: andreiOnly,
+	})
+}
+
+func (s *apiSpec) notifications() {
+	s.add("GET", "/api/stream", endpoint{
+		id: "streamEvents", tag: "notifications", summary: "Live events for the caller's role",
+		description: "Server-sent events. Starts with `ready`; `resync` means events were dropped and " +
+			"the client should reload.",
+		raw: contentResponse("Event stream", "text/event-stream", openapi.String()),
+	})
+	s.add("GET", "/api/notifications", endpoint{
+		id: "getNotifications", tag: "notifications", summary: "Own notifications",
+		params: listParams(
+			query("type", openapi.String(), "Notification type"),
+			query("read", openapi.Boolean(), ""),
+		),
+		page: dto.NotificationResponse{},
+	})
+	s.add("GET", "/api/notifications/unread-count", endpoint{
+		id: "getUnreadCount", tag: "notifications", summary: "Unread notification count", data: dto.UnreadCountResponse{},
+	})
+	s.add("PUT", "/api/notifications/read-all", endpoint{
+		id: "markAllNotificationsRead", tag: "notifications", summary: "Mark every notification as read",
+	})
+	s.add("GET", "/api/notifications/preferences", endpoint{
+		id: "getNotificationPreferences", tag: "notifications", summary: "Notification preferences",
+		data: []dto.NotificationPreferenceItem{},
+	})
+	s.add("PUT", "/api/notifications/preferences", endpoint{
+		id: "updateNotificationPreferences", tag: "notifications", summary: "Update notification preferences",
+		body: dto.UpdateNotificationPreferencesRequest{}, data: []dto.NotificationPreferenceItem{},
+	})
+	s.add("PUT", "/api/notifications/:id/read", endpoint{
+		id: "markNotificationRead", tag: "notifications", summary: "Mark a notification as read",
+	})
+}
+
+func (s *apiSpec) webhooks() {
+	s.add("POST", "/api/webhooks", endpoint{
+		id: "createWebhook", tag: "webhooks", summary: "Register a webhook", roles: andreiOnly,
+		body: dto.CreateWebhookRequest{}, status: http.StatusCreated, data: dto.WebhookResponse{},
+	})
+	s.add("GET", "/api/webhooks", endpoint{
+		id: "getWebhooks", tag: "webhooks", summary: "List webhooks", roles: andreiOnly, data: []dto.WebhookResponse{},
+	})
+	s.add("GET", "/api/webhooks/:id", endpoint{
+		id: "getWebhook", tag: "webhooks", summary: "Get a webhook", roles: andreiOnly, data: dto.WebhookResponse{},
+	})
+	s.add("PUT", "/api/webhooks/:id", endpoint{
+		id: "updateWebhook", tag: "webhooks", summary: "Update a webhook", roles: andreiOnly,
+		body: dto.UpdateWebhookRequest{}, data: dto.WebhookResponse{},
+	})
+	s.add("DELETE", "/api/webhooks/:id", endpoint{
+		id: "deleteWebhook", tag: "webhooks", summary: "Delete a webhook", roles: andreiOnly,
+	})
+	s.add("POST", "/api/webhooks/:id/secret", endpoint{
+		id: "rotateWebhookSecret", tag: "webhooks", summary: "Rotate the signing secret", roles: andreiOnly,
+		data: dto.WebhookResponse{},
+	})
+	s.add("POST", "/api/webhooks/:id/ping", endpoint{
+		id: "pingWebhook", tag: "webhooks", summary: "Queue a test delivery", roles: andreiOnly,
+		status: http.StatusAccepted, data: dto.WebhookDeliveryResponse{},
+	})
+	s.add("GET", "/api/webhooks/:id/deliveries", endpoint{
+		id: "getWebhookDeliveries", tag: "webhooks", summary: "Deliveries of a webhook", roles: andreiOnly,
+		params: listParams(
+			query("status", openapi.Enum(models.DeliveryStatusPending, models.DeliveryStatusDelivering,
+				models.DeliveryStatusSucceeded, models.DeliveryStatusDead), ""),
+			query("event_type", openapi.String(), "Event name, e.g. capture.created"),
+		),
+		page: dto.WebhookDeliveryResponse{},
+	})
+	s.add("GET", "/api/webhooks/:id/deliveries/:deliveryId", endpoint{
+		id: "getWebhookDelivery", tag: "webhooks", summary: "Get a delivery", roles: andreiOnly,
+		data: dto.WebhookDeliveryResponse{},
+	})
+	s.add("POST", "/api/webhooks/:id/deliveries/:deliveryId/redeliver", endpoint{
+		id: "redeliverWebhookDelivery", tag: "webhooks", summary: "Queue a delivery again", roles: andreiOnly,
+		status: http.StatusAccepted, data: dto.WebhookDeliveryResponse{},
+	})
+}
+
+func (s *apiSpec) invites() {
+	s.add("POST", "/api/invites", endpoint{
+		id: "createInvite", tag: "invites", summary: "Issue an invite", roles: andreiOnly,
+		body: dto.CreateInviteRequest{}, status: http.StatusCreated, data: dto.InviteResponse{},
+	})
+	s.add("GET", "/api/invites", endpoint{
+		id: "getInvites", tag: "invites", summary: "List invites", roles: andreiOnly,
+		params: listParams(
+			query("role", openapi.Enum(models.Roles...), ""),
+			query("status", openapi.Enum(models.InviteStatusPending, models.InviteStatusAccepted,
+				models.InviteStatusRevoked, models.InviteStatusExpired), ""),
+			query("channel", openapi.String(), "Delivery channel"),
+		),
+		page: dto.InviteResponse{},
+	})
+	s.add("POST", "/api/invites/:id/resend", endpoint{
+		id: "resendInvite", tag: "invites", summary: "Issue a fresh token for an invite", roles: andreiOnly,
+		body: dto.ResendInviteRequest{}, data: dto.InviteResponse{},
+	})
+	s.add("DELETE", "/api/invites/:id", endpoint{
+		id: "revokeInvite", tag: "invites", summary: "Revoke an invite", roles: andreiOnly,
+	})
+}
+
+func (s *apiSpec) trash() {
+	entity := pathParam("entity", openapi.Enum("users", "reports", "punishments"), "Kind of deleted item")
+	s.add("GET", "/api/trash/:entity", endpoint{
+		id: "getTrash", tag: "trash", summary: "List deleted items", roles: andreiOnly,
+		params: append([]openapi.Parameter{entity}, listParams(
+			query("role", openapi.Enum(models.Roles...), "Users only"),
+			query("status", openapi.String(), ""),
+			query("type", openapi.String(), "Reports and punishments"),
+			query("severity", openapi.String(), "Reports only"),
+			query("author", openapi.ID(), "Reports only"),
+			query("target", openapi.ID(), "Punishments only"),
+			query("assigner", openapi.ID(), "Punishments only"),
+		)...),
+		page: dto.TrashItem{},
+	})
+	s.add("POST", "/api/trash/:entity/:id/restore", endpoint{
+		id: "restoreTrashItem", tag: "trash", summary: "Restore a deleted item", roles: andreiOnly,
+		params: []openapi.Parameter{entity}, data: dto.TrashItem{},
+	})
+	s.add("DELETE", "/api/trash/:entity/:id", endpoint{
+		id: "purgeTrashItem", tag: "trash", summary: "Delete an item permanently", roles: andreiOnly,
+		params: []openapi.Parameter{entity},
+	})
+	s.add("GET", "/api/audit", endpoint{
+		id: "getAuditLog", tag: "audit", summary: "Audit log", roles: andreiOnly,
+		params: listParams(
+			query("event", openapi.String(), "Event name, e.g. user.updated"),
+			query("actor", openapi.ID(), "Acting user ID"),
+			query("subject", openapi.ID(), "Affected user ID"),
+		),
+		page: dto.AuditLogResponse{},
+	})
+}
+
+func (s *apiSpec) dashboard() {
+	s.add("GET", "/api/dashboard/andrei", endpoint{
+		id: "getAndreiDashboard", tag: "dashboard", summary: "Andrei's dashboard", roles: andreiOnly,
+		data: dto.AndreiDashboardResponse{},
+	})
+	s.add("GET", "/api/dashboard/daemon", endpoint{
+		id: "getDaemonDashboard", tag: "dashboard", summary: "Daemon dashboard", roles: daemonOnly,
+		data: dto.DaemonDashboardResponse{},
+	})
+}
+
+func (s *apiSpec) resistance() {
+	s.add("GET", "/api/resistance", endpoint{
+		id: "getResistancePage", tag: "resistance", summary: "Resistance page", roles: networkAdminOnly,
+		data: dto.ResistancePageResponse{},
+	})
+	s.add("GET", "/api/resistance/report/challenge", endpoint{
+		id: "getSubmissionChallenge", tag: "resistance", summary: "Proof-of-work challenge for an anonymous report",
+		data: dto.SubmissionChallenge{},
+	})
+	s.add("POST", "/api/resistance/report", endpoint{
+		id: "reportSuspiciousActivity", tag: "resistance", summary: "Submit an anonymous report",
+		body: dto.ReportSuspiciousActivityRequest{}, status: http.StatusCreated, data: dto.AnonymousReportReceipt{},
+	})
+	s.add("POST", "/api/resistance/receipts/lookup", endpoint{
+		id: "getReceiptStatus", tag: "resistance", summary: "Status of an anonymous report",
+		description: "The receipt code travels in the body, never in the URL.",
+		body:        dto.ReceiptLookupRequest{}, data: dto.ReceiptStatusResponse{},
+	})
+	s.add("POST", "/api/resistance/receipts/notes", endpoint{
+		id: "addReceiptNote", tag: "resistance", summary: "Add a note to an anonymous report",
+		body: dto.ReceiptNoteRequest{}, status: http.StatusCreated, data: dto.ReportCommentResponse{},
+	})
+
+	contentFilters := func(filters ...openapi.Parameter) []openapi.Parameter {
+		status := query("status", openapi.Enum(models.ContentStatusPending, models.ContentStatusApproved,
+			models.ContentStatusRejected), "Only moderators see pending and rejected content")
+		return listParams(append([]openapi.Parameter{status}, filters...)...)
+	}
+	s.add("GET", "/api/resistance/tips", endpoint{
+		id: "getSurvivalTips", tag: "resistance", summary: "Survival tips", roles: resistanceContent,
+		params: contentFilters(query("category", openapi.String(), ""), query("priority", openapi.String(), "")),
+		page:   dto.SurvivalTip{},
+	})
+	s.add("POST", "/api/resistance/tips", endpoint{
+		id: "submitSurvivalTip", tag: "resistance", summary: "Submit a survival tip", roles: networkAdminOnly,
+		body: dto.CreateSurvivalTipRequest{}, status: http.StatusCreated, data: dto.SurvivalTip{},
+	})
+	s.add("POST", "/api/resistance/tips/:id/vote", endpoint{
+		id: "voteSurvivalTip", tag: "resistance", summary: "Vote for a survival tip", roles: networkAdminOnly,
+		data: dto.SurvivalTip{},
+	})
+	s.add("PUT", "/api/resistance/tips/:id/moderation", endpoint{
+		id: "moderateSurvivalTip", tag: "resistance", summary: "Approve or reject a survival tip", roles: moderators,
+		body: dto.ModerateContentRequest{}, data: dto.SurvivalTip{},
+	})
+	s.add("GET", "/api/resistance/memes", endpoint{
+		id: "getResistanceMemes", tag: "resistance", summary: "Resistance memes", roles: resistanceContent,
+		params: contentFilters(), page: dto.ResistanceMeme{},
+	})
+	s.add("POST", "/api/resistance/memes", endpoint{
+		id: "submitResistanceMeme", tag: "resistance", summary: "Submit a meme", roles: networkAdminOnly,
+		content: map[string]*openapi.Schema{"multipart/form-data": s.doc.FormSchema(dto.CreateResistanceMemeRequest{}, "image")},
+		status:  http.StatusCreated, data: dto.ResistanceMeme{},
+	})
+	s.add("GET", "/api/resistance/memes/:id/image", endpoint{
+		id: "getResistanceMemeImage", tag: "resistance", summary: "Meme image", roles: resistanceContent,
+		raw: contentResponse("Meme image (PNG, JPEG or GIF)", "image/*", openapi.Binary()),
+	})
+	s.add("POST", "/api/resistance/memes/:id/vote", endpoint{
+		id: "voteResistanceMeme", tag: "resistance", summary: "Vote for a meme", roles: networkAdminOnly,
+		data: dto.ResistanceMeme{},
+	})
+	s.add("PUT", "/api/resistance/memes/:id/moderation", endpoint{
+		id: "moderateResistanceMeme", tag: "resistance", summary: "Approve or reject a meme", roles: moderators,
+		body: dto.ModerateContentRequest{}, data: dto.ResistanceMeme{},
+	})
+}
+
+func (s *apiSpec) teams() {
+	s.add("POST", "/api/teams", endpoint{
+		id: "createTeam", tag: "teams", summary: "Create a team", roles: networkAdminOnly,
+		body: dto.CreateTeamRequest{}, status: http.StatusCreated, data: dto.TeamDetail{},
+	})
+	s.add("GET", "/api/teams", endpoint{
+		id: "getTeams", tag: "teams", summary: "List teams", roles: networkAdminOnly, data: []dto.TeamSummary{},
+	})
+	s.add("GET", "/api/teams/mine", endpoint{
+		id: "getMyTeam", tag: "teams", summary: "Own team", roles: networkAdminOnly, data: dto.TeamDetail{},
+	})
+	s.add("GET", "/api/teams/:id", endpoint{
+		id: "getTeam", tag: "teams", summary: "Get a team", roles: networkAdminOnly, data: dto.TeamDetail{},
+	})
+	s.add("PUT", "/api/teams/:id", endpoint{
+		id: "updateTeam", tag: "teams", summary: "Update own team", roles: networkAdminOnly,
+		body: dto.UpdateTeamRequest{}, data: dto.TeamDetail{},
+	})
+	s.add("POST", "/api/teams/:id/members", endpoint{
+		id: "addTeamMember", tag: "teams", summary: "Add a network admin to the team", roles: networkAdminOnly,
+		body: dto.AddTeamMemberRequest{}, data: dto.TeamDetail{},
+	})
+	s.add("DELETE", "/api/teams/:id/members/:userId", endpoint{
+		id: "removeTeamMember", tag: "teams", summary: "Remove a member or leave the team", roles: networkAdminOnly,
+	})
+	s.add("GET", "/api/teams/:id/messages", endpoint{
+		id: "getTeamMessages", tag: "teams", summary: "Team board", roles: networkAdminOnly,
+		data: []dto.TeamMessageResponse{},
+	})
+	s.add("POST", "/api/teams/:id/messages", endpoint{
+		id: "postTeamMessage", tag: "teams", summary: "Post on the team board", roles: networkAdminOnly,
+		body: dto.CreateTeamMessageRequest{}, status: http.StatusCreated, data: dto.TeamMessageResponse{},
+	})
+	s.add("POST", "/api/teams/:id/contributions", endpoint{
+		id: "contributeProtection", tag: "teams", summary: "Contribute protection points", roles: networkAdminOnly,
+		body: dto.ContributeProtectionRequest{}, data: dto.TeamDetail{},
+	})
+	s.add("POST", "/api/teams/:id/rescues", endpoint{
+		id: "rescueTeamMember", tag: "teams", summary: "Rescue a captured teammate", roles: networkAdminOnly,
+		body: dto.RescueTeamMemberRequest{}, data: dto.TeamRescueResponse{},
+	})
+}
+
+// add arma la operación: parámetros de ruta (numéricos salvo que se declaren
+// en e.params), cuerpo, respuesta de éxito y respuestas de error comunes
+func (s *apiSpec) add(method, path string, e endpoint) {
+	op := &openapi.Operation{
+		Tags:        []string{e.tag},
+		Summary:     e.summary,
+		Description: e.description,
+		OperationID: e.id,
+		Responses:   make(map[string]*openapi.Response),
+		Roles:       e.roles,
+	}
+	if e.public {
+		op.Security = []openapi.SecurityRequirement{{}}
+	}
+	if len(e.roles) > 0 {
+		roles := "Roles: " + strings.Join(e.roles, ", ") + "."
+		op.Description = strings.TrimSpace(roles + " " + op.Description)
+	}
+
+	declared := make(map[string]bool)
+	for _, param := range e.params {
+		if param.In == "path" {
+			declared[param.Name] = true
+		}
+	}
+	hasPathParams := false
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		hasPathParams = true
+		name := strings.TrimPrefix(segment, ":")
+		if !declared[name] {
+			op.Parameters = append(op.Parameters, pathParam(name, openapi.ID(), ""))
+		}
+	}
+	op.Parameters = append(op.Parameters, e.params...)
+
+	switch {
+	case e.body != nil:
+		op.RequestBody = &openapi.RequestBody{Required: true, Content: map[string]openapi.MediaType{
+			"application/json": {Schema: s.doc.Schema(e.body)},
+		}}
+	case e.content != nil:
+		op.RequestBody = &openapi.RequestBody{Required: true, Content: make(map[string]openapi.MediaType)}
+		for mediaType, schema := range e.content {
+			op.RequestBody.Content[mediaType] = openapi.MediaType{Schema: schema}
+		}
+	}
+
+	status := e.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	switch {
+	case e.raw != nil:
+		op.Responses[strconv.Itoa(status)] = e.raw
+	case e.page != nil:
+		op.Responses[strconv.Itoa(status)] = s.paginated(e.page)
+	case e.dataSchema != nil:
+		op.Responses[strconv.Itoa(status)] = s.envelope(http.StatusText(status), e.dataSchema)
+	case e.data != nil:
+		op.Responses[strconv.Itoa(status)] = s.envelope(http.StatusText(status), s.doc.Schema(e.data))
+	default:
+		op.Responses[strconv.Itoa(status)] = s.envelope(http.StatusText(status), nil)
+	}
+	for code, response := range e.extra {
+		op.Responses[strconv.Itoa(code)] = response
+	}
+
+	if len(op.Parameters) > 0 || op.RequestBody != nil {
+		op.Responses["400"] = openapi.ResponseRef("BadRequest")
+	}
+	if !e.public {
+		op.Responses["401"] = openapi.ResponseRef("Unauthorized")
+	}
+	if len(e.roles) > 0 {
+		op.Responses["403"] = openapi.ResponseRef("Forbidden")
+	}
+	if hasPathParams {
+		op.Responses["404"] = openapi.ResponseRef("NotFound")
+	}
+	op.Responses["default"] = openapi.ResponseRef("Problem")
+
+	s.doc.Add(method, path, op)
+}
+
+// envelope es la respuesta estándar (dto.ApiResponse) con data del esquema indicado
+func (s *apiSpec) envelope(description string, data *openapi.Schema) *openapi.Response {
+	schema := s.doc.Schema(dto.ApiResponse{})
+	if data != nil {
+		schema = &openapi.Schema{AllOf: []*openapi.Schema{schema, {
+			Type:       "object",
+			Properties: map[string]*openapi.Schema{"data": data},
+		}}}
+	}
+	return s.jsonResponse(description, schema)
+}
+
+// paginated es dto.PaginatedResponse con data como lista de items
+func (s *apiSpec) paginated(item interface{}) *openapi.Response {
+	schema := &openapi.Schema{AllOf: []*openapi.Schema{s.doc.Schema(dto.PaginatedResponse{}), {
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"data": openapi.ArrayOf(s.doc.Schema(item))},
+	}}}
+	return s.jsonResponse("Page of results", schema)
+}
+
+func (s *apiSpec) jsonResponse(description string, schema *openapi.Schema) *openapi.Response {
+	return contentResponse(description, "application/json", schema)
+}
+
+func contentResponse(description, mediaType string, schema *openapi.Schema) *openapi.Response {
+	return &openapi.Response{
+		Description: description,
+		Content:     map[string]openapi.MediaType{mediaType: {Schema: schema}},
+	}
+}
+
+// listParams son los parámetros que lee parseListParams más los filtros
+func listParams(filters ...openapi.Parameter) []openapi.Parameter {
+	params := []openapi.Parameter{
+		query("page", openapi.Integer(), "Page number, from 1; ignored when cursor is sent"),
+		query("limit", openapi.Integer(), "Page size; capped by the server"),
+		query("cursor", openapi.String(), "Opaque cursor from next_cursor"),
+		query("sort", openapi.String(), "Public field to sort by"),
+		query("order", openapi.Enum("asc", "desc"), ""),
+		query("q", openapi.String(), "Free-text search"),
+		query("from", openapi.String(), "Start date, RFC3339 or YYYY-MM-DD"),
+		query("to", openapi.String(), "End date, RFC3339 or YYYY-MM-DD (a bare date covers the whole day)"),
+	}
+	sort.SliceStable(filters, func(i, j int) bool { return filters[i].Name < filters[j].Name })
+	return append(params, filters...)
+}
+
+func query(name string, schema *openapi.Schema, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func requiredQuery(name string, schema *openapi.Schema, description string) openapi.Parameter {
+	param := query(name, schema, description)
+	param.Required = true
+	return param
+}
+
+func pathParam(name string, schema *openapi.Schema, description string) openapi.Parameter {
+	if description == "" {
+		description = "Identifier"
+		if resource := strings.TrimSuffix(strings.TrimSuffix(name, "Id"), "_id"); resource != name {
+			description = fmt.Sprintf("%s identifier", strings.ReplaceAll(resource, "_", " "))
+		}
+	}
+	return openapi.Parameter{Name: name, In: "path", Required: true, Description: description, Schema: schema}
+}
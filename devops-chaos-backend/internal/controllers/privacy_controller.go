@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/services"
+	"devops-chaos-backend/internal/storage"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PrivacyController struct {
+	privacyService *services.PrivacyService
+}
+
+func NewPrivacyController(store storage.BlobStore, bus *events.Bus) *PrivacyController {
+	return &PrivacyController{
+		privacyService: services.NewPrivacyService(store, bus),
+	}
+}
+
+// GET /me/export?format=json|zip
+func (pc *PrivacyController) ExportMyData(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", services.PersonalDataFormatJSON))
+	userID, _ := c.Get("userID")
+
+	data, err := pc.privacyService.ExportPersonalData(userID.(uint), format)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	contentType := "application/json"
+	if format == services.PersonalDataFormatZIP {
+		contentType = "application/zip"
+	}
+	filename := fmt.Sprintf("personal-data-%s.%s", time.Now().Format("20060102"), format)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// POST /users/:id/anonymize
+func (pc *PrivacyController) AnonymizeUser(c *gin.Context) {
+	userID, err := parseIDParam(c, "id", "user")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	currentUserID, _ := c.Get("userID")
+	currentUserRole, _ := c.Get("userRole")
+
+	response, err := pc.privacyService.AnonymizeUser(userID, currentUserID.(uint), currentUserRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "User anonymized successfully",
+		Data:    response,
+	})
+}
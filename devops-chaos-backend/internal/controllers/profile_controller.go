@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/mail"
+	"devops-chaos-backend/internal/services"
+	"devops-chaos-backend/internal/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileController struct {
+	profileService *services.ProfileService
+}
+
+func NewProfileController(cfg *config.ProfileConfig, mailer mail.Mailer, store storage.BlobStore, bus *events.Bus) *ProfileController {
+	return &ProfileController{
+		profileService: services.NewProfileService(cfg, mailer, store, bus),
+	}
+}
+
+// GET /auth/me
+func (pc *ProfileController) GetProfile(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	profile, err := pc.profileService.GetProfile(userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Profile retrieved successfully",
+		Data:    profile,
+	})
+}
+
+// PUT /me/profile
+func (pc *ProfileController) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	profile, err := pc.profileService.UpdateProfile(userID.(uint), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Profile updated successfully",
+		Data:    profile,
+	})
+}
+
+// POST /me/email
+func (pc *ProfileController) RequestEmailChange(c *gin.Context) {
+	var req dto.ChangeEmailRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := pc.profileService.RequestEmailChange(userID.(uint), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.ApiResponse{
+		Success: true,
+		Message: "Check the new address to confirm the change",
+		Data:    response,
+	})
+}
+
+// POST /auth/verify-email (pública; token en el cuerpo, nunca en la URL)
+func (pc *ProfileController) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	profile, err := pc.profileService.ConfirmEmailChange(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Email changed successfully",
+		Data:    profile,
+	})
+}
+
+// PUT /me/avatar (multipart: avatar)
+func (pc *ProfileController) UploadAvatar(c *gin.Context) {
+	file, err := formFile(c, "avatar")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	profile, err := pc.profileService.UploadAvatar(c.Request.Context(), userID.(uint), file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Avatar updated successfully",
+		Data:    profile,
+	})
+}
+
+// DELETE /me/avatar
+func (pc *ProfileController) DeleteAvatar(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	if err := pc.profileService.DeleteAvatar(userID.(uint)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Avatar removed successfully",
+	})
+}
+
+// GET /users/:id/avatar
+func (pc *ProfileController) GetAvatar(c *gin.Context) {
+	userID, err := parseIDParam(c, "id", "user")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	user, reader, err := pc.profileService.OpenAvatar(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, user.AvatarSize, user.AvatarType, reader, map[string]string{
+		"X-Content-Type-Options":  "nosniff",
+		"Content-Security-Policy": "default-src 'none'; sandbox",
+		"Cache-Control":           "private, max-age=300",
+	})
+}
@@ -2,9 +2,9 @@ package controllers
 
 import (
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/services"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,21 +13,17 @@ type PunishmentController struct {
 	punishmentService *services.PunishmentService
 }
 
-func NewPunishmentController() *PunishmentController {
+func NewPunishmentController(bus *events.Bus) *PunishmentController {
 	return &PunishmentController{
-		punishmentService: services.NewPunishmentService(),
+		punishmentService: services.NewPunishmentService(bus),
 	}
 }
 
 // POST /punishments
 func (pc *PunishmentController) CreatePunishment(c *gin.Context) {
 	var req dto.CreatePunishmentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -36,11 +32,7 @@ func (pc *PunishmentController) CreatePunishment(c *gin.Context) {
 
 	response, err := pc.punishmentService.CreatePunishment(&req, assignerID.(uint), assignerRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to create punishment",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -53,20 +45,18 @@ func (pc *PunishmentController) CreatePunishment(c *gin.Context) {
 
 // GET /punishments
 func (pc *PunishmentController) GetPunishments(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	status := c.Query("status")
+	params, err := parseListParams(c, "type", "status", "target", "assigner")
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	userID, _ := c.Get("userID")
 	userRole, _ := c.Get("userRole")
 
-	response, err := pc.punishmentService.GetPunishments(page, limit, status, userID.(uint), userRole.(string))
+	response, err := pc.punishmentService.GetPunishments(params, userID.(uint), userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -75,27 +65,18 @@ func (pc *PunishmentController) GetPunishments(c *gin.Context) {
 
 // GET /punishments/:id
 func (pc *PunishmentController) GetPunishmentByID(c *gin.Context) {
-	idParam := c.Param("id")
-	punishmentID, err := strconv.ParseUint(idParam, 10, 32)
+	punishmentID, err := parseIDParam(c, "id", "punishment")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid punishment ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	userID, _ := c.Get("userID")
 	userRole, _ := c.Get("userRole")
 
-	response, err := pc.punishmentService.GetPunishmentByID(uint(punishmentID), userID.(uint), userRole.(string))
+	response, err := pc.punishmentService.GetPunishmentByID(punishmentID, userID.(uint), userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{
-			Success: false,
-			Message: "Punishment not found",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -108,36 +89,23 @@ func (pc *PunishmentController) GetPunishmentByID(c *gin.Context) {
 
 // PUT /punishments/:id
 func (pc *PunishmentController) UpdatePunishment(c *gin.Context) {
-	idParam := c.Param("id")
-	punishmentID, err := strconv.ParseUint(idParam, 10, 32)
+	punishmentID, err := parseIDParam(c, "id", "punishment")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid punishment ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	var req dto.UpdatePunishmentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
 	userRole, _ := c.Get("userRole")
 
-	err = pc.punishmentService.UpdatePunishment(uint(punishmentID), &req, userRole.(string))
+	err = pc.punishmentService.UpdatePunishment(punishmentID, &req, userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to update punishment",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -149,26 +117,17 @@ func (pc *PunishmentController) UpdatePunishment(c *gin.Context) {
 
 // DELETE /punishments/:id
 func (pc *PunishmentController) DeletePunishment(c *gin.Context) {
-	idParam := c.Param("id")
-	punishmentID, err := strconv.ParseUint(idParam, 10, 32)
+	punishmentID, err := parseIDParam(c, "id", "punishment")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid punishment ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	userRole, _ := c.Get("userRole")
 
-	err = pc.punishmentService.DeletePunishment(uint(punishmentID), userRole.(string))
+	err = pc.punishmentService.DeletePunishment(punishmentID, userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to delete punishment",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -180,27 +139,18 @@ func (pc *PunishmentController) DeletePunishment(c *gin.Context) {
 
 // GET /users/:id/punishments/active
 func (pc *PunishmentController) GetActivePunishments(c *gin.Context) {
-	idParam := c.Param("id")
-	targetID, err := strconv.ParseUint(idParam, 10, 32)
+	targetID, err := parseIDParam(c, "id", "user")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid user ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	userID, _ := c.Get("userID")
 	userRole, _ := c.Get("userRole")
 
-	punishments, err := pc.punishmentService.GetActivePunishments(uint(targetID), userID.(uint), userRole.(string))
+	punishments, err := pc.punishmentService.GetActivePunishments(targetID, userID.(uint), userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
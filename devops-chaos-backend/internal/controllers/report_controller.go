@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/services"
 	"net/http"
 	"strconv"
@@ -13,21 +14,17 @@ type ReportController struct {
 	reportService *services.ReportService
 }
 
-func NewReportController() *ReportController {
+func NewReportController(bus *events.Bus) *ReportController {
 	return &ReportController{
-		reportService: services.NewReportService(),
+		reportService: services.NewReportService(bus),
 	}
 }
 
 // POST /reports
 func (rc *ReportController) CreateReport(c *gin.Context) {
 	var req dto.CreateReportRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -36,11 +33,7 @@ func (rc *ReportController) CreateReport(c *gin.Context) {
 
 	response, err := rc.reportService.CreateReport(&req, userID.(uint), userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to create report",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -53,21 +46,35 @@ func (rc *ReportController) CreateReport(c *gin.Context) {
 
 // GET /reports
 func (rc *ReportController) GetReports(c *gin.Context) {
+	params, err := parseListParams(c, "type", "status", "severity", "author", "assignee")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	response, err := rc.reportService.GetReports(params, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GET /reports/search?q=
+func (rc *ReportController) SearchReports(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	reportType := c.Query("type")
-	status := c.Query("status")
 
 	userID, _ := c.Get("userID")
 	userRole, _ := c.Get("userRole")
 
-	response, err := rc.reportService.GetReports(page, limit, reportType, status, userID.(uint), userRole.(string))
+	response, err := rc.reportService.SearchReports(c.Query("q"), page, limit, userID.(uint), userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -76,27 +83,18 @@ func (rc *ReportController) GetReports(c *gin.Context) {
 
 // GET /reports/:id
 func (rc *ReportController) GetReportByID(c *gin.Context) {
-	idParam := c.Param("id")
-	reportID, err := strconv.ParseUint(idParam, 10, 32)
+	reportID, err := parseIDParam(c, "id", "report")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid report ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	userID, _ := c.Get("userID")
 	userRole, _ := c.Get("userRole")
 
-	response, err := rc.reportService.GetReportByID(uint(reportID), userID.(uint), userRole.(string))
+	response, err := rc.reportService.GetReportByID(reportID, userID.(uint), userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{
-			Success: false,
-			Message: "Report not found",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -109,36 +107,24 @@ func (rc *ReportController) GetReportByID(c *gin.Context) {
 
 // PUT /reports/:id/status
 func (rc *ReportController) UpdateReportStatus(c *gin.Context) {
-	idParam := c.Param("id")
-	reportID, err := strconv.ParseUint(idParam, 10, 32)
+	reportID, err := parseIDParam(c, "id", "report")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid report ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	var req dto.UpdateReportRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
+	userID, _ := c.Get("userID")
 	userRole, _ := c.Get("userRole")
 
-	err = rc.reportService.UpdateReportStatus(uint(reportID), &req, userRole.(string))
+	err = rc.reportService.UpdateReportStatus(reportID, &req, userID.(uint), userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to update report status",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -148,29 +134,128 @@ func (rc *ReportController) UpdateReportStatus(c *gin.Context) {
 	})
 }
 
+// PUT /reports/:id/assignee
+func (rc *ReportController) AssignReport(c *gin.Context) {
+	reportID, err := parseIDParam(c, "id", "report")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.AssignReportRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	report, err := rc.reportService.AssignReport(reportID, &req, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Report assigned successfully",
+		Data:    report,
+	})
+}
+
+// GET /reports/:id/comments
+func (rc *ReportController) GetComments(c *gin.Context) {
+	reportID, err := parseIDParam(c, "id", "report")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	comments, err := rc.reportService.GetComments(reportID, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Comments retrieved successfully",
+		Data:    comments,
+	})
+}
+
+// POST /reports/:id/comments
+func (rc *ReportController) AddComment(c *gin.Context) {
+	reportID, err := parseIDParam(c, "id", "report")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.CreateReportCommentRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	comment, err := rc.reportService.AddComment(reportID, &req, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Comment added successfully",
+		Data:    comment,
+	})
+}
+
+// GET /reports/:id/history
+func (rc *ReportController) GetReportHistory(c *gin.Context) {
+	reportID, err := parseIDParam(c, "id", "report")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	history, err := rc.reportService.GetHistory(reportID, userID.(uint), userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Report history retrieved successfully",
+		Data:    history,
+	})
+}
+
 // DELETE /reports/:id
 func (rc *ReportController) DeleteReport(c *gin.Context) {
-	idParam := c.Param("id")
-	reportID, err := strconv.ParseUint(idParam, 10, 32)
+	reportID, err := parseIDParam(c, "id", "report")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid report ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	userID, _ := c.Get("userID")
 	userRole, _ := c.Get("userRole")
 
-	err = rc.reportService.DeleteReport(uint(reportID), userID.(uint), userRole.(string))
+	err = rc.reportService.DeleteReport(reportID, userID.(uint), userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to delete report",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -187,11 +272,7 @@ func (rc *ReportController) GetRecentReports(c *gin.Context) {
 
 	reports, err := rc.reportService.GetRecentReports(limit, userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
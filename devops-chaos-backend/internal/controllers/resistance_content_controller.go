@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/services"
+	"devops-chaos-backend/internal/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ResistanceContentController struct {
+	contentService *services.ResistanceContentService
+}
+
+func NewResistanceContentController(store storage.BlobStore, maxUploadBytes int64) *ResistanceContentController {
+	return &ResistanceContentController{
+		contentService: services.NewResistanceContentService(store, maxUploadBytes),
+	}
+}
+
+// GET /resistance/tips
+func (rc *ResistanceContentController) GetTips(c *gin.Context) {
+	params, err := parseListParams(c, "status", "category", "priority")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userRole, _ := c.Get("userRole")
+
+	response, err := rc.contentService.GetTips(params, userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// POST /resistance/tips
+func (rc *ResistanceContentController) SubmitTip(c *gin.Context) {
+	var req dto.CreateSurvivalTipRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	tip, err := rc.contentService.SubmitTip(&req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Survival tip submitted for moderation",
+		Data:    tip,
+	})
+}
+
+// POST /resistance/tips/:id/vote
+func (rc *ResistanceContentController) VoteTip(c *gin.Context) {
+	tipID, err := parseIDParam(c, "id", "tip")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	tip, err := rc.contentService.VoteTip(tipID, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Survival tip upvoted",
+		Data:    tip,
+	})
+}
+
+// PUT /resistance/tips/:id/moderation
+func (rc *ResistanceContentController) ModerateTip(c *gin.Context) {
+	tipID, err := parseIDParam(c, "id", "tip")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.ModerateContentRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userRole, _ := c.Get("userRole")
+
+	tip, err := rc.contentService.ModerateTip(tipID, &req, userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Survival tip moderated successfully",
+		Data:    tip,
+	})
+}
+
+// GET /resistance/memes
+func (rc *ResistanceContentController) GetMemes(c *gin.Context) {
+	params, err := parseListParams(c, "status")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userRole, _ := c.Get("userRole")
+
+	response, err := rc.contentService.GetMemes(params, userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// POST /resistance/memes (multipart: title, description, image)
+func (rc *ResistanceContentController) SubmitMeme(c *gin.Context) {
+	var req dto.CreateResistanceMemeRequest
+	if err := bindForm(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	file, err := formFile(c, "image")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	meme, err := rc.contentService.SubmitMeme(c.Request.Context(), &req, file, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Meme submitted for moderation",
+		Data:    meme,
+	})
+}
+
+// GET /resistance/memes/:id/image
+func (rc *ResistanceContentController) GetMemeImage(c *gin.Context) {
+	memeID, err := parseIDParam(c, "id", "meme")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userRole, _ := c.Get("userRole")
+
+	meme, reader, err := rc.contentService.OpenMemeImage(c.Request.Context(), memeID, userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, meme.ImageSize, meme.ImageType, reader, map[string]string{
+		"X-Content-Type-Options":  "nosniff",
+		"Content-Security-Policy": "default-src 'none'; sandbox",
+		"Cache-Control":           "private, max-age=3600",
+	})
+}
+
+// POST /resistance/memes/:id/vote
+func (rc *ResistanceContentController) VoteMeme(c *gin.Context) {
+	memeID, err := parseIDParam(c, "id", "meme")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	meme, err := rc.contentService.VoteMeme(memeID, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Meme upvoted",
+		Data:    meme,
+	})
+}
+
+// PUT /resistance/memes/:id/moderation
+func (rc *ResistanceContentController) ModerateMeme(c *gin.Context) {
+	memeID, err := parseIDParam(c, "id", "meme")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.ModerateContentRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userRole, _ := c.Get("userRole")
+
+	meme, err := rc.contentService.ModerateMeme(memeID, &req, userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Meme moderated successfully",
+		Data:    meme,
+	})
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/services"
 	"net/http"
 
@@ -12,9 +13,9 @@ type ResistanceController struct {
 	resistanceService *services.ResistanceService
 }
 
-func NewResistanceController() *ResistanceController {
+func NewResistanceController(guard *services.AnonymousGuard, bus *events.Bus) *ResistanceController {
 	return &ResistanceController{
-		resistanceService: services.NewResistanceService(),
+		resistanceService: services.NewResistanceService(guard, bus),
 	}
 }
 
@@ -24,11 +25,7 @@ func (rc *ResistanceController) GetResistancePage(c *gin.Context) {
 
 	response, err := rc.resistanceService.GetResistancePage(userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -39,30 +36,84 @@ func (rc *ResistanceController) GetResistancePage(c *gin.Context) {
 	})
 }
 
+// GET /resistance/report/challenge
+func (rc *ResistanceController) GetSubmissionChallenge(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	challenge, err := rc.resistanceService.GetSubmissionChallenge(userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Submission challenge issued",
+		Data:    challenge,
+	})
+}
+
 // POST /resistance/report
 func (rc *ResistanceController) ReportSuspiciousActivity(c *gin.Context) {
 	var req dto.ReportSuspiciousActivityRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	receipt, err := rc.resistanceService.ReportSuspiciousActivity(&req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Anonymous report submitted successfully. Keep your receipt code, it cannot be recovered",
+		Data:    receipt,
+	})
+}
+
+// POST /resistance/receipts/lookup
+func (rc *ResistanceController) GetReceiptStatus(c *gin.Context) {
+	var req dto.ReceiptLookupRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	status, err := rc.resistanceService.GetReceiptStatus(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Report status retrieved successfully",
+		Data:    status,
+	})
+}
+
+// POST /resistance/receipts/notes
+func (rc *ResistanceController) AddReceiptNote(c *gin.Context) {
+	var req dto.ReceiptNoteRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	err := rc.resistanceService.ReportSuspiciousActivity(&req)
+	note, err := rc.resistanceService.AddReceiptNote(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to submit report",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, dto.ApiResponse{
 		Success: true,
-		Message: "Anonymous report submitted successfully",
+		Message: "Follow-up note added successfully",
+		Data:    note,
 	})
 }
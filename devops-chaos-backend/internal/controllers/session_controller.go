@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionController struct {
+	sessionService *services.SessionService
+}
+
+func NewSessionController(sessionService *services.SessionService) *SessionController {
+	return &SessionController{sessionService: sessionService}
+}
+
+// GET /me/sessions
+func (sc *SessionController) GetSessions(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sessionID, _ := c.Get("sessionID")
+
+	sessions, err := sc.sessionService.GetSessions(userID.(uint), sessionID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Sessions retrieved successfully",
+		Data:    sessions,
+	})
+}
+
+// DELETE /me/sessions/:id (puede ser la actual: equivale a cerrar sesión)
+func (sc *SessionController) RevokeSession(c *gin.Context) {
+	sessionID, err := parseIDParam(c, "id", "session")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	if err := sc.sessionService.RevokeSession(userID.(uint), sessionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Session revoked successfully",
+	})
+}
+
+// DELETE /me/sessions (todas menos la actual)
+func (sc *SessionController) RevokeOtherSessions(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sessionID, _ := c.Get("sessionID")
+
+	revoked, err := sc.sessionService.RevokeOtherSessions(userID.(uint), sessionID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Other sessions revoked successfully",
+		Data:    dto.RevokedSessionsResponse{Revoked: revoked},
+	})
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SquadController struct {
+	squadService *services.SquadService
+}
+
+func NewSquadController() *SquadController {
+	return &SquadController{
+		squadService: services.NewSquadService(),
+	}
+}
+
+// POST /squads
+func (sc *SquadController) CreateSquad(c *gin.Context) {
+	var req dto.CreateSquadRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	squad, err := sc.squadService.CreateSquad(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Squad created successfully",
+		Data:    squad,
+	})
+}
+
+// GET /squads
+func (sc *SquadController) GetSquads(c *gin.Context) {
+	squads, err := sc.squadService.GetSquads()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Squads retrieved successfully",
+		Data:    squads,
+	})
+}
+
+// GET /squads/:id
+func (sc *SquadController) GetSquad(c *gin.Context) {
+	squadID, err := parseIDParam(c, "id", "squad")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	squad, err := sc.squadService.GetSquad(squadID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Squad retrieved successfully",
+		Data:    squad,
+	})
+}
+
+// PUT /squads/:id
+func (sc *SquadController) UpdateSquad(c *gin.Context) {
+	squadID, err := parseIDParam(c, "id", "squad")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.UpdateSquadRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	squad, err := sc.squadService.UpdateSquad(squadID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Squad updated successfully",
+		Data:    squad,
+	})
+}
+
+// DELETE /squads/:id
+func (sc *SquadController) DeleteSquad(c *gin.Context) {
+	squadID, err := parseIDParam(c, "id", "squad")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := sc.squadService.DeleteSquad(squadID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Squad deleted successfully",
+	})
+}
+
+// POST /squads/:id/members
+func (sc *SquadController) AddMember(c *gin.Context) {
+	squadID, err := parseIDParam(c, "id", "squad")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.AddSquadMemberRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	squad, err := sc.squadService.AddMember(squadID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Member added successfully",
+		Data:    squad,
+	})
+}
+
+// DELETE /squads/:id/members/:userId
+func (sc *SquadController) RemoveMember(c *gin.Context) {
+	squadID, err := parseIDParam(c, "id", "squad")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	memberID, err := parseIDParam(c, "userId", "member")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := sc.squadService.RemoveMember(squadID, memberID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Member removed successfully",
+	})
+}
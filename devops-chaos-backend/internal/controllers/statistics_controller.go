@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/services"
 	"net/http"
 	"strconv"
@@ -13,35 +14,26 @@ type StatisticsController struct {
 	statisticsService *services.StatisticsService
 }
 
-func NewStatisticsController() *StatisticsController {
+func NewStatisticsController(bus *events.Bus) *StatisticsController {
 	return &StatisticsController{
-		statisticsService: services.NewStatisticsService(),
+		statisticsService: services.NewStatisticsService(bus),
 	}
 }
 
 // GET /statistics/:user_id
 func (sc *StatisticsController) GetUserStatistics(c *gin.Context) {
-	idParam := c.Param("user_id")
-	targetUserID, err := strconv.ParseUint(idParam, 10, 32)
+	targetUserID, err := parseIDParam(c, "user_id", "user")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid user ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	userID, _ := c.Get("userID")
 	userRole, _ := c.Get("userRole")
 
-	stats, err := sc.statisticsService.GetUserStatistics(uint(targetUserID), userID.(uint), userRole.(string))
+	stats, err := sc.statisticsService.GetUserStatistics(targetUserID, userID.(uint), userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -59,11 +51,7 @@ func (sc *StatisticsController) GetLeaderboard(c *gin.Context) {
 
 	leaderboard, err := sc.statisticsService.GetLeaderboard(limit, userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -74,38 +62,43 @@ func (sc *StatisticsController) GetLeaderboard(c *gin.Context) {
 	})
 }
 
+// GET /statistics/leaderboard/squads
+func (sc *StatisticsController) GetSquadLeaderboard(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	userRole, _ := c.Get("userRole")
+
+	leaderboard, err := sc.statisticsService.GetSquadLeaderboard(limit, userRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Squad leaderboard retrieved successfully",
+		Data:    leaderboard,
+	})
+}
+
 // PUT /statistics/:user_id
 func (sc *StatisticsController) UpdateStatistics(c *gin.Context) {
-	idParam := c.Param("user_id")
-	targetUserID, err := strconv.ParseUint(idParam, 10, 32)
+	targetUserID, err := parseIDParam(c, "user_id", "user")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid user ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	var req dto.UpdateStatisticRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
 	userRole, _ := c.Get("userRole")
 
-	err = sc.statisticsService.UpdateStatistics(uint(targetUserID), &req, userRole.(string))
+	err = sc.statisticsService.UpdateStatistics(targetUserID, &req, userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to update statistics",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -121,11 +114,7 @@ func (sc *StatisticsController) RecalculateRankings(c *gin.Context) {
 
 	err := sc.statisticsService.RecalculateRankings(userRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to recalculate rankings",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
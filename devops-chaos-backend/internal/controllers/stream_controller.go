@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/realtime"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StreamController struct {
+	hub       *realtime.Hub
+	heartbeat time.Duration
+}
+
+func NewStreamController(hub *realtime.Hub, heartbeat time.Duration) *StreamController {
+	return &StreamController{
+		hub:       hub,
+		heartbeat: heartbeat,
+	}
+}
+
+// GET /stream (Server-Sent Events)
+func (sc *StreamController) Stream(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+
+	// La conexión vive más que SERVER_WRITE_TIMEOUT
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		c.Error(err)
+		return
+	}
+
+	sub := sc.hub.Subscribe(userID.(uint), userRole.(string))
+	defer sc.hub.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Sin buffer en nginx
+	c.Status(http.StatusOK)
+
+	writeSSE(c, 0, "ready", gin.H{"role": userRole})
+
+	ticker := time.NewTicker(sc.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-sub.Done():
+			if sub.Overflowed() {
+				// El cliente quedó atrás: debe recargar el dashboard y reconectar
+				writeSSE(c, 0, "resync", gin.H{"reason": "slow_consumer"})
+			}
+			return
+		case event := <-sub.Events():
+			writeSSE(c, event.ID, event.Type, event.Data)
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeSSE(c *gin.Context, id uint64, event string, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+
+	if id > 0 {
+		fmt.Fprintf(c.Writer, "id: %d\n", id)
+	}
+	fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, payload)
+	c.Writer.Flush()
+}
@@ -0,0 +1,293 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TeamController struct {
+	teamService *services.TeamService
+}
+
+func NewTeamController(bus *events.Bus) *TeamController {
+	return &TeamController{
+		teamService: services.NewTeamService(bus),
+	}
+}
+
+// POST /teams
+func (tc *TeamController) CreateTeam(c *gin.Context) {
+	var req dto.CreateTeamRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	team, err := tc.teamService.CreateTeam(&req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Team created successfully",
+		Data:    team,
+	})
+}
+
+// GET /teams
+func (tc *TeamController) GetTeams(c *gin.Context) {
+	teams, err := tc.teamService.GetTeams()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Teams retrieved successfully",
+		Data:    teams,
+	})
+}
+
+// GET /teams/mine
+func (tc *TeamController) GetMyTeam(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	team, err := tc.teamService.GetMyTeam(userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Team retrieved successfully",
+		Data:    team,
+	})
+}
+
+// GET /teams/:id
+func (tc *TeamController) GetTeam(c *gin.Context) {
+	teamID, err := parseIDParam(c, "id", "team")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	team, err := tc.teamService.GetTeam(teamID, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Team retrieved successfully",
+		Data:    team,
+	})
+}
+
+// PUT /teams/:id
+func (tc *TeamController) UpdateTeam(c *gin.Context) {
+	teamID, err := parseIDParam(c, "id", "team")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.UpdateTeamRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	team, err := tc.teamService.UpdateTeam(teamID, &req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Team updated successfully",
+		Data:    team,
+	})
+}
+
+// POST /teams/:id/members
+func (tc *TeamController) AddMember(c *gin.Context) {
+	teamID, err := parseIDParam(c, "id", "team")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.AddTeamMemberRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	team, err := tc.teamService.AddMember(teamID, &req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Member added successfully",
+		Data:    team,
+	})
+}
+
+// DELETE /teams/:id/members/:userId
+func (tc *TeamController) RemoveMember(c *gin.Context) {
+	teamID, err := parseIDParam(c, "id", "team")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	memberID, err := parseIDParam(c, "userId", "member")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	if err := tc.teamService.RemoveMember(teamID, memberID, userID.(uint)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Member removed successfully",
+	})
+}
+
+// GET /teams/:id/messages
+func (tc *TeamController) GetMessages(c *gin.Context) {
+	teamID, err := parseIDParam(c, "id", "team")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	messages, err := tc.teamService.GetMessages(teamID, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Messages retrieved successfully",
+		Data:    messages,
+	})
+}
+
+// POST /teams/:id/messages
+func (tc *TeamController) PostMessage(c *gin.Context) {
+	teamID, err := parseIDParam(c, "id", "team")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.CreateTeamMessageRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	message, err := tc.teamService.PostMessage(teamID, &req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Message posted successfully",
+		Data:    message,
+	})
+}
+
+// POST /teams/:id/contributions
+func (tc *TeamController) Contribute(c *gin.Context) {
+	teamID, err := parseIDParam(c, "id", "team")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.ContributeProtectionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	team, err := tc.teamService.Contribute(teamID, &req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Protection points contributed",
+		Data:    team,
+	})
+}
+
+// POST /teams/:id/rescues
+func (tc *TeamController) RescueMember(c *gin.Context) {
+	teamID, err := parseIDParam(c, "id", "team")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.RescueTeamMemberRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	rescue, err := tc.teamService.RescueMember(teamID, &req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Rescue completed, member has escaped",
+		Data:    rescue,
+	})
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashController struct {
+	trashService *services.TrashService
+}
+
+func NewTrashController(trashService *services.TrashService) *TrashController {
+	return &TrashController{
+		trashService: trashService,
+	}
+}
+
+// GET /trash/:entity (users, reports o punishments)
+func (tc *TrashController) GetTrash(c *gin.Context) {
+	params, err := parseListParams(c, "role", "status", "type", "severity", "author", "target", "assigner")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := tc.trashService.GetTrash(c.Param("entity"), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// POST /trash/:entity/:id/restore
+func (tc *TrashController) Restore(c *gin.Context) {
+	id, err := parseIDParam(c, "id", "trash_item")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	item, err := tc.trashService.Restore(c.Param("entity"), id, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Item restored successfully",
+		Data:    item,
+	})
+}
+
+// DELETE /trash/:entity/:id
+func (tc *TrashController) Purge(c *gin.Context) {
+	id, err := parseIDParam(c, "id", "trash_item")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	if err := tc.trashService.Purge(c.Param("entity"), id, userID.(uint)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Item permanently deleted",
+	})
+}
@@ -1,10 +1,18 @@
 package controllers
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/services"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,9 +21,9 @@ type UserController struct {
 	userService *services.UserService
 }
 
-func NewUserController() *UserController {
+func NewUserController(bus *events.Bus) *UserController {
 	return &UserController{
-		userService: services.NewUserService(),
+		userService: services.NewUserService(bus),
 	}
 }
 
@@ -23,18 +31,16 @@ func NewUserController() *UserController {
 func (uc *UserController) GetUsers(c *gin.Context) {
 	currentUserRole, _ := c.Get("userRole")
 
-	// Parámetros de paginación
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	role := c.Query("role")
+	// Parámetros de paginación, orden y filtros
+	params, err := parseListParams(c, "role", "status")
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	response, err := uc.userService.GetUsers(page, limit, role, currentUserRole.(string))
+	response, err := uc.userService.GetUsers(params, currentUserRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -43,26 +49,17 @@ func (uc *UserController) GetUsers(c *gin.Context) {
 
 // GET /users/:id
 func (uc *UserController) GetUserByID(c *gin.Context) {
-	idParam := c.Param("id")
-	userID, err := strconv.ParseUint(idParam, 10, 32)
+	userID, err := parseIDParam(c, "id", "user")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid user ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	currentUserRole, _ := c.Get("userRole")
 
-	userInfo, err := uc.userService.GetByID(uint(userID), currentUserRole.(string))
+	userInfo, err := uc.userService.GetByID(userID, currentUserRole.(string))
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{
-			Success: false,
-			Message: "User not found",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -75,36 +72,24 @@ func (uc *UserController) GetUserByID(c *gin.Context) {
 
 // PUT /users/:id
 func (uc *UserController) UpdateUser(c *gin.Context) {
-	idParam := c.Param("id")
-	userID, err := strconv.ParseUint(idParam, 10, 32)
+	userID, err := parseIDParam(c, "id", "user")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid user ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	var req dto.UpdateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
+	currentUserID, _ := c.Get("userID")
 	currentUserRole, _ := c.Get("userRole")
 
-	err = uc.userService.UpdateUser(uint(userID), &req, currentUserRole.(string))
+	err = uc.userService.UpdateUser(userID, &req, currentUserID.(uint), currentUserRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to update user",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -114,28 +99,26 @@ func (uc *UserController) UpdateUser(c *gin.Context) {
 	})
 }
 
-// DELETE /users/:id
+// DELETE /users/:id?reassign_to=<daemon>
 func (uc *UserController) DeleteUser(c *gin.Context) {
-	idParam := c.Param("id")
-	userID, err := strconv.ParseUint(idParam, 10, 32)
+	userID, err := parseIDParam(c, "id", "user")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid user ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
+	reassignTo, err := parseOptionalIDQuery(c, "reassign_to", "user")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	currentUserID, _ := c.Get("userID")
 	currentUserRole, _ := c.Get("userRole")
 
-	err = uc.userService.DeleteUser(uint(userID), currentUserRole.(string))
+	err = uc.userService.DeleteUser(userID, reassignTo, currentUserID.(uint), currentUserRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Failed to delete user",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -145,17 +128,42 @@ func (uc *UserController) DeleteUser(c *gin.Context) {
 	})
 }
 
+// GET /users/:id/deletion-preview
+func (uc *UserController) PreviewDeletion(c *gin.Context) {
+	userID, err := parseIDParam(c, "id", "user")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	reassignTo, err := parseOptionalIDQuery(c, "reassign_to", "user")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	currentUserRole, _ := c.Get("userRole")
+
+	preview, err := uc.userService.PreviewDeletion(userID, reassignTo, currentUserRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Deletion preview generated successfully",
+		Data:    preview,
+	})
+}
+
 // GET /users/stats
 func (uc *UserController) GetUserStats(c *gin.Context) {
 	currentUserRole, _ := c.Get("userRole")
 
 	stats, err := uc.userService.GetUserStats(currentUserRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -166,29 +174,121 @@ func (uc *UserController) GetUserStats(c *gin.Context) {
 	})
 }
 
-// POST /users/:id/capture
-func (uc *UserController) CaptureNetworkAdmin(c *gin.Context) {
-	idParam := c.Param("id")
-	targetID, err := strconv.ParseUint(idParam, 10, 32)
+// POST /users/import?dry_run=true
+// Acepta un archivo multipart "file" o el CSV/JSON directo en el cuerpo
+func (uc *UserController) ImportUsers(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid user ID",
-			Error:   err.Error(),
-		})
+		c.Error(apperrors.Validation("invalid_dry_run", "dry_run must be true or false"))
 		return
 	}
 
+	var body io.Reader = c.Request.Body
+	name := ""
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := formFile(c, "file")
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			c.Error(apperrors.Internal(err))
+			return
+		}
+		defer file.Close()
+		body = file
+		name = header.Filename
+	}
+
+	format := userFileFormat(c.Query("format"), name, c.ContentType())
 	currentUserID, _ := c.Get("userID")
 	currentUserRole, _ := c.Get("userRole")
 
-	err = uc.userService.CaptureNetworkAdmin(uint(targetID), currentUserID.(uint), currentUserRole.(string))
+	response, err := uc.userService.ImportUsers(body, format, dryRun, currentUserID.(uint), currentUserRole.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+		c.Error(err)
+		return
+	}
+
+	switch {
+	case len(response.Errors) > 0:
+		c.JSON(http.StatusUnprocessableEntity, dto.ApiResponse{
 			Success: false,
-			Message: "Failed to capture network admin",
-			Error:   err.Error(),
+			Message: "Some rows are invalid; no users were created",
+			Data:    response,
 		})
+	case dryRun:
+		c.JSON(http.StatusOK, dto.ApiResponse{
+			Success: true,
+			Message: "Dry run completed; no users were created",
+			Data:    response,
+		})
+	default:
+		c.JSON(http.StatusCreated, dto.ApiResponse{
+			Success: true,
+			Message: "Users imported successfully",
+			Data:    response,
+		})
+	}
+}
+
+// GET /users/export?format=csv|json
+func (uc *UserController) ExportUsers(c *gin.Context) {
+	format := c.DefaultQuery("format", services.UserFormatCSV)
+	currentUserRole, _ := c.Get("userRole")
+
+	data, err := uc.userService.ExportUsers(format, c.Query("role"), c.Query("status"), currentUserRole.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == services.UserFormatJSON {
+		contentType = "application/json"
+	}
+	filename := fmt.Sprintf("users-%s.%s", time.Now().Format("20060102"), format)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// userFileFormat elige csv o json: por el parámetro, la extensión del archivo
+// o el Content-Type, en ese orden
+func userFileFormat(query, filename, contentType string) string {
+	if query != "" {
+		return strings.ToLower(query)
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return services.UserFormatCSV
+	case ".json":
+		return services.UserFormatJSON
+	}
+	switch contentType {
+	case "text/csv", "application/csv":
+		return services.UserFormatCSV
+	case "application/json":
+		return services.UserFormatJSON
+	}
+	return ""
+}
+
+// POST /users/:id/capture
+func (uc *UserController) CaptureNetworkAdmin(c *gin.Context) {
+	targetID, err := parseIDParam(c, "id", "user")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	currentUserID, _ := c.Get("userID")
+	currentUserRole, _ := c.Get("userRole")
+
+	err = uc.userService.CaptureNetworkAdmin(targetID, currentUserID.(uint), currentUserRole.(string))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -201,27 +301,18 @@ func (uc *UserController) CaptureNetworkAdmin(c *gin.Context) {
 
 // GET /users/:id/captures
 func (uc *UserController) GetDaemonCaptures(c *gin.Context) {
-	idParam := c.Param("id")
-	daemonID, err := strconv.ParseUint(idParam, 10, 32)
+	daemonID, err := parseIDParam(c, "id", "daemon")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid daemon ID",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	currentUserID, _ := c.Get("userID")
 	currentUserRole, _ := c.Get("userRole")
 
-	captures, err := uc.userService.GetDaemonCaptures(uint(daemonID), currentUserRole.(string), currentUserID.(uint))
+	captures, err := uc.userService.GetDaemonCaptures(daemonID, currentUserRole.(string), currentUserID.(uint))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -238,11 +329,7 @@ func (uc *UserController) GetAllCaptures(c *gin.Context) {
 
 	captures, err := uc.userService.GetAllCaptures(currentUserRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -259,11 +346,7 @@ func (uc *UserController) GetNetworkAdminsForCapture(c *gin.Context) {
 
 	admins, err := uc.userService.GetNetworkAdminsForCapture(currentUserRole.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse{
-			Success: false,
-			Message: "Access denied",
-			Error:   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookController() *WebhookController {
+	return &WebhookController{
+		webhookService: services.NewWebhookService(),
+	}
+}
+
+// POST /webhooks
+func (wc *WebhookController) CreateWebhook(c *gin.Context) {
+	var req dto.CreateWebhookRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	webhook, err := wc.webhookService.CreateWebhook(&req, userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Webhook created successfully, store the secret now",
+		Data:    webhook,
+	})
+}
+
+// GET /webhooks
+func (wc *WebhookController) GetWebhooks(c *gin.Context) {
+	webhooks, err := wc.webhookService.GetWebhooks()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Webhooks retrieved successfully",
+		Data:    webhooks,
+	})
+}
+
+// GET /webhooks/:id
+func (wc *WebhookController) GetWebhook(c *gin.Context) {
+	webhookID, err := parseIDParam(c, "id", "webhook")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	webhook, err := wc.webhookService.GetWebhook(webhookID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Webhook retrieved successfully",
+		Data:    webhook,
+	})
+}
+
+// PUT /webhooks/:id
+func (wc *WebhookController) UpdateWebhook(c *gin.Context) {
+	webhookID, err := parseIDParam(c, "id", "webhook")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	webhook, err := wc.webhookService.UpdateWebhook(webhookID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Webhook updated successfully",
+		Data:    webhook,
+	})
+}
+
+// DELETE /webhooks/:id
+func (wc *WebhookController) DeleteWebhook(c *gin.Context) {
+	webhookID, err := parseIDParam(c, "id", "webhook")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := wc.webhookService.DeleteWebhook(webhookID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// POST /webhooks/:id/secret
+func (wc *WebhookController) RotateSecret(c *gin.Context) {
+	webhookID, err := parseIDParam(c, "id", "webhook")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	webhook, err := wc.webhookService.RotateSecret(webhookID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Webhook secret rotated, store the new secret now",
+		Data:    webhook,
+	})
+}
+
+// POST /webhooks/:id/ping
+func (wc *WebhookController) Ping(c *gin.Context) {
+	webhookID, err := parseIDParam(c, "id", "webhook")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	delivery, err := wc.webhookService.Ping(webhookID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.ApiResponse{
+		Success: true,
+		Message: "Ping queued for delivery",
+		Data:    delivery,
+	})
+}
+
+// GET /webhooks/:id/deliveries
+func (wc *WebhookController) GetDeliveries(c *gin.Context) {
+	webhookID, err := parseIDParam(c, "id", "webhook")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	params, err := parseListParams(c, "status", "event_type")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := wc.webhookService.GetDeliveries(webhookID, params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GET /webhooks/:id/deliveries/:deliveryId
+func (wc *WebhookController) GetDelivery(c *gin.Context) {
+	webhookID, err := parseIDParam(c, "id", "webhook")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	deliveryID, err := parseIDParam(c, "deliveryId", "delivery")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	delivery, err := wc.webhookService.GetDelivery(webhookID, deliveryID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Webhook delivery retrieved successfully",
+		Data:    delivery,
+	})
+}
+
+// POST /webhooks/:id/deliveries/:deliveryId/redeliver
+func (wc *WebhookController) Redeliver(c *gin.Context) {
+	webhookID, err := parseIDParam(c, "id", "webhook")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	deliveryID, err := parseIDParam(c, "deliveryId", "delivery")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	delivery, err := wc.webhookService.Redeliver(webhookID, deliveryID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.ApiResponse{
+		Success: true,
+		Message: "Delivery queued again",
+		Data:    delivery,
+	})
+}
@@ -0,0 +1,47 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+type AuditDAO struct{}
+
+func NewAuditDAO() *AuditDAO {
+	return &AuditDAO{}
+}
+
+var auditListSpec = &ListSpec{
+	SortFields: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+		"event":      "event_name",
+	},
+	FilterFields: map[string]string{
+		"event":   "event_name",
+		"actor":   "actor_id",
+		"subject": "subject_id",
+	},
+	FilterKinds: map[string]string{
+		"actor":   FilterKindID,
+		"subject": FilterKindID,
+	},
+	SearchColumns: []string{"summary"},
+	DateColumn:    "created_at",
+	DefaultSort:   "created_at",
+	DefaultOrder:  "desc",
+}
+
+// Registrar una entrada; si el evento ya estaba registrado no hace nada
+func (dao *AuditDAO) Create(entry *models.AuditLog) error {
+	return config.DB.
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}}, DoNothing: true}).
+		Create(entry).Error
+}
+
+// Listar el registro con paginación, filtros y búsqueda
+func (dao *AuditDAO) List(q *ListQuery) ([]models.AuditLog, *PageInfo, error) {
+	return findPage[models.AuditLog](auditListSpec, q)
+}
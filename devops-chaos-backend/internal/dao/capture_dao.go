@@ -1,8 +1,12 @@
 package dao
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/config"
 	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type CaptureDAO struct{}
@@ -17,6 +21,27 @@ func (dao *CaptureDAO) Create(capture *models.Capture) error {
 	return db.Create(capture).Error
 }
 
+// Crear la captura marcando al objetivo como capturado en la misma transacción.
+// Devuelve un conflicto si el objetivo ya no es un network admin libre
+func (dao *CaptureDAO) CreateForTarget(capture *models.Capture, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		// Solo una de dos capturas concurrentes encuentra al objetivo libre
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND role = ? AND status <> ?", capture.TargetID, models.RoleNetworkAdmin, models.StatusCaptured).
+			Update("status", models.StatusCaptured)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("already_captured", "Network admin is already captured")
+		}
+		if err := tx.Create(capture).Error; err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
 // Obtener capturas por daemon
 func (dao *CaptureDAO) FindByDaemonID(daemonID uint) ([]models.Capture, error) {
 	var captures []models.Capture
@@ -88,4 +113,37 @@ func (dao *CaptureDAO) UpdateStatus(captureID uint, status string) error {
 	return db.Model(&models.Capture{}).
 		Where("id = ?", captureID).
 		Update("status", status).Error
-}
\ No newline at end of file
+}
+
+// Contar capturas ocurridas en un rango
+func (dao *CaptureDAO) CountInRange(timeRange TimeRange) (int64, error) {
+	var count int64
+	db := config.GetDatabase()
+
+	err := db.Model(&models.Capture{}).
+		Scopes(timeRange.scope("capture_date")).
+		Count(&count).Error
+
+	return count, err
+}
+
+// Capturas por día entre from y to, agrupadas en la zona horaria indicada
+func (dao *CaptureDAO) CountByDay(from, to time.Time, location *time.Location) ([]DailyCount, error) {
+	db := config.GetDatabase()
+	return countByDay(db.Model(&models.Capture{}), "capture_date", from, to, location)
+}
+
+// Captura vigente de un network admin
+func (dao *CaptureDAO) FindActiveByTargetID(targetID uint) (*models.Capture, error) {
+	var capture models.Capture
+	db := config.GetDatabase()
+
+	err := db.Where("target_id = ? AND status = ?", targetID, models.CaptureStatusCaptured).
+		Order("capture_date DESC").
+		First(&capture).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &capture, nil
+}
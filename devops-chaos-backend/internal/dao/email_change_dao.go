@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EmailChangeDAO struct{}
+
+func NewEmailChangeDAO() *EmailChangeDAO {
+	return &EmailChangeDAO{}
+}
+
+// Crear un cambio de email; los pendientes anteriores del usuario dejan de valer
+func (dao *EmailChangeDAO) Create(change *models.EmailChange) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND confirmed_at IS NULL", change.UserID).
+			Delete(&models.EmailChange{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+// Buscar cambio por el hash de su token
+func (dao *EmailChangeDAO) FindByTokenHash(tokenHash string) (*models.EmailChange, error) {
+	var change models.EmailChange
+	err := config.DB.Where("token_hash = ?", tokenHash).First(&change).Error
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// Último cambio pendiente y vigente del usuario
+func (dao *EmailChangeDAO) FindPendingByUser(userID uint, now time.Time) (*models.EmailChange, error) {
+	var change models.EmailChange
+	err := config.DB.
+		Where("user_id = ? AND confirmed_at IS NULL AND expires_at > ?", userID, now).
+		Order("id DESC").
+		First(&change).Error
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// Confirmar el cambio y aplicar el email nuevo en una transacción. Devuelve
+// gorm.ErrRecordNotFound si el cambio ya se usó o venció, y
+// gorm.ErrDuplicatedKey si otro usuario tomó el email mientras tanto
+func (dao *EmailChangeDAO) Confirm(change *models.EmailChange, now time.Time, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.EmailChange{}).
+			Where("id = ? AND confirmed_at IS NULL AND expires_at > ?", change.ID, now).
+			Update("confirmed_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		result = tx.Model(&models.User{}).
+			Where("id = ?", change.UserID).
+			Update("email", change.NewEmail)
+		if result.Error != nil {
+			return translateError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Borrar los cambios vencidos antes de cutoff que nunca se confirmaron
+func (dao *EmailChangeDAO) PurgeExpired(cutoff time.Time) (int64, error) {
+	result := config.DB.Where("confirmed_at IS NULL AND expires_at < ?", cutoff).Delete(&models.EmailChange{})
+	return result.RowsAffected, result.Error
+}
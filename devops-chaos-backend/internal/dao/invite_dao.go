@@ -0,0 +1,173 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type InviteDAO struct{}
+
+func NewInviteDAO() *InviteDAO {
+	return &InviteDAO{}
+}
+
+var inviteListSpec = &ListSpec{
+	SortFields: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+		"expires_at": "expires_at",
+	},
+	FilterFields: map[string]string{
+		"role":    "role",
+		"channel": "channel",
+	},
+	SearchColumns: []string{"email"},
+	DateColumn:    "created_at",
+	DefaultSort:   "created_at",
+	DefaultOrder:  "desc",
+}
+
+// InvitesWithStatus restringe por estado; "pending" y "expired" se distinguen
+// por la fecha de expiración
+func InvitesWithStatus(status string, now time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch status {
+		case models.InviteStatusPending:
+			return db.Where("status = ? AND expires_at > ?", models.InviteStatusPending, now)
+		case models.InviteStatusExpired:
+			return db.Where("status = ? AND expires_at <= ?", models.InviteStatusPending, now)
+		default:
+			return db.Where("status = ?", status)
+		}
+	}
+}
+
+// Crear invitación y registrar sus eventos en la misma transacción
+func (dao *InviteDAO) Create(invite *models.Invite, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(invite).Error; err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Buscar invitación por ID
+func (dao *InviteDAO) FindByID(id uint) (*models.Invite, error) {
+	var invite models.Invite
+	err := config.DB.First(&invite, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// Buscar invitación por el hash de su token
+func (dao *InviteDAO) FindByTokenHash(tokenHash string) (*models.Invite, error) {
+	var invite models.Invite
+	err := config.DB.Where("token_hash = ?", tokenHash).First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// Listar invitaciones con paginación, filtros y búsqueda
+func (dao *InviteDAO) List(q *ListQuery) ([]models.Invite, *PageInfo, error) {
+	return findPage[models.Invite](inviteListSpec, q)
+}
+
+// Verificar si hay una invitación vigente para el email
+func (dao *InviteDAO) ExistsPendingForEmail(email string, now time.Time) (bool, error) {
+	var count int64
+	err := config.DB.Model(&models.Invite{}).
+		Where("email = ? AND status = ? AND expires_at > ?", email, models.InviteStatusPending, now).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Guardar el reenvío (token y expiración nuevos) solo si la invitación sigue
+// pendiente; devuelve gorm.ErrRecordNotFound si ya no lo está
+func (dao *InviteDAO) SaveResend(invite *models.Invite, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Invite{}).
+			Where("id = ? AND status = ?", invite.ID, models.InviteStatusPending).
+			Updates(map[string]interface{}{
+				"token_hash":   invite.TokenHash,
+				"channel":      invite.Channel,
+				"expires_at":   invite.ExpiresAt,
+				"sent_count":   invite.SentCount,
+				"last_sent_at": invite.LastSentAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Revocar una invitación pendiente; devuelve gorm.ErrRecordNotFound si ya no lo está
+func (dao *InviteDAO) Revoke(id uint, revokedAt time.Time, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Invite{}).
+			Where("id = ? AND status = ?", id, models.InviteStatusPending).
+			Updates(map[string]interface{}{
+				"status":     models.InviteStatusRevoked,
+				"revoked_at": revokedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Aceptar la invitación y crear el usuario (con estadísticas si es daemon) en
+// una sola transacción. La marca es condicional, así un token no se usa dos
+// veces; devuelve gorm.ErrRecordNotFound si la invitación ya no es válida
+func (dao *InviteDAO) Accept(invite *models.Invite, user *models.User, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.Invite{}).
+			Where("id = ? AND status = ? AND expires_at > ?", invite.ID, models.InviteStatusPending, now).
+			Updates(map[string]interface{}{
+				"status":      models.InviteStatusAccepted,
+				"accepted_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		if user.Role == models.RoleDaemon {
+			if err := tx.Create(&models.Statistic{UserID: user.ID}).Error; err != nil {
+				return err
+			}
+		}
+
+		err := tx.Model(&models.Invite{}).Where("id = ?", invite.ID).
+			Update("accepted_user_id", user.ID).Error
+		if err != nil {
+			return err
+		}
+
+		invite.Status = models.InviteStatusAccepted
+		invite.AcceptedAt = &now
+		invite.AcceptedUserID = &user.ID
+		return writeOutbox(tx, outbox)
+	})
+}
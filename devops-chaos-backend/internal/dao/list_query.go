@@ -0,0 +1,333 @@
+package dao
+
+import (
+	"context"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// ListQuery combina los parámetros del cliente con restricciones impuestas por el servicio
+type ListQuery struct {
+	dto.ListParams
+	Scopes []func(*gorm.DB) *gorm.DB
+}
+
+// NewListQuery crea una consulta a partir de los parámetros del cliente
+func NewListQuery(params dto.ListParams) *ListQuery {
+	return &ListQuery{ListParams: params}
+}
+
+// Scope agrega una condición obligatoria (p.ej. visibilidad por rol)
+func (q *ListQuery) Scope(scope func(*gorm.DB) *gorm.DB) *ListQuery {
+	q.Scopes = append(q.Scopes, scope)
+	return q
+}
+
+// Tipos de filtro que se validan antes de llegar a la base de datos
+const (
+	FilterKindID   = "id"
+	FilterKindBool = "bool"
+)
+
+// ListSpec declara qué campos de una tabla se pueden ordenar, filtrar y buscar
+type ListSpec struct {
+	SortFields    map[string]string // campo público -> columna
+	FilterFields  map[string]string // campo público -> columna
+	FilterKinds   map[string]string // campo público -> FilterKindID o FilterKindBool; el resto es texto
+	SearchColumns []string
+	DateColumn    string
+	DefaultSort   string
+	DefaultOrder  string
+	Preloads      []string
+}
+
+// PageInfo describe la página devuelta
+type PageInfo struct {
+	Page       int
+	Limit      int
+	Total      int64
+	TotalPages int
+	NextCursor string
+	HasMore    bool
+}
+
+// ToResponse arma la respuesta paginada estándar
+func (p *PageInfo) ToResponse(message string, data interface{}) *dto.PaginatedResponse {
+	return &dto.PaginatedResponse{
+		Success:    true,
+		Message:    message,
+		Data:       data,
+		Page:       p.Page,
+		Limit:      p.Limit,
+		TotalItems: p.Total,
+		TotalPages: p.TotalPages,
+		NextCursor: p.NextCursor,
+		HasMore:    p.HasMore,
+	}
+}
+
+// cursorPayload es el contenido del cursor opaco (keyset sobre columna de orden + id)
+type cursorPayload struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Kind  string `json:"k"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// findPage ejecuta una consulta de listado según spec y q
+func findPage[T any](spec *ListSpec, q *ListQuery) ([]T, *PageInfo, error) {
+	if err := q.normalize(spec); err != nil {
+		return nil, nil, err
+	}
+	sortColumn := spec.SortFields[q.Sort]
+
+	query := config.DB.Model(new(T)).Scopes(q.Scopes...)
+
+	for key, value := range q.Filters {
+		if value == "" {
+			continue
+		}
+		column, ok := spec.FilterFields[key]
+		if !ok {
+			return nil, nil, apperrors.Validation("invalid_filter", fmt.Sprintf("Cannot filter by %q", key))
+		}
+		typed, err := parseFilter(spec.FilterKinds[key], key, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		query = query.Where(column+" = ?", typed)
+	}
+
+	if spec.DateColumn != "" {
+		if q.From != nil {
+			query = query.Where(spec.DateColumn+" >= ?", *q.From)
+		}
+		if q.To != nil {
+			query = query.Where(spec.DateColumn+" <= ?", *q.To)
+		}
+	}
+
+	if q.Search != "" && len(spec.SearchColumns) > 0 {
+		pattern := "%" + escapeLike(strings.ToLower(q.Search)) + "%"
+		conditions := make([]string, len(spec.SearchColumns))
+		args := make([]interface{}, len(spec.SearchColumns))
+		for i, column := range spec.SearchColumns {
+			conditions[i] = "LOWER(" + column + ") LIKE ?"
+			args[i] = pattern
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+
+	// A partir de aquí cada finalizador trabaja sobre una copia de las condiciones
+	query = query.Session(&gorm.Session{})
+
+	info := &PageInfo{Page: q.Page, Limit: q.Limit}
+	direction := strings.ToUpper(q.Order)
+
+	if q.Cursor == "" {
+		if err := query.Count(&info.Total).Error; err != nil {
+			return nil, nil, err
+		}
+		info.TotalPages = int(math.Ceil(float64(info.Total) / float64(q.Limit)))
+		query = query.Offset((q.Page - 1) * q.Limit)
+	} else {
+		cursor, err := decodeCursor(q.Cursor)
+		if err != nil || cursor.Sort != q.Sort || cursor.Order != q.Order {
+			return nil, nil, apperrors.Validation("invalid_cursor", "Cursor is invalid or does not match the requested sort")
+		}
+		value, err := cursor.typedValue()
+		if err != nil {
+			return nil, nil, apperrors.Validation("invalid_cursor", "Cursor is invalid or does not match the requested sort")
+		}
+
+		operator := ">"
+		if direction == "DESC" {
+			operator = "<"
+		}
+		query = query.Where(
+			fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", sortColumn, operator, sortColumn, operator),
+			value, value, cursor.ID,
+		)
+		info.Page = 0
+	}
+
+	for _, preload := range spec.Preloads {
+		query = query.Preload(preload)
+	}
+
+	var items []T
+	err := query.
+		Order(fmt.Sprintf("%s %s, id %s", sortColumn, direction, direction)).
+		Limit(q.Limit + 1).
+		Find(&items).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(items) > q.Limit {
+		items = items[:q.Limit]
+		info.HasMore = true
+
+		next, err := encodeCursor(q, sortColumn, &items[len(items)-1])
+		if err != nil {
+			return nil, nil, err
+		}
+		info.NextCursor = next
+	}
+
+	return items, info, nil
+}
+
+// parseFilter convierte el valor de un filtro a su tipo; un valor inválido es un
+// error del cliente, no de la base de datos
+func parseFilter(kind, key, value string) (interface{}, error) {
+	switch kind {
+	case FilterKindID:
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || id == 0 {
+			return nil, apperrors.Validation("invalid_filter", fmt.Sprintf("Filter %q must be a positive integer ID", key))
+		}
+		return uint(id), nil
+	case FilterKindBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, apperrors.Validation("invalid_filter", fmt.Sprintf("Filter %q must be true or false", key))
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
+
+// normalize aplica valores por defecto y valida orden y tamaño de página
+func (q *ListQuery) normalize(spec *ListSpec) error {
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit > MaxPageSize {
+		q.Limit = MaxPageSize
+	}
+
+	if q.Sort == "" {
+		q.Sort = spec.DefaultSort
+	}
+	if _, ok := spec.SortFields[q.Sort]; !ok {
+		return apperrors.Validation("invalid_sort", fmt.Sprintf("Cannot sort by %q", q.Sort))
+	}
+
+	q.Order = strings.ToLower(q.Order)
+	if q.Order == "" {
+		q.Order = spec.DefaultOrder
+	}
+	if q.Order != "asc" && q.Order != "desc" {
+		return apperrors.Validation("invalid_order", "Order must be 'asc' or 'desc'")
+	}
+
+	return nil
+}
+
+func encodeCursor(q *ListQuery, sortColumn string, last interface{}) (string, error) {
+	value, id, err := cursorValues(last, sortColumn)
+	if err != nil {
+		return "", err
+	}
+
+	payload := cursorPayload{Sort: q.Sort, Order: q.Order, ID: id}
+	switch v := value.(type) {
+	case time.Time:
+		payload.Kind = "time"
+		payload.Value = v.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		payload.Kind = "time"
+		payload.Value = v.UTC().Format(time.RFC3339Nano)
+	case gorm.DeletedAt:
+		payload.Kind = "time"
+		payload.Value = v.Time.UTC().Format(time.RFC3339Nano)
+	case string:
+		payload.Kind = "string"
+		payload.Value = v
+	default:
+		payload.Kind = "number"
+		payload.Value = fmt.Sprint(v)
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor(encoded string) (*cursorPayload, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+func (p *cursorPayload) typedValue() (interface{}, error) {
+	switch p.Kind {
+	case "time":
+		return time.Parse(time.RFC3339Nano, p.Value)
+	case "number":
+		n := json.Number(p.Value)
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		return n.Float64()
+	case "string":
+		return p.Value, nil
+	default:
+		return nil, fmt.Errorf("unknown cursor kind %q", p.Kind)
+	}
+}
+
+// cursorValues extrae la columna de orden y el ID de una fila usando el esquema de GORM
+func cursorValues(row interface{}, column string) (interface{}, uint, error) {
+	stmt := &gorm.Statement{DB: config.DB}
+	if err := stmt.Parse(row); err != nil {
+		return nil, 0, err
+	}
+
+	rv := reflect.ValueOf(row)
+	sortField := stmt.Schema.LookUpField(column)
+	idField := stmt.Schema.LookUpField("id")
+	if sortField == nil || idField == nil {
+		return nil, 0, fmt.Errorf("column %q not found in %s", column, stmt.Schema.Name)
+	}
+
+	value, _ := sortField.ValueOf(context.Background(), rv)
+	id, _ := idField.ValueOf(context.Background(), rv)
+	return value, id.(uint), nil
+}
+
+// escapeLike escapa los comodines de LIKE en texto libre
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
@@ -0,0 +1,113 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/models"
+	"encoding/base64"
+	"os"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// TestMain abre el dialecto sin conectarse: los cursores solo necesitan el esquema de GORM
+func TestMain(m *testing.M) {
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 dbname=unused"), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		panic(err)
+	}
+	config.DB = db
+	os.Exit(m.Run())
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 3, 14, 15, 9, 26, 535897932, time.FixedZone("CET", 3600))
+	deletedAt := time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC)
+	report := &models.Report{
+		ID:            42,
+		Title:         "Daemon patrol",
+		SeverityLevel: 3,
+		CreatedAt:     createdAt,
+		DeletedAt:     gorm.DeletedAt{Time: deletedAt, Valid: true},
+	}
+
+	tests := []struct {
+		name     string
+		sort     string
+		order    string
+		column   string
+		wantKind string
+		want     interface{}
+	}{
+		{"time column keeps nanoseconds", "created_at", "desc", "created_at", "time", createdAt},
+		{"soft delete column", "deleted_at", "desc", "deleted_at", "time", deletedAt},
+		{"number column", "severity", "asc", "severity_level", "number", int64(3)},
+		{"string column", "title", "asc", "title", "string", "Daemon patrol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := encodeCursor(NewListQuery(dto.ListParams{Sort: tt.sort, Order: tt.order}), tt.column, report)
+			if err != nil {
+				t.Fatalf("encodeCursor: %v", err)
+			}
+
+			cursor, err := decodeCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+			if cursor.Sort != tt.sort || cursor.Order != tt.order || cursor.ID != report.ID || cursor.Kind != tt.wantKind {
+				t.Fatalf("cursor = %+v, want sort %q order %q id %d kind %q", cursor, tt.sort, tt.order, report.ID, tt.wantKind)
+			}
+
+			value, err := cursor.typedValue()
+			if err != nil {
+				t.Fatalf("typedValue: %v", err)
+			}
+			if want, ok := tt.want.(time.Time); ok {
+				got, isTime := value.(time.Time)
+				if !isTime || !got.Equal(want) {
+					t.Fatalf("value = %v, want %v", value, want)
+				}
+				return
+			}
+			if value != tt.want {
+				t.Fatalf("value = %#v, want %#v", value, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsGarbage(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"not base64", "%%%"},
+		{"not JSON", base64.RawURLEncoding.EncodeToString([]byte("daemon"))},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"id"}`))},
+	}
+
+	for _, tt := range tests {
+		if _, err := decodeCursor(tt.encoded); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestCursorTypedValueRejectsBadValues(t *testing.T) {
+	tests := []cursorPayload{
+		{Kind: "time", Value: "yesterday"},
+		{Kind: "number", Value: "forty-two"},
+		{Kind: "blob", Value: "x"},
+	}
+
+	for _, payload := range tests {
+		if _, err := payload.typedValue(); err == nil {
+			t.Errorf("typedValue(%+v): expected an error", payload)
+		}
+	}
+}
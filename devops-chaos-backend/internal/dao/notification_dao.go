@@ -0,0 +1,122 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationDAO struct{}
+
+func NewNotificationDAO() *NotificationDAO {
+	return &NotificationDAO{}
+}
+
+var notificationListSpec = &ListSpec{
+	SortFields: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+	},
+	FilterFields: map[string]string{
+		"type": "type",
+		"read": "read",
+	},
+	FilterKinds: map[string]string{
+		"read": FilterKindBool,
+	},
+	SearchColumns: []string{"title", "body"},
+	DateColumn:    "created_at",
+	DefaultSort:   "created_at",
+	DefaultOrder:  "desc",
+}
+
+// NotificationsOf restringe a la bandeja de un usuario
+func NotificationsOf(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID)
+	}
+}
+
+// Crear notificaciones
+func (dao *NotificationDAO) CreateBatch(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return config.DB.Create(&notifications).Error
+}
+
+// Listar notificaciones con paginación, filtros y búsqueda
+func (dao *NotificationDAO) List(q *ListQuery) ([]models.Notification, *PageInfo, error) {
+	return findPage[models.Notification](notificationListSpec, q)
+}
+
+// Cantidad de no leídas
+func (dao *NotificationDAO) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := config.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND read = ?", userID, false).
+		Count(&count).Error
+	return count, err
+}
+
+// Marcar una notificación como leída; devuelve false si no es del usuario
+func (dao *NotificationDAO) MarkRead(userID, id uint) (bool, error) {
+	var notification models.Notification
+	err := config.DB.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if notification.Read {
+		return true, nil
+	}
+
+	err = config.DB.Model(&notification).Updates(map[string]interface{}{
+		"read":    true,
+		"read_at": time.Now(),
+	}).Error
+	return err == nil, err
+}
+
+// Marcar todas como leídas
+func (dao *NotificationDAO) MarkAllRead(userID uint) (int64, error) {
+	result := config.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND read = ?", userID, false).
+		Updates(map[string]interface{}{
+			"read":    true,
+			"read_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// Preferencias guardadas de un usuario
+func (dao *NotificationDAO) FindPreferences(userID uint) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := config.DB.Where("user_id = ?", userID).Find(&preferences).Error
+	return preferences, err
+}
+
+// Usuarios (de entre userIDs) que desactivaron el tipo
+func (dao *NotificationDAO) FindOptedOut(userIDs []uint, notificationType string) ([]uint, error) {
+	var optedOut []uint
+	err := config.DB.Model(&models.NotificationPreference{}).
+		Where("user_id IN ? AND type = ? AND enabled = ?", userIDs, notificationType, false).
+		Pluck("user_id", &optedOut).Error
+	return optedOut, err
+}
+
+// Guardar preferencias
+func (dao *NotificationDAO) SavePreferences(preferences []models.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+	return config.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(&preferences).Error
+}
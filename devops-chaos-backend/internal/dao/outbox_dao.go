@@ -0,0 +1,103 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxWriter escribe eventos de dominio dentro de la transacción de un DAO
+type OutboxWriter func(tx *gorm.DB) error
+
+// writeOutbox ejecuta el writer si lo hay
+func writeOutbox(tx *gorm.DB, outbox OutboxWriter) error {
+	if outbox == nil {
+		return nil
+	}
+	return outbox(tx)
+}
+
+// claimEvent registra que subscriber aplicó eventID dentro de tx; devuelve false
+// si ya estaba registrado. Sin eventID (fuera del bus) siempre aplica
+func claimEvent(tx *gorm.DB, subscriber, eventID string) (bool, error) {
+	if eventID == "" {
+		return true, nil
+	}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ProcessedEvent{
+		Subscriber: subscriber,
+		EventID:    eventID,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+type OutboxDAO struct{}
+
+func NewOutboxDAO() *OutboxDAO {
+	return &OutboxDAO{}
+}
+
+// Insertar eventos con la transacción dada
+func (dao *OutboxDAO) Append(tx *gorm.DB, events []models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Create(&events).Error
+}
+
+// ClaimDue toma hasta limit eventos vencidos y los marca en proceso hasta lease;
+// si el lease vence sin resultado se vuelven a tomar
+func (dao *OutboxDAO) ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []string{models.OutboxStatusPending, models.OutboxStatusProcessing}, now).
+			Order("id ASC").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(events))
+		for i := range events {
+			ids[i] = events[i].ID
+		}
+		return tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":          models.OutboxStatusProcessing,
+				"next_attempt_at": now.Add(lease),
+			}).Error
+	})
+	return events, err
+}
+
+// Guardar el resultado de un intento
+func (dao *OutboxDAO) SaveAttempt(event *models.OutboxEvent) error {
+	return config.DB.Model(event).
+		Select("status", "attempts", "next_attempt_at", "last_error", "processed_at").
+		Updates(event).Error
+}
+
+// Borrar eventos procesados antes de before, junto con el registro de eventos
+// aplicados de ese periodo (sus filas del outbox ya no se pueden repetir)
+func (dao *OutboxDAO) PurgeProcessed(before time.Time) (int64, error) {
+	var removed int64
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Where("status = ? AND processed_at < ?", models.OutboxStatusProcessed, before).
+			Delete(&models.OutboxEvent{})
+		if result.Error != nil {
+			return result.Error
+		}
+		removed = result.RowsAffected
+		return tx.Where("created_at < ?", before).Delete(&models.ProcessedEvent{}).Error
+	})
+	return removed, err
+}
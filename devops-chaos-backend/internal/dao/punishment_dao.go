@@ -3,10 +3,42 @@ package dao
 import (
 	"devops-chaos-backend/internal/config"
 	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type PunishmentDAO struct{}
 
+// Campos de castigos disponibles para orden, filtros y búsqueda
+var punishmentListSpec = &ListSpec{
+	SortFields: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+		"type":       "type",
+		"status":     "status",
+	},
+	FilterFields: map[string]string{
+		"type":     "type",
+		"status":   "status",
+		"target":   "target_id",
+		"assigner": "assigned_by",
+	},
+	FilterKinds: map[string]string{
+		"target":   FilterKindID,
+		"assigner": FilterKindID,
+	},
+	SearchColumns: []string{"description"},
+	DateColumn:    "created_at",
+	DefaultSort:   "created_at",
+	DefaultOrder:  "desc",
+	Preloads:      []string{"Target", "Assigner"},
+}
+
+// Castigos en la papelera
+var punishmentTrashSpec = trashSpec(punishmentListSpec)
+
 func NewPunishmentDAO() *PunishmentDAO {
 	return &PunishmentDAO{}
 }
@@ -16,6 +48,24 @@ func (dao *PunishmentDAO) Create(punishment *models.Punishment) error {
 	return config.DB.Create(punishment).Error
 }
 
+// Crear castigo y, si targetStatus no está vacío, aplicarlo al objetivo
+func (dao *PunishmentDAO) CreateWithTargetStatus(punishment *models.Punishment, targetStatus string, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(punishment).Error; err != nil {
+			return err
+		}
+		if targetStatus != "" {
+			err := tx.Model(&models.User{}).
+				Where("id = ?", punishment.TargetID).
+				Update("status", targetStatus).Error
+			if err != nil {
+				return err
+			}
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
 // Buscar castigo por ID
 func (dao *PunishmentDAO) FindByID(id uint) (*models.Punishment, error) {
 	var punishment models.Punishment
@@ -44,6 +94,18 @@ func (dao *PunishmentDAO) FindAll(page, limit int) ([]models.Punishment, int64,
 	return punishments, total, err
 }
 
+// Listar castigos con filtros, orden y paginación (offset o cursor)
+func (dao *PunishmentDAO) List(q *ListQuery) ([]models.Punishment, *PageInfo, error) {
+	return findPage[models.Punishment](punishmentListSpec, q)
+}
+
+// PunishmentsByTarget restringe el listado a los castigos de un usuario
+func PunishmentsByTarget(targetID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("target_id = ?", targetID)
+	}
+}
+
 // Listar castigos por objetivo (target)
 func (dao *PunishmentDAO) FindByTarget(targetID uint, page, limit int) ([]models.Punishment, int64, error) {
 	var punishments []models.Punishment
@@ -64,6 +126,16 @@ func (dao *PunishmentDAO) FindByTarget(targetID uint, page, limit int) ([]models
 	return punishments, total, err
 }
 
+// Todos los castigos de un objetivo, en orden cronológico
+func (dao *PunishmentDAO) FindAllByTarget(targetID uint) ([]models.Punishment, error) {
+	var punishments []models.Punishment
+	err := config.DB.Preload("Target").Preload("Assigner").
+		Where("target_id = ?", targetID).
+		Order("created_at ASC, id ASC").
+		Find(&punishments).Error
+	return punishments, err
+}
+
 // Listar castigos por quien los asignó
 func (dao *PunishmentDAO) FindByAssigner(assignerID uint, page, limit int) ([]models.Punishment, int64, error) {
 	var punishments []models.Punishment
@@ -129,3 +201,52 @@ func (dao *PunishmentDAO) FindActiveByTarget(targetID uint) ([]models.Punishment
 		Find(&punishments).Error
 	return punishments, err
 }
+
+// Listar castigos eliminados
+func (dao *PunishmentDAO) ListTrashed(q *ListQuery) ([]models.Punishment, *PageInfo, error) {
+	return findPage[models.Punishment](punishmentTrashSpec, q.Scope(Trashed))
+}
+
+// Buscar castigo eliminado por ID
+func (dao *PunishmentDAO) FindTrashedByID(id uint) (*models.Punishment, error) {
+	var punishment models.Punishment
+	err := Trashed(config.DB).Preload("Target").Preload("Assigner").First(&punishment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &punishment, nil
+}
+
+// Restaurar castigo eliminado y, si targetStatus no está vacío, volver a
+// aplicarlo al objetivo
+func (dao *PunishmentDAO) Restore(punishment *models.Punishment, targetStatus string, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := restoreTrashed(tx, &models.Punishment{}, punishment.ID); err != nil {
+			return err
+		}
+		if targetStatus != "" {
+			err := tx.Model(&models.User{}).
+				Where("id = ?", punishment.TargetID).
+				Update("status", targetStatus).Error
+			if err != nil {
+				return err
+			}
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Borrar definitivamente un castigo eliminado
+func (dao *PunishmentDAO) Purge(id uint, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := purgeTrashed(tx, &models.Punishment{}, id); err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// IDs de castigos eliminados antes de cutoff, a partir de afterID
+func (dao *PunishmentDAO) FindDeletedBefore(cutoff time.Time, afterID uint, limit int) ([]uint, error) {
+	return findDeletedBefore[models.Punishment](cutoff, afterID, limit)
+}
@@ -0,0 +1,68 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"time"
+)
+
+type ReportAttachmentDAO struct{}
+
+func NewReportAttachmentDAO() *ReportAttachmentDAO {
+	return &ReportAttachmentDAO{}
+}
+
+// Registrar adjunto
+func (dao *ReportAttachmentDAO) Create(attachment *models.ReportAttachment) error {
+	return config.DB.Create(attachment).Error
+}
+
+// Buscar adjunto de un reporte
+func (dao *ReportAttachmentDAO) FindByID(reportID, id uint) (*models.ReportAttachment, error) {
+	var attachment models.ReportAttachment
+	err := config.DB.Preload("Uploader").
+		Where("report_id = ?", reportID).
+		First(&attachment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// Adjuntos de un reporte en orden de subida
+func (dao *ReportAttachmentDAO) FindByReport(reportID uint) ([]models.ReportAttachment, error) {
+	var attachments []models.ReportAttachment
+	err := config.DB.Preload("Uploader").
+		Where("report_id = ?", reportID).
+		Order("created_at ASC, id ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// Contar adjuntos de un reporte
+func (dao *ReportAttachmentDAO) CountByReport(reportID uint) (int64, error) {
+	var count int64
+	err := config.DB.Model(&models.ReportAttachment{}).Where("report_id = ?", reportID).Count(&count).Error
+	return count, err
+}
+
+// Eliminar adjunto (soft delete)
+func (dao *ReportAttachmentDAO) Delete(id uint) error {
+	return config.DB.Delete(&models.ReportAttachment{}, id).Error
+}
+
+// Adjuntos eliminados antes de cutoff, a partir de afterID
+func (dao *ReportAttachmentDAO) FindDeletedBefore(cutoff time.Time, afterID uint, limit int) ([]models.ReportAttachment, error) {
+	var attachments []models.ReportAttachment
+	err := config.DB.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND id > ?", cutoff, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// Borrar definitivamente un adjunto eliminado
+func (dao *ReportAttachmentDAO) Purge(id uint) error {
+	return purgeTrashed(config.DB, &models.ReportAttachment{}, id)
+}
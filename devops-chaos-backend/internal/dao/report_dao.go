@@ -1,12 +1,65 @@
 package dao
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/config"
 	"devops-chaos-backend/internal/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Marcadores de resaltado en los fragmentos de búsqueda; el servicio los convierte a HTML
+const (
+	HighlightStart = "\x02"
+	HighlightStop  = "\x03"
 )
 
+// Fila de resultado de búsqueda de texto completo
+type ReportSearchRow struct {
+	ID                 uint
+	Rank               float64
+	TitleSnippet       string
+	DescriptionSnippet string
+}
+
 type ReportDAO struct{}
 
+// Campos de reportes disponibles para orden, filtros y búsqueda
+var reportListSpec = &ListSpec{
+	SortFields: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+		"title":      "title",
+		"type":       "type",
+		"status":     "status",
+		"severity":   "severity_level",
+	},
+	FilterFields: map[string]string{
+		"type":     "type",
+		"status":   "status",
+		"severity": "severity",
+		"author":   "author_id",
+		"assignee": "assignee_id",
+	},
+	FilterKinds: map[string]string{
+		"author":   FilterKindID,
+		"assignee": FilterKindID,
+	},
+	SearchColumns: []string{"title", "description", "location"},
+	DateColumn:    "created_at",
+	DefaultSort:   "created_at",
+	DefaultOrder:  "desc",
+	Preloads:      []string{"Author"},
+}
+
+// Reportes en la papelera
+var reportTrashSpec = trashSpec(reportListSpec)
+
 func NewReportDAO() *ReportDAO {
 	return &ReportDAO{}
 }
@@ -16,10 +69,84 @@ func (dao *ReportDAO) Create(report *models.Report) error {
 	return config.DB.Create(report).Error
 }
 
+// Crear reporte junto con la entrada inicial de su historial
+func (dao *ReportDAO) CreateWithHistory(report *models.Report, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := createWithHistory(tx, report); err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Crear reporte anónimo con su historial y el hash de su código de recibo
+func (dao *ReportDAO) CreateWithReceipt(report *models.Report, codeHash string, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := createWithHistory(tx, report); err != nil {
+			return err
+		}
+		err := tx.Create(&models.AnonymousReceipt{
+			ReportID: report.ID,
+			CodeHash: codeHash,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+func createWithHistory(tx *gorm.DB, report *models.Report) error {
+	if err := tx.Create(report).Error; err != nil {
+		return err
+	}
+	return tx.Create(&models.ReportStatusChange{
+		ReportID:    report.ID,
+		ToStatus:    report.Status,
+		ChangedByID: report.AuthorID,
+	}).Error
+}
+
+// Buscar el reporte asociado a un código de recibo
+func (dao *ReportDAO) FindByReceiptHash(codeHash string) (*models.Report, error) {
+	var report models.Report
+	err := config.DB.
+		Joins("JOIN anonymous_receipts ON anonymous_receipts.report_id = reports.id").
+		Where("anonymous_receipts.code_hash = ?", codeHash).
+		First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Actualizar reporte registrando el cambio en el historial. Solo aplica si el
+// reporte sigue en change.FromStatus; si otro cambio se adelantó devuelve un conflicto
+func (dao *ReportDAO) UpdateWithHistory(report *models.Report, change *models.ReportStatusChange, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		// Dos revisiones concurrentes leen el mismo estado; solo una lo encuentra al escribir
+		result := tx.Model(report).
+			Select("*").Omit(clause.Associations).
+			Where("status = ?", change.FromStatus).
+			Updates(report)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("report_status_changed", "Report status changed since it was read, reload and try again")
+		}
+		change.ReportID = report.ID
+		if err := tx.Create(change).Error; err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
 // Buscar reporte por ID
 func (dao *ReportDAO) FindByID(id uint) (*models.Report, error) {
 	var report models.Report
-	err := config.DB.Preload("Author").First(&report, id).Error
+	err := config.DB.Preload("Author").Preload("Assignee").First(&report, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +171,35 @@ func (dao *ReportDAO) FindAll(page, limit int) ([]models.Report, int64, error) {
 	return reports, total, err
 }
 
+// Listar reportes con filtros, orden y paginación (offset o cursor)
+func (dao *ReportDAO) List(q *ListQuery) ([]models.Report, *PageInfo, error) {
+	return findPage[models.Report](reportListSpec, q)
+}
+
+// ReportsByAuthor restringe el listado a los reportes de un autor
+func ReportsByAuthor(authorID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("author_id = ?", authorID)
+	}
+}
+
+// Todos los reportes de un autor con su asignado, en orden cronológico
+func (dao *ReportDAO) FindAllByAuthor(authorID uint) ([]models.Report, error) {
+	var reports []models.Report
+	err := config.DB.Preload("Assignee").
+		Where("author_id = ?", authorID).
+		Order("created_at ASC, id ASC").
+		Find(&reports).Error
+	return reports, err
+}
+
+// ReportsInvolving restringe el listado a reportes propios o asignados al usuario
+func ReportsInvolving(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(author_id = ? OR assignee_id = ?)", userID, userID)
+	}
+}
+
 // Listar reportes por autor
 func (dao *ReportDAO) FindByAuthor(authorID uint, page, limit int) ([]models.Report, int64, error) {
 	var reports []models.Report
@@ -140,6 +296,36 @@ func (dao *ReportDAO) CountByStatus(status string) (int64, error) {
 	return count, err
 }
 
+// ReportCountFilter selecciona reportes por tipo, status y rango de creación
+type ReportCountFilter struct {
+	Type   string
+	Status string
+	Range  TimeRange
+}
+
+func (f ReportCountFilter) scope(db *gorm.DB) *gorm.DB {
+	if f.Type != "" {
+		db = db.Where("type = ?", f.Type)
+	}
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+	return db.Scopes(f.Range.scope("created_at"))
+}
+
+// Contar reportes según el filtro (campos vacíos no filtran)
+func (dao *ReportDAO) CountReports(filter ReportCountFilter) (int64, error) {
+	var count int64
+	err := config.DB.Model(&models.Report{}).Scopes(filter.scope).Count(&count).Error
+	return count, err
+}
+
+// Reportes por día entre from y to, agrupados en la zona horaria indicada
+func (dao *ReportDAO) CountReportsByDay(filter ReportCountFilter, from, to time.Time, location *time.Location) ([]DailyCount, error) {
+	filter.Range = TimeRange{}
+	return countByDay(config.DB.Model(&models.Report{}).Scopes(filter.scope), "created_at", from, to, location)
+}
+
 // Contar reportes por autor
 func (dao *ReportDAO) CountByAuthor(authorID uint) (int64, error) {
 	var count int64
@@ -156,3 +342,204 @@ func (dao *ReportDAO) FindRecent(limit int) ([]models.Report, error) {
 		Find(&reports).Error
 	return reports, err
 }
+
+// Contar reportes abiertos (pendientes o en revisión) de un tipo y severidad
+func (dao *ReportDAO) CountOpenBySeverity(reportType, severity string) (int64, error) {
+	var count int64
+	err := config.DB.Model(&models.Report{}).
+		Scopes(openReportsOfSeverity(reportType, severity)).
+		Count(&count).Error
+	return count, err
+}
+
+// Reportes abiertos de un tipo y severidad, más recientes primero
+func (dao *ReportDAO) FindOpenBySeverity(reportType, severity string, limit int) ([]models.Report, error) {
+	var reports []models.Report
+	err := config.DB.Preload("Author").
+		Scopes(openReportsOfSeverity(reportType, severity)).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&reports).Error
+	return reports, err
+}
+
+func openReportsOfSeverity(reportType, severity string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("type = ? AND severity = ? AND status IN ?", reportType, severity,
+			[]string{models.ReportStatusPending, models.ReportStatusInReview})
+	}
+}
+
+// ReportFingerprint es la huella de contenido de un reporte
+type ReportFingerprint struct {
+	ID          uint
+	ContentHash string
+	SimHash     int64
+}
+
+// Huellas de los reportes de un tipo creados desde since
+func (dao *ReportDAO) FindFingerprintsSince(reportType string, since time.Time) ([]ReportFingerprint, error) {
+	var fingerprints []ReportFingerprint
+	err := config.DB.Model(&models.Report{}).
+		Select("id, content_hash, sim_hash").
+		Where("type = ? AND created_at >= ? AND content_hash <> ''", reportType, since).
+		Find(&fingerprints).Error
+	return fingerprints, err
+}
+
+// Buscar reportes por IDs con autor
+func (dao *ReportDAO) FindByIDs(ids []uint) ([]models.Report, error) {
+	var reports []models.Report
+	if len(ids) == 0 {
+		return reports, nil
+	}
+	err := config.DB.Preload("Author").Where("id IN ?", ids).Find(&reports).Error
+	return reports, err
+}
+
+// Búsqueda de texto completo sobre título y descripción.
+// En PostgreSQL usa tsvector con ranking y ts_headline; en otras bases (p.ej. la de tests)
+// cae a un LIKE sin ranking con fragmentos calculados por el servicio.
+func (dao *ReportDAO) Search(text string, scopes []func(*gorm.DB) *gorm.DB, page, limit int) ([]ReportSearchRow, int64, error) {
+	if config.IsPostgres() {
+		return dao.searchFullText(text, scopes, page, limit)
+	}
+	return dao.searchLike(text, scopes, page, limit)
+}
+
+// EnsureSearchIndex crea el índice GIN usado por la búsqueda de texto completo
+func (dao *ReportDAO) EnsureSearchIndex() error {
+	if !config.IsPostgres() {
+		return nil
+	}
+	return config.DB.Exec(fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS idx_reports_search ON reports USING GIN (%s)",
+		reportSearchDocument(config.SearchLanguage()),
+	)).Error
+}
+
+func (dao *ReportDAO) searchFullText(text string, scopes []func(*gorm.DB) *gorm.DB, page, limit int) ([]ReportSearchRow, int64, error) {
+	language := config.SearchLanguage()
+	document := reportSearchDocument(language)
+	tsQuery := fmt.Sprintf("websearch_to_tsquery('%s', ?)", language)
+	titleOptions := fmt.Sprintf("HighlightAll=true, StartSel=%s, StopSel=%s", HighlightStart, HighlightStop)
+	descriptionOptions := fmt.Sprintf("StartSel=%s, StopSel=%s, MaxFragments=2, MaxWords=25, MinWords=8", HighlightStart, HighlightStop)
+
+	query := config.DB.Model(&models.Report{}).
+		Scopes(scopes...).
+		Where(document+" @@ "+tsQuery, text).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []ReportSearchRow
+	err := query.
+		Select(fmt.Sprintf(
+			"id, ts_rank(%[1]s, %[2]s) AS rank, "+
+				"ts_headline('%[3]s', title, %[2]s, ?) AS title_snippet, "+
+				"ts_headline('%[3]s', coalesce(description, ''), %[2]s, ?) AS description_snippet",
+			document, tsQuery, language,
+		), text, text, titleOptions, text, descriptionOptions).
+		Order("rank DESC, created_at DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Scan(&rows).Error
+
+	return rows, total, err
+}
+
+func (dao *ReportDAO) searchLike(text string, scopes []func(*gorm.DB) *gorm.DB, page, limit int) ([]ReportSearchRow, int64, error) {
+	pattern := "%" + escapeLike(strings.ToLower(text)) + "%"
+
+	query := config.DB.Model(&models.Report{}).
+		Scopes(scopes...).
+		Where("(LOWER(title) LIKE ? OR LOWER(description) LIKE ?)", pattern, pattern).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []ReportSearchRow
+	err := query.
+		Select("id").
+		Order("created_at DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Scan(&rows).Error
+
+	return rows, total, err
+}
+
+// reportSearchDocument es la expresión tsvector; debe coincidir con la del índice
+func reportSearchDocument(language string) string {
+	return fmt.Sprintf("to_tsvector('%s', coalesce(title, '') || ' ' || coalesce(description, ''))", language)
+}
+
+// Listar reportes eliminados
+func (dao *ReportDAO) ListTrashed(q *ListQuery) ([]models.Report, *PageInfo, error) {
+	return findPage[models.Report](reportTrashSpec, q.Scope(Trashed))
+}
+
+// Buscar reporte eliminado por ID
+func (dao *ReportDAO) FindTrashedByID(id uint) (*models.Report, error) {
+	var report models.Report
+	err := Trashed(config.DB).Preload("Author").First(&report, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Restaurar reporte eliminado
+func (dao *ReportDAO) Restore(id uint, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := restoreTrashed(tx, &models.Report{}, id); err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Borrar definitivamente un reporte eliminado con sus comentarios, historial,
+// recibo anónimo y adjuntos. Devuelve las llaves de los archivos adjuntos,
+// que el llamador borra del almacenamiento tras el commit
+func (dao *ReportDAO) Purge(id uint, outbox OutboxWriter) ([]string, error) {
+	var storageKeys []string
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.ReportAttachment{}).Unscoped().
+			Where("report_id = ?", id).
+			Pluck("storage_key", &storageKeys).Error
+		if err != nil {
+			return err
+		}
+
+		children := []interface{}{
+			&models.ReportAttachment{},
+			&models.ReportComment{},
+			&models.ReportStatusChange{},
+			&models.AnonymousReceipt{},
+		}
+		for _, model := range children {
+			if err := tx.Unscoped().Where("report_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := purgeTrashed(tx, &models.Report{}, id); err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return storageKeys, nil
+}
+
+// IDs de reportes eliminados antes de cutoff, a partir de afterID
+func (dao *ReportDAO) FindDeletedBefore(cutoff time.Time, afterID uint, limit int) ([]uint, error) {
+	return findDeletedBefore[models.Report](cutoff, afterID, limit)
+}
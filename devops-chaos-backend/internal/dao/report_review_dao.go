@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+)
+
+type ReportReviewDAO struct{}
+
+func NewReportReviewDAO() *ReportReviewDAO {
+	return &ReportReviewDAO{}
+}
+
+// Crear comentario de revisión
+func (dao *ReportReviewDAO) CreateComment(comment *models.ReportComment) error {
+	return config.DB.Create(comment).Error
+}
+
+// Buscar comentario por ID con autor
+func (dao *ReportReviewDAO) FindCommentByID(id uint) (*models.ReportComment, error) {
+	var comment models.ReportComment
+	err := config.DB.Preload("Author").First(&comment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Comentarios de un reporte en orden cronológico
+func (dao *ReportReviewDAO) FindComments(reportID uint) ([]models.ReportComment, error) {
+	var comments []models.ReportComment
+	err := config.DB.Preload("Author").
+		Where("report_id = ?", reportID).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+// Comentarios escritos por un usuario en orden cronológico
+func (dao *ReportReviewDAO) FindCommentsByAuthor(authorID uint) ([]models.ReportComment, error) {
+	var comments []models.ReportComment
+	err := config.DB.
+		Where("author_id = ?", authorID).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+// Historial de estados de un reporte en orden cronológico
+func (dao *ReportReviewDAO) FindHistory(reportID uint) ([]models.ReportStatusChange, error) {
+	var history []models.ReportStatusChange
+	err := config.DB.Preload("ChangedBy").
+		Where("report_id = ?", reportID).
+		Order("created_at ASC, id ASC").
+		Find(&history).Error
+	return history, err
+}
+
+// Contar notas del remitente anónimo (comentarios sin autor)
+func (dao *ReportReviewDAO) CountReporterNotes(reportID uint) (int64, error) {
+	var count int64
+	err := config.DB.Model(&models.ReportComment{}).
+		Where("report_id = ? AND author_id IS NULL", reportID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,162 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ResistanceContentDAO struct{}
+
+func NewResistanceContentDAO() *ResistanceContentDAO {
+	return &ResistanceContentDAO{}
+}
+
+var tipListSpec = &ListSpec{
+	SortFields: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+		"upvotes":    "upvotes",
+		"title":      "title",
+	},
+	FilterFields: map[string]string{
+		"status":   "status",
+		"category": "category",
+		"priority": "priority",
+	},
+	SearchColumns: []string{"title", "description"},
+	DateColumn:    "created_at",
+	DefaultSort:   "upvotes",
+	DefaultOrder:  "desc",
+}
+
+var memeListSpec = &ListSpec{
+	SortFields: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+		"upvotes":    "upvotes",
+		"title":      "title",
+	},
+	FilterFields: map[string]string{
+		"status": "status",
+	},
+	SearchColumns: []string{"title", "description"},
+	DateColumn:    "created_at",
+	DefaultSort:   "upvotes",
+	DefaultOrder:  "desc",
+}
+
+// ContentWithStatus restringe el listado a un status de moderación
+func ContentWithStatus(status string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", status)
+	}
+}
+
+// Crear consejo
+func (dao *ResistanceContentDAO) CreateTip(tip *models.SurvivalTip) error {
+	return config.DB.Create(tip).Error
+}
+
+// Buscar consejo por ID
+func (dao *ResistanceContentDAO) FindTipByID(id uint) (*models.SurvivalTip, error) {
+	var tip models.SurvivalTip
+	err := config.DB.First(&tip, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tip, nil
+}
+
+// Listar consejos con filtros y paginación
+func (dao *ResistanceContentDAO) ListTips(q *ListQuery) ([]models.SurvivalTip, *PageInfo, error) {
+	return findPage[models.SurvivalTip](tipListSpec, q)
+}
+
+// Consejos aprobados mejor votados
+func (dao *ResistanceContentDAO) FindTopTips(limit int) ([]models.SurvivalTip, error) {
+	var tips []models.SurvivalTip
+	err := config.DB.Where("status = ?", models.ContentStatusApproved).
+		Order("upvotes DESC, created_at DESC").
+		Limit(limit).
+		Find(&tips).Error
+	return tips, err
+}
+
+// Actualizar consejo
+func (dao *ResistanceContentDAO) UpdateTip(tip *models.SurvivalTip) error {
+	return config.DB.Save(tip).Error
+}
+
+// Crear meme
+func (dao *ResistanceContentDAO) CreateMeme(meme *models.ResistanceMeme) error {
+	return config.DB.Create(meme).Error
+}
+
+// Buscar meme por ID
+func (dao *ResistanceContentDAO) FindMemeByID(id uint) (*models.ResistanceMeme, error) {
+	var meme models.ResistanceMeme
+	err := config.DB.First(&meme, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &meme, nil
+}
+
+// Listar memes con filtros y paginación
+func (dao *ResistanceContentDAO) ListMemes(q *ListQuery) ([]models.ResistanceMeme, *PageInfo, error) {
+	return findPage[models.ResistanceMeme](memeListSpec, q)
+}
+
+// Memes aprobados mejor votados
+func (dao *ResistanceContentDAO) FindTopMemes(limit int) ([]models.ResistanceMeme, error) {
+	var memes []models.ResistanceMeme
+	err := config.DB.Where("status = ?", models.ContentStatusApproved).
+		Order("upvotes DESC, created_at DESC").
+		Limit(limit).
+		Find(&memes).Error
+	return memes, err
+}
+
+// Actualizar meme
+func (dao *ResistanceContentDAO) UpdateMeme(meme *models.ResistanceMeme) error {
+	return config.DB.Save(meme).Error
+}
+
+// Vote registra el voto del usuario y suma el upvote en la misma transacción.
+// Devuelve false si el usuario ya había votado ese contenido
+func (dao *ResistanceContentDAO) Vote(contentType string, contentID, userID uint) (bool, error) {
+	voted := false
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ContentVote{
+			ContentType: contentType,
+			ContentID:   contentID,
+			UserID:      userID,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil // Voto repetido
+		}
+
+		voted = true
+		var model interface{} = &models.SurvivalTip{}
+		if contentType == models.ContentTypeMeme {
+			model = &models.ResistanceMeme{}
+		}
+		return tx.Model(model).
+			Where("id = ?", contentID).
+			UpdateColumn("upvotes", gorm.Expr("upvotes + 1")).Error
+	})
+	return voted, err
+}
+
+// Contactos de emergencia en su orden de presentación
+func (dao *ResistanceContentDAO) FindContacts() ([]models.EmergencyContact, error) {
+	var contacts []models.EmergencyContact
+	err := config.DB.Order("sort_order ASC, id ASC").Find(&contacts).Error
+	return contacts, err
+}
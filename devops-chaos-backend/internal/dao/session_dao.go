@@ -0,0 +1,93 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Cada cuánto se actualiza LastSeenAt como máximo, para no escribir en cada petición
+const sessionTouchInterval = time.Minute
+
+type SessionDAO struct{}
+
+func NewSessionDAO() *SessionDAO {
+	return &SessionDAO{}
+}
+
+// Crear sesión
+func (dao *SessionDAO) Create(session *models.Session) error {
+	return config.DB.Create(session).Error
+}
+
+// Buscar sesión por el ID que lleva el token
+func (dao *SessionDAO) FindByTokenID(tokenID string) (*models.Session, error) {
+	var session models.Session
+	err := config.DB.Where("token_id = ?", tokenID).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Registrar actividad de la sesión si la última es más vieja que sessionTouchInterval
+func (dao *SessionDAO) Touch(id uint, now time.Time) error {
+	return config.DB.Model(&models.Session{}).
+		Where("id = ? AND last_seen_at < ?", id, now.Add(-sessionTouchInterval)).
+		Update("last_seen_at", now).Error
+}
+
+// Sesiones vigentes de un usuario, la más reciente primero
+func (dao *SessionDAO) FindActiveByUser(userID uint, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := config.DB.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC, id DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Revocar una sesión vigente del usuario. Devuelve gorm.ErrRecordNotFound si
+// no existe, es de otro usuario o ya no está vigente
+func (dao *SessionDAO) Revoke(userID, id uint, now time.Time, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Session{}).
+			Where("id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", id, userID, now).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Revocar las sesiones vigentes del usuario salvo keepID y devolver cuántas
+// se revocaron. outbox recibe ese número y solo se llama si es mayor que cero
+func (dao *SessionDAO) RevokeOthers(userID, keepID uint, now time.Time, outbox func(revoked int64) OutboxWriter) (int64, error) {
+	var revoked int64
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Session{}).
+			Where("user_id = ? AND id <> ? AND revoked_at IS NULL AND expires_at > ?", userID, keepID, now).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		revoked = result.RowsAffected
+		if revoked == 0 {
+			return nil
+		}
+		return writeOutbox(tx, outbox(revoked))
+	})
+	return revoked, err
+}
+
+// Borrar las sesiones vencidas antes de cutoff; las revocadas se borran al vencer
+func (dao *SessionDAO) PurgeExpired(cutoff time.Time) (int64, error) {
+	result := config.DB.Where("expires_at < ?", cutoff).Delete(&models.Session{})
+	return result.RowsAffected, result.Error
+}
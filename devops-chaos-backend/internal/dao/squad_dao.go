@@ -0,0 +1,177 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SquadDAO struct{}
+
+func NewSquadDAO() *SquadDAO {
+	return &SquadDAO{}
+}
+
+// Crear escuadrón
+func (dao *SquadDAO) Create(squad *models.Squad) error {
+	return config.DB.Omit("Members", "Leader").Create(squad).Error
+}
+
+// Buscar escuadrón por ID con líder y miembros
+func (dao *SquadDAO) FindByID(id uint) (*models.Squad, error) {
+	var squad models.Squad
+	err := config.DB.Preload("Leader").
+		Preload("Members", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC, id ASC")
+		}).
+		Preload("Members.User").
+		First(&squad, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &squad, nil
+}
+
+// Listar escuadrones con líder y miembros
+func (dao *SquadDAO) FindAll() ([]models.Squad, error) {
+	var squads []models.Squad
+	err := config.DB.Preload("Leader").
+		Preload("Members").
+		Preload("Members.User").
+		Order("name ASC").
+		Find(&squads).Error
+	return squads, err
+}
+
+// Verificar si existe un escuadrón con ese nombre
+func (dao *SquadDAO) ExistsByName(name string) (bool, error) {
+	var count int64
+	err := config.DB.Model(&models.Squad{}).Where("LOWER(name) = LOWER(?)", name).Count(&count).Error
+	return count > 0, err
+}
+
+// Actualizar escuadrón
+func (dao *SquadDAO) Update(squad *models.Squad) error {
+	return config.DB.Omit("Members", "Leader").Save(squad).Error
+}
+
+// Disolver escuadrón; su historial de puntos se conserva
+func (dao *SquadDAO) Delete(id uint) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("squad_id = ?", id).Delete(&models.SquadMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Squad{}, id).Error
+	})
+}
+
+// Membresía de un daemon, si tiene
+func (dao *SquadDAO) FindMembership(userID uint) (*models.SquadMember, error) {
+	var member models.SquadMember
+	err := config.DB.Where("user_id = ?", userID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// Agregar miembro
+func (dao *SquadDAO) AddMember(member *models.SquadMember) error {
+	return config.DB.Create(member).Error
+}
+
+// Sacar a un miembro; si era el líder el escuadrón queda sin líder
+func (dao *SquadDAO) RemoveMember(squadID, userID uint) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("squad_id = ? AND user_id = ?", squadID, userID).Delete(&models.SquadMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Squad{}).
+			Where("id = ? AND leader_id = ?", squadID, userID).
+			Update("leader_id", nil).Error
+	})
+}
+
+// RecordScore acredita puntos al escuadrón actual del daemon. No hace nada si
+// el daemon no tiene escuadrón o si el origen ya fue acreditado
+func (dao *SquadDAO) RecordScore(userID uint, source string, sourceID uint, points int) error {
+	member, err := dao.FindMembership(userID)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return config.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.SquadScore{
+		SquadID:  member.SquadID,
+		UserID:   userID,
+		Source:   source,
+		SourceID: sourceID,
+		Points:   points,
+	}).Error
+}
+
+// SquadTotals son los totales acumulados de un escuadrón
+type SquadTotals struct {
+	SquadID     uint
+	Name        string
+	MemberCount int
+	Captures    int
+	Reports     int
+	Points      int
+}
+
+// Leaderboard de escuadrones por puntos acumulados
+func (dao *SquadDAO) FindLeaderboard(limit int) ([]SquadTotals, error) {
+	var rows []SquadTotals
+
+	members := config.DB.Model(&models.SquadMember{}).
+		Select("squad_id, COUNT(*) AS member_count").
+		Group("squad_id")
+
+	scores := config.DB.Model(&models.SquadScore{}).
+		Select("squad_id, "+
+			"COUNT(*) FILTER (WHERE source = ?) AS captures, "+
+			"COUNT(*) FILTER (WHERE source = ?) AS reports, "+
+			"SUM(points) AS points",
+			models.SquadScoreSourceCapture, models.SquadScoreSourceReport).
+		Group("squad_id")
+
+	err := config.DB.Model(&models.Squad{}).
+		Select("squads.id AS squad_id, squads.name, "+
+			"COALESCE(m.member_count, 0) AS member_count, "+
+			"COALESCE(s.captures, 0) AS captures, "+
+			"COALESCE(s.reports, 0) AS reports, "+
+			"COALESCE(s.points, 0) AS points").
+		Joins("LEFT JOIN (?) AS m ON m.squad_id = squads.id", members).
+		Joins("LEFT JOIN (?) AS s ON s.squad_id = squads.id", scores).
+		Order("points DESC, captures DESC, squads.name ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// SquadWeeklyPoints son los puntos de un escuadrón en una semana
+type SquadWeeklyPoints struct {
+	SquadID uint
+	Week    time.Time // Medianoche del lunes en la zona pedida
+	Points  int
+}
+
+// Puntos por escuadrón y semana local dentro de [from, to); solo devuelve semanas con actividad
+func (dao *SquadDAO) SumPointsByWeek(from, to time.Time, location *time.Location) ([]SquadWeeklyPoints, error) {
+	var rows []SquadWeeklyPoints
+
+	// date_trunc('week') trunca al lunes, igual que StartOfWeek
+	err := config.DB.Model(&models.SquadScore{}).
+		Scopes(Between(from, to).scope("created_at")).
+		Select("squad_id, date_trunc('week', created_at AT TIME ZONE ?) AS week, SUM(points) AS points", location.String()).
+		Group("squad_id, week").
+		Order("week").
+		Scan(&rows).Error
+	return rows, err
+}
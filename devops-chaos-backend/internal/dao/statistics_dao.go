@@ -5,6 +5,7 @@ import (
 	"devops-chaos-backend/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type StatisticDAO struct{}
@@ -39,22 +40,35 @@ func (dao *StatisticDAO) FindByUserIDWithUser(userID uint) (*models.Statistic, e
 }
 
 // Actualizar estadística
-func (dao *StatisticDAO) Update(statistic *models.Statistic) error {
-	return config.DB.Save(statistic).Error
+func (dao *StatisticDAO) Update(statistic *models.Statistic, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(statistic).Error; err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
 }
 
-// Incrementar capturas
-func (dao *StatisticDAO) IncrementCaptures(userID uint) error {
-	return config.DB.Model(&models.Statistic{}).
-		Where("user_id = ?", userID).
-		UpdateColumn("captures_count", gorm.Expr("captures_count + ?", 1)).Error
+// Incrementar capturas una sola vez por evento (subscriber, eventID)
+func (dao *StatisticDAO) IncrementCaptures(userID uint, subscriber, eventID string) error {
+	return dao.incrementOnce(userID, "captures_count", subscriber, eventID)
 }
 
-// Incrementar reportes
-func (dao *StatisticDAO) IncrementReports(userID uint) error {
-	return config.DB.Model(&models.Statistic{}).
-		Where("user_id = ?", userID).
-		UpdateColumn("reports_count", gorm.Expr("reports_count + ?", 1)).Error
+// Incrementar reportes una sola vez por evento (subscriber, eventID)
+func (dao *StatisticDAO) IncrementReports(userID uint, subscriber, eventID string) error {
+	return dao.incrementOnce(userID, "reports_count", subscriber, eventID)
+}
+
+func (dao *StatisticDAO) incrementOnce(userID uint, column, subscriber, eventID string) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		claimed, err := claimEvent(tx, subscriber, eventID)
+		if err != nil || !claimed {
+			return err
+		}
+		return tx.Model(&models.Statistic{}).
+			Where("user_id = ?", userID).
+			UpdateColumn(column, gorm.Expr(column+" + ?", 1)).Error
+	})
 }
 
 // Agregar puntos
@@ -64,6 +78,50 @@ func (dao *StatisticDAO) AddPoints(userID uint, points int) error {
 		UpdateColumn("points", gorm.Expr("points + ?", points)).Error
 }
 
+// Agregar puntos una sola vez por evento (subscriber, eventID) y devolver el
+// nuevo total; outbox recibe el total en la misma transacción. Si el evento ya
+// se había aplicado no suma nada y devuelve applied en false
+func (dao *StatisticDAO) AddPointsReturning(userID uint, points int, subscriber, eventID string, outbox func(tx *gorm.DB, total int) error) (total int, applied bool, err error) {
+	var statistic models.Statistic
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		claimed, claimErr := claimEvent(tx, subscriber, eventID)
+		if claimErr != nil || !claimed {
+			return claimErr
+		}
+		applied = true
+
+		result := tx.Model(&statistic).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "points"}}}).
+			Where("user_id = ?", userID).
+			UpdateColumn("points", gorm.Expr("points + ?", points))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if outbox == nil {
+			return nil
+		}
+		return outbox(tx, statistic.Points)
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return statistic.Points, applied, nil
+}
+
+// Daemons cuyo puntaje quedó estrictamente entre before y after (superados al sumar puntos)
+func (dao *StatisticDAO) FindDaemonsBetweenPoints(excludeUserID uint, before, after int) ([]uint, error) {
+	var userIDs []uint
+	err := config.DB.Model(&models.Statistic{}).
+		Joins("JOIN users ON users.id = statistics.user_id").
+		Where("users.role = ? AND statistics.user_id <> ?", models.RoleDaemon, excludeUserID).
+		Where("statistics.points > ? AND statistics.points < ?", before, after).
+		Pluck("statistics.user_id", &userIDs).Error
+	return userIDs, err
+}
+
 // Top daemons por ranking
 func (dao *StatisticDAO) FindTopDaemons(limit int) ([]models.Statistic, error) {
 	var statistics []models.Statistic
@@ -0,0 +1,213 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TeamDAO struct{}
+
+func NewTeamDAO() *TeamDAO {
+	return &TeamDAO{}
+}
+
+// Crear equipo con su líder
+func (dao *TeamDAO) CreateWithLeader(team *models.Team, leaderID uint) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(team).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.TeamMember{
+			TeamID: team.ID,
+			UserID: leaderID,
+			Role:   models.TeamRoleLeader,
+		}).Error
+	})
+}
+
+// Buscar equipo por ID con sus miembros
+func (dao *TeamDAO) FindByID(id uint) (*models.Team, error) {
+	var team models.Team
+	err := config.DB.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).Preload("Members.User").First(&team, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// Verificar si existe un equipo con ese nombre
+func (dao *TeamDAO) ExistsByName(name string) (bool, error) {
+	var count int64
+	err := config.DB.Model(&models.Team{}).Where("LOWER(name) = LOWER(?)", name).Count(&count).Error
+	return count > 0, err
+}
+
+// Listar equipos con cantidad de miembros
+func (dao *TeamDAO) FindAllWithMemberCount() ([]TeamSummaryRow, error) {
+	var rows []TeamSummaryRow
+	err := config.DB.Model(&models.Team{}).
+		Select("teams.id, teams.name, teams.description, COUNT(team_members.id) AS member_count").
+		Joins("LEFT JOIN team_members ON team_members.team_id = teams.id").
+		Group("teams.id").
+		Order("teams.name ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// TeamSummaryRow es la vista pública de un equipo
+type TeamSummaryRow struct {
+	ID          uint
+	Name        string
+	Description string
+	MemberCount int
+}
+
+// Membresía de un usuario, si tiene
+func (dao *TeamDAO) FindMembership(userID uint) (*models.TeamMember, error) {
+	var member models.TeamMember
+	err := config.DB.Where("user_id = ?", userID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// Agregar miembro
+func (dao *TeamDAO) AddMember(member *models.TeamMember) error {
+	return config.DB.Create(member).Error
+}
+
+// Actualizar equipo
+func (dao *TeamDAO) Update(team *models.Team) error {
+	return config.DB.Omit("Members").Save(team).Error
+}
+
+// RemoveMember saca a un miembro; si era líder asciende al más antiguo y si
+// era el último borra el equipo
+func (dao *TeamDAO) RemoveMember(member *models.TeamMember) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		return removeTeamMember(tx, member)
+	})
+}
+
+func removeTeamMember(tx *gorm.DB, member *models.TeamMember) error {
+	if err := tx.Delete(member).Error; err != nil {
+		return err
+	}
+
+	var next models.TeamMember
+	err := tx.Where("team_id = ?", member.TeamID).Order("created_at ASC, id ASC").First(&next).Error
+	if err == gorm.ErrRecordNotFound {
+		// Equipo vacío: se disuelve junto con su tablero y libera el nombre
+		if err := tx.Unscoped().Where("team_id = ?", member.TeamID).Delete(&models.TeamMessage{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.Team{}, member.TeamID).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	if member.Role == models.TeamRoleLeader {
+		return tx.Model(&next).Update("role", models.TeamRoleLeader).Error
+	}
+	return nil
+}
+
+// Crear mensaje en el tablero del equipo
+func (dao *TeamDAO) CreateMessage(message *models.TeamMessage) error {
+	return config.DB.Create(message).Error
+}
+
+// Mensajes del equipo, más recientes primero
+func (dao *TeamDAO) FindMessages(teamID uint, limit int) ([]models.TeamMessage, error) {
+	var messages []models.TeamMessage
+	err := config.DB.Preload("Author").
+		Where("team_id = ?", teamID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// Puntos aportados por un miembro desde since
+func (dao *TeamDAO) SumContributionsSince(teamID, userID uint, since time.Time) (int, error) {
+	var total int
+	err := config.DB.Model(&models.TeamContribution{}).
+		Select("COALESCE(SUM(points), 0)").
+		Where("team_id = ? AND user_id = ? AND created_at >= ?", teamID, userID, since).
+		Scan(&total).Error
+	return total, err
+}
+
+// Registrar aporte y sumarlo al fondo del equipo
+func (dao *TeamDAO) Contribute(contribution *models.TeamContribution) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(contribution).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Team{}).
+			Where("id = ?", contribution.TeamID).
+			UpdateColumn("protection_points", gorm.Expr("protection_points + ?", contribution.Points)).Error
+	})
+}
+
+// Puntos de protección del equipo de un usuario (0 si no tiene equipo)
+func (dao *TeamDAO) FindProtectionPoints(userID uint) (int, error) {
+	var points int
+	err := config.DB.Model(&models.Team{}).
+		Select("COALESCE(MAX(teams.protection_points), 0)").
+		Joins("JOIN team_members ON team_members.team_id = teams.id").
+		Where("team_members.user_id = ?", userID).
+		Scan(&points).Error
+	return points, err
+}
+
+// Rescue descuenta el costo del fondo, marca la captura como escapada y libera al
+// miembro. Devuelve false si el equipo no tiene puntos suficientes y un conflicto
+// si la captura ya fue liberada
+func (dao *TeamDAO) Rescue(rescue *models.TeamRescue, outbox OutboxWriter) (bool, error) {
+	rescued := false
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Team{}).
+			Where("id = ? AND protection_points >= ?", rescue.TeamID, rescue.PointsSpent).
+			UpdateColumn("protection_points", gorm.Expr("protection_points - ?", rescue.PointsSpent))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil // Fondo insuficiente
+		}
+
+		// Solo una de dos rescates concurrentes encuentra la captura vigente
+		result = tx.Model(&models.Capture{}).
+			Where("id = ? AND status = ?", rescue.CaptureID, models.CaptureStatusCaptured).
+			Update("status", models.CaptureStatusEscaped)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("capture_already_released", "This capture has already been released") // Revierte el descuento
+		}
+
+		err := tx.Model(&models.User{}).
+			Where("id = ?", rescue.RescuedID).
+			Update("status", models.StatusActive).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Create(rescue).Error; err != nil {
+			return err
+		}
+		rescued = true
+		return writeOutbox(tx, outbox)
+	})
+	return rescued, err
+}
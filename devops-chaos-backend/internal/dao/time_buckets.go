@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DailyCount es la cantidad de registros de un día en la zona horaria pedida
+type DailyCount struct {
+	Day   time.Time // Medianoche del día en la zona pedida
+	Count int64
+}
+
+// TimeRange acota una consulta a [From, To); cualquiera de los extremos puede omitirse
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// Between crea un rango [from, to)
+func Between(from, to time.Time) TimeRange {
+	return TimeRange{From: &from, To: &to}
+}
+
+func (r TimeRange) scope(column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if r.From != nil {
+			db = db.Where(column+" >= ?", *r.From)
+		}
+		if r.To != nil {
+			db = db.Where(column+" < ?", *r.To)
+		}
+		return db
+	}
+}
+
+// countByDay agrupa por día local la columna de fecha y completa con ceros los días sin registros
+func countByDay(query *gorm.DB, column string, from, to time.Time, location *time.Location) ([]DailyCount, error) {
+	var rows []struct {
+		Day   time.Time
+		Count int64
+	}
+
+	// AT TIME ZONE convierte el instante a hora local antes de truncar al día
+	bucket := "date_trunc('day', " + column + " AT TIME ZONE ?)"
+	err := query.
+		Scopes(Between(from, to).scope(column)).
+		Select(bucket+" AS day, COUNT(*) AS count", location.String()).
+		Group("day").
+		Order("day").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Day.Format("2006-01-02")] = row.Count
+	}
+
+	var result []DailyCount
+	start := from.In(location)
+	for i := 0; ; i++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, location)
+		if !day.Before(to) {
+			break
+		}
+		result = append(result, DailyCount{Day: day, Count: counts[day.Format("2006-01-02")]})
+	}
+
+	return result, nil
+}
+
+// StartOfDay devuelve la medianoche del día de t en la zona indicada
+func StartOfDay(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+}
+
+// StartOfWeek devuelve la medianoche del lunes de la semana de t
+func StartOfWeek(t time.Time, location *time.Location) time.Time {
+	day := StartOfDay(t, location)
+	offset := (int(day.Weekday()) + 6) % 7 // lunes = 0
+	return time.Date(day.Year(), day.Month(), day.Day()-offset, 0, 0, 0, 0, location)
+}
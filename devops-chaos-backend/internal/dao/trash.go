@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Trashed restringe a las filas eliminadas (soft delete)
+func Trashed(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where("deleted_at IS NOT NULL")
+}
+
+// trashSpec deriva de spec la especificación de la papelera: se ordena y se
+// filtra por fecha de eliminación
+func trashSpec(spec *ListSpec) *ListSpec {
+	sortFields := make(map[string]string, len(spec.SortFields)+1)
+	for field, column := range spec.SortFields {
+		sortFields[field] = column
+	}
+	sortFields["deleted_at"] = "deleted_at"
+
+	trash := *spec
+	trash.SortFields = sortFields
+	trash.DateColumn = "deleted_at"
+	trash.DefaultSort = "deleted_at"
+	trash.DefaultOrder = "desc"
+	return &trash
+}
+
+// findDeletedBefore devuelve hasta limit IDs mayores que afterID de filas de T
+// eliminadas antes de cutoff. Recorrer por ID permite saltar las que no se
+// pudieron purgar
+func findDeletedBefore[T any](cutoff time.Time, afterID uint, limit int) ([]uint, error) {
+	var ids []uint
+	err := config.DB.Model(new(T)).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND id > ?", cutoff, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// restoreTrashed quita la marca de eliminación; devuelve gorm.ErrRecordNotFound
+// si la fila no está en la papelera
+func restoreTrashed(tx *gorm.DB, model interface{}, id uint) error {
+	result := tx.Model(model).Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// purgeTrashed borra definitivamente una fila de la papelera; devuelve
+// gorm.ErrRecordNotFound si no está y gorm.ErrForeignKeyViolated si otras
+// tablas aún la referencian
+func purgeTrashed(tx *gorm.DB, model interface{}, id uint) error {
+	result := tx.Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Delete(model)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// translateError traduce errores del driver a los de GORM (p.ej. violaciones
+// de clave foránea) sin activar TranslateError en toda la conexión
+func translateError(err error) error {
+	if translator, ok := config.DB.Dialector.(gorm.ErrorTranslator); ok {
+		return translator.Translate(err)
+	}
+	return err
+}
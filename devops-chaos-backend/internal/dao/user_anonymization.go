@@ -0,0 +1,102 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserAnonymization describe el reemplazo de los datos personales de un usuario
+type UserAnonymization struct {
+	UserID      uint
+	OldUsername string
+	Username    string // Seudónimo
+	Email       string
+	Password    string // Hash de una contraseña que nadie conoce
+	At          time.Time
+}
+
+// Buscar usuario por ID, esté o no en la papelera
+func (dao *UserDAO) FindByIDWithTrashed(id uint) (*models.User, error) {
+	var user models.User
+	err := config.DB.Unscoped().First(&user, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Reemplazar username, email y contraseña por valores sin datos personales y
+// vaciar el perfil en una sola transacción. Se conservan estadísticas,
+// capturas, castigos y reportes; se borran sus sesiones, los cambios de email
+// pendientes y el email de las invitaciones que aceptó, y se cambia el
+// username por el seudónimo en la auditoría. El blob del avatar lo borra quien
+// llama. Si el usuario ya fue anonimizado devuelve gorm.ErrRecordNotFound
+func (dao *UserDAO) Anonymize(a *UserAnonymization, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).Unscoped().
+			Where("id = ? AND anonymized_at IS NULL", a.UserID).
+			Updates(map[string]interface{}{
+				"username":      a.Username,
+				"email":         a.Email,
+				"password":      a.Password,
+				"anonymized_at": a.At,
+				"display_name":  "",
+				"bio":           "",
+				"avatar_key":    "",
+				"avatar_type":   "",
+				"avatar_size":   0,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		for _, model := range []interface{}{&models.Session{}, &models.EmailChange{}} {
+			if err := tx.Where("user_id = ?", a.UserID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		err := tx.Model(&models.Invite{}).
+			Where("accepted_user_id = ?", a.UserID).
+			Update("email", "").Error
+		if err != nil {
+			return err
+		}
+
+		if err := scrubAuditUsername(tx, a); err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// scrubAuditUsername cambia el username por el seudónimo en los resúmenes de
+// las entradas donde el usuario es actor u objetivo, y en los payloads donde
+// aparece como valor JSON exacto
+func scrubAuditUsername(tx *gorm.DB, a *UserAnonymization) error {
+	err := tx.Model(&models.AuditLog{}).
+		Where("actor_id = ? OR subject_id = ?", a.UserID, a.UserID).
+		Update("summary", gorm.Expr("REPLACE(summary, ?, ?)", a.OldUsername, a.Username)).Error
+	if err != nil {
+		return err
+	}
+
+	oldValue, err := json.Marshal(a.OldUsername)
+	if err != nil {
+		return err
+	}
+	newValue, err := json.Marshal(a.Username)
+	if err != nil {
+		return err
+	}
+	return tx.Model(&models.AuditLog{}).
+		Where("payload LIKE ?", "%"+escapeLike(string(oldValue))+"%").
+		Update("payload", gorm.Expr("REPLACE(payload, ?, ?)", string(oldValue), string(newValue))).Error
+}
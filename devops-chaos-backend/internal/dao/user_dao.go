@@ -3,10 +3,36 @@ package dao
 import (
 	"devops-chaos-backend/internal/config"
 	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type UserDAO struct{}
 
+// Campos de usuarios disponibles para orden, filtros y búsqueda
+var userListSpec = &ListSpec{
+	SortFields: map[string]string{
+		"id":         "id",
+		"username":   "username",
+		"email":      "email",
+		"role":       "role",
+		"status":     "status",
+		"created_at": "created_at",
+	},
+	FilterFields: map[string]string{
+		"role":   "role",
+		"status": "status",
+	},
+	SearchColumns: []string{"username", "email"},
+	DateColumn:    "created_at",
+	DefaultSort:   "id",
+	DefaultOrder:  "asc",
+}
+
+// Usuarios en la papelera
+var userTrashSpec = trashSpec(userListSpec)
+
 func NewUserDAO() *UserDAO {
 	return &UserDAO{}
 }
@@ -61,6 +87,11 @@ func (dao *UserDAO) FindAll(page, limit int) ([]models.User, int64, error) {
 	return users, total, err
 }
 
+// Listar usuarios con filtros, orden y paginación (offset o cursor)
+func (dao *UserDAO) List(q *ListQuery) ([]models.User, *PageInfo, error) {
+	return findPage[models.User](userListSpec, q)
+}
+
 // Listar usuarios por rol
 func (dao *UserDAO) FindByRole(role string, page, limit int) ([]models.User, int64, error) {
 	var users []models.User
@@ -115,3 +146,137 @@ func (dao *UserDAO) ExistsByEmail(email string) (bool, error) {
 	err := config.DB.Model(&models.User{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
+
+// Crear usuarios en lote, con estadísticas para los daemons
+func (dao *UserDAO) CreateBatch(users []models.User, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&users).Error; err != nil {
+			return err
+		}
+
+		var statistics []models.Statistic
+		for _, user := range users {
+			if user.Role == models.RoleDaemon {
+				statistics = append(statistics, models.Statistic{UserID: user.ID})
+			}
+		}
+		if len(statistics) > 0 {
+			if err := tx.Create(&statistics).Error; err != nil {
+				return err
+			}
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Usernames y emails ya usados, incluidos los de usuarios eliminados que
+// siguen ocupando el índice único
+func (dao *UserDAO) FindTakenIdentities(usernames, emails []string) ([]string, []string, error) {
+	var users []models.User
+	err := config.DB.Unscoped().
+		Select("username", "email").
+		Where("username IN ? OR email IN ?", usernames, emails).
+		Find(&users).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	takenUsernames := make([]string, len(users))
+	takenEmails := make([]string, len(users))
+	for i, user := range users {
+		takenUsernames[i] = user.Username
+		takenEmails[i] = user.Email
+	}
+	return takenUsernames, takenEmails, nil
+}
+
+// Usuarios para exportar, filtrados por rol y status si se indican
+func (dao *UserDAO) FindForExport(role, status string) ([]models.User, error) {
+	query := config.DB.Order("id ASC")
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var users []models.User
+	err := query.Find(&users).Error
+	return users, err
+}
+
+// Listar usuarios eliminados
+func (dao *UserDAO) ListTrashed(q *ListQuery) ([]models.User, *PageInfo, error) {
+	return findPage[models.User](userTrashSpec, q.Scope(Trashed))
+}
+
+// Buscar usuario eliminado por ID
+func (dao *UserDAO) FindTrashedByID(id uint) (*models.User, error) {
+	var user models.User
+	err := Trashed(config.DB).First(&user, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Indica si algún usuario activo distinto de id usa el username o el email
+func (dao *UserDAO) FindIdentityConflicts(id uint, username, email string) (bool, bool, error) {
+	var users []models.User
+	err := config.DB.
+		Select("username", "email").
+		Where("id <> ? AND (username = ? OR email = ?)", id, username, email).
+		Find(&users).Error
+	if err != nil {
+		return false, false, err
+	}
+
+	usernameTaken, emailTaken := false, false
+	for _, user := range users {
+		usernameTaken = usernameTaken || user.Username == username
+		emailTaken = emailTaken || user.Email == email
+	}
+	return usernameTaken, emailTaken, nil
+}
+
+// Restaurar usuario eliminado junto con sus estadísticas
+func (dao *UserDAO) Restore(id uint, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := restoreTrashed(tx, &models.User{}, id); err != nil {
+			return err
+		}
+		err := tx.Model(&models.Statistic{}).Unscoped().
+			Where("user_id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// Borrar definitivamente un usuario eliminado con sus estadísticas, su
+// bandeja y sus sesiones. Si aún tiene reportes, capturas, castigos u otras filas que lo
+// referencian devuelve gorm.ErrForeignKeyViolated y no borra nada
+func (dao *UserDAO) Purge(id uint, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		owned := []interface{}{
+			&models.Statistic{}, &models.Notification{}, &models.NotificationPreference{},
+			&models.Session{}, &models.EmailChange{},
+		}
+		for _, model := range owned {
+			if err := tx.Unscoped().Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := purgeTrashed(tx, &models.User{}, id); err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// IDs de usuarios eliminados antes de cutoff, a partir de afterID
+func (dao *UserDAO) FindDeletedBefore(cutoff time.Time, afterID uint, limit int) ([]uint, error) {
+	return findDeletedBefore[models.User](cutoff, afterID, limit)
+}
@@ -0,0 +1,213 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// UserDeletionImpact cuenta las filas relacionadas que la eliminación de un
+// usuario modifica o conserva
+type UserDeletionImpact struct {
+	Statistics        int64
+	ActivePunishments int64
+	CapturedAsTarget  int64 // Capturas vigentes donde el usuario es el objetivo
+	CapturedByUser    int64 // Capturas vigentes hechas por el usuario (daemon)
+	AssignedReports   int64 // Reportes abiertos asignados al usuario
+	SquadMemberships  int64
+	SquadsLed         int64
+	TeamMemberships   int64
+	SubmittedContent  int64 // Consejos y memes enviados
+	AuthoredReports   int64
+	CaptureHistory    int64
+	PunishmentHistory int64
+}
+
+// UserDeletion describe una eliminación ya validada
+type UserDeletion struct {
+	User        *models.User
+	DeletedByID uint
+	ReassignTo  *models.User // Daemon que hereda los reportes abiertos; nil los devuelve a la cola
+}
+
+// Contar lo que afectaría eliminar al usuario
+func (dao *UserDAO) CountDeletionImpact(userID uint) (*UserDeletionImpact, error) {
+	impact := &UserDeletionImpact{}
+	openReports := []string{models.ReportStatusPending, models.ReportStatusInReview}
+
+	counts := []struct {
+		target *int64
+		query  *gorm.DB
+	}{
+		{&impact.Statistics, config.DB.Model(&models.Statistic{}).Where("user_id = ?", userID)},
+		{&impact.ActivePunishments, config.DB.Model(&models.Punishment{}).
+			Where("target_id = ? AND status = ?", userID, models.PunishmentStatusActive)},
+		{&impact.CapturedAsTarget, config.DB.Model(&models.Capture{}).
+			Where("target_id = ? AND status = ?", userID, models.CaptureStatusCaptured)},
+		{&impact.CapturedByUser, config.DB.Model(&models.Capture{}).
+			Where("daemon_id = ? AND status = ?", userID, models.CaptureStatusCaptured)},
+		{&impact.AssignedReports, config.DB.Model(&models.Report{}).
+			Where("assignee_id = ? AND status IN ?", userID, openReports)},
+		{&impact.SquadMemberships, config.DB.Model(&models.SquadMember{}).Where("user_id = ?", userID)},
+		{&impact.SquadsLed, config.DB.Model(&models.Squad{}).Where("leader_id = ?", userID)},
+		{&impact.TeamMemberships, config.DB.Model(&models.TeamMember{}).Where("user_id = ?", userID)},
+		{&impact.AuthoredReports, config.DB.Model(&models.Report{}).Where("author_id = ?", userID)},
+		{&impact.CaptureHistory, config.DB.Model(&models.Capture{}).
+			Where("(daemon_id = ? OR target_id = ?) AND status <> ?", userID, userID, models.CaptureStatusCaptured)},
+		{&impact.PunishmentHistory, config.DB.Model(&models.Punishment{}).
+			Where("target_id = ? AND status <> ?", userID, models.PunishmentStatusActive)},
+	}
+	for _, count := range counts {
+		if err := count.query.Count(count.target).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	var tips, memes int64
+	if err := config.DB.Model(&models.SurvivalTip{}).Where("submitted_by_id = ?", userID).Count(&tips).Error; err != nil {
+		return nil, err
+	}
+	if err := config.DB.Model(&models.ResistanceMeme{}).Where("submitted_by_id = ?", userID).Count(&memes).Error; err != nil {
+		return nil, err
+	}
+	impact.SubmittedContent = tips + memes
+
+	return impact, nil
+}
+
+// DeleteWithPolicy aplica en una transacción la política de eliminación y
+// marca al usuario como eliminado (soft delete)
+func (dao *UserDAO) DeleteWithPolicy(deletion *UserDeletion, outbox OutboxWriter) error {
+	userID := deletion.User.ID
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		// Cascada: estadísticas (vuelven si se restaura al usuario)
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Statistic{}).Error; err != nil {
+			return err
+		}
+
+		// Cascada: castigos vigentes
+		err := tx.Model(&models.Punishment{}).
+			Where("target_id = ? AND status = ?", userID, models.PunishmentStatusActive).
+			Update("status", models.PunishmentStatusCancelled).Error
+		if err != nil {
+			return err
+		}
+
+		// Cascada: se liberan sus capturas vigentes, como objetivo y como captor
+		if err := releaseCaptures(tx, userID); err != nil {
+			return err
+		}
+
+		// Reasignación: reportes abiertos
+		if err := reassignReports(tx, deletion); err != nil {
+			return err
+		}
+
+		// Cascada: escuadrón; los que lideraba quedan sin líder
+		if err := tx.Where("user_id = ?", userID).Delete(&models.SquadMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Squad{}).Where("leader_id = ?", userID).Update("leader_id", nil).Error; err != nil {
+			return err
+		}
+
+		// Cascada: equipo, con las mismas reglas que al salir de él
+		var membership models.TeamMember
+		err = tx.Where("user_id = ?", userID).First(&membership).Error
+		if err == nil {
+			if err := removeTeamMember(tx, &membership); err != nil {
+				return err
+			}
+		} else if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		// Anonimización: el contenido de la resistencia se conserva sin autor
+		for _, model := range []interface{}{&models.SurvivalTip{}, &models.ResistanceMeme{}} {
+			err := tx.Model(model).Unscoped().
+				Where("submitted_by_id = ?", userID).
+				UpdateColumn("submitted_by_id", nil).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Delete(&models.User{}, userID).Error; err != nil {
+			return err
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
+
+// releaseCaptures libera las capturas vigentes del usuario. Los network admins
+// que capturó vuelven a estar activos si no tienen otra captura vigente
+func releaseCaptures(tx *gorm.DB, userID uint) error {
+	var targetIDs []uint
+	err := tx.Model(&models.Capture{}).
+		Where("daemon_id = ? AND status = ?", userID, models.CaptureStatusCaptured).
+		Pluck("target_id", &targetIDs).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Model(&models.Capture{}).
+		Where("(daemon_id = ? OR target_id = ?) AND status = ?", userID, userID, models.CaptureStatusCaptured).
+		Update("status", models.CaptureStatusReleased).Error
+	if err != nil {
+		return err
+	}
+	if len(targetIDs) == 0 {
+		return nil
+	}
+
+	stillCaptured := tx.Model(&models.Capture{}).
+		Select("1").
+		Where("captures.target_id = users.id AND captures.status = ?", models.CaptureStatusCaptured)
+	return tx.Model(&models.User{}).
+		Where("id IN ? AND status = ?", targetIDs, models.StatusCaptured).
+		Where("NOT EXISTS (?)", stillCaptured).
+		Update("status", models.StatusActive).Error
+}
+
+// reassignReports pasa los reportes abiertos del usuario a deletion.ReassignTo
+// o, si no hay, los devuelve a la cola de pendientes. Cada cambio queda en el historial
+func reassignReports(tx *gorm.DB, deletion *UserDeletion) error {
+	var reports []models.Report
+	err := tx.Select("id", "status").
+		Where("assignee_id = ? AND status IN ?", deletion.User.ID,
+			[]string{models.ReportStatusPending, models.ReportStatusInReview}).
+		Find(&reports).Error
+	if err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		change := models.ReportStatusChange{
+			ReportID:    report.ID,
+			FromStatus:  report.Status,
+			ToStatus:    report.Status,
+			ChangedByID: &deletion.DeletedByID,
+		}
+		updates := map[string]interface{}{"assignee_id": nil}
+
+		if deletion.ReassignTo != nil {
+			updates["assignee_id"] = deletion.ReassignTo.ID
+			change.AssigneeID = &deletion.ReassignTo.ID
+			change.Reason = fmt.Sprintf("Reassigned to %s: %s was deleted", deletion.ReassignTo.Username, deletion.User.Username)
+		} else {
+			updates["status"] = models.ReportStatusPending
+			change.ToStatus = models.ReportStatusPending
+			change.Reason = fmt.Sprintf("Returned to the queue: %s was deleted", deletion.User.Username)
+		}
+
+		if err := tx.Model(&models.Report{}).Where("id = ?", report.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&change).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Actualizar campos del perfil propio (nombre visible, bio, avatar) y
+// registrar sus eventos en la misma transacción
+func (dao *UserDAO) UpdateProfile(userID uint, updates map[string]interface{}, outbox OutboxWriter) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).Where("id = ?", userID).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return writeOutbox(tx, outbox)
+	})
+}
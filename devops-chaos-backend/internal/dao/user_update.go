@@ -0,0 +1,233 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrLastAndrei indica que el cambio dejaría al sistema sin ningún Andrei
+var ErrLastAndrei = errors.New("cannot demote the last andrei")
+
+// UserChange describe un cambio de identidad, rol o status ya validado
+type UserChange struct {
+	User     *models.User // Usuario tal como se validó
+	Username string
+	Email    string
+	Role     string
+	Status   string
+}
+
+// RoleChanged indica si el cambio modifica el rol
+func (c *UserChange) RoleChanged() bool {
+	return c.Role != c.User.Role
+}
+
+// StatusChanged indica si el cambio modifica el status
+func (c *UserChange) StatusChanged() bool {
+	return c.Status != c.User.Status
+}
+
+// UserChangeEffects cuenta las filas relacionadas que modificó el cambio
+type UserChangeEffects struct {
+	StatisticsRemoved int64
+	StatisticsAdded   int64
+	PunishmentsClosed int64
+	CapturesReleased  int64
+	SquadMemberships  int64
+	ReleasedCaptures  []models.Capture // Solo ID, daemon y target
+	SquadsLed         int64
+	TeamMemberships   int64
+}
+
+// ApplyChange guarda el cambio y sus efectos en una transacción:
+//   - al dejar de ser daemon se borran sus estadísticas (vuelven si recupera
+//     el rol), se cancelan sus castigos, se liberan sus cautivos y sale de su
+//     escuadrón
+//   - al pasar a daemon se restauran o crean sus estadísticas
+//   - al dejar de ser network admin se liberan sus capturas y sale de su equipo
+//   - pasar de punished a active cancela los castigos vigentes y pasar de
+//     captured a active libera las capturas vigentes
+//
+// Devuelve gorm.ErrRecordNotFound si el usuario cambió de rol o status desde
+// que se validó, gorm.ErrDuplicatedKey si el username o el email ya existen y
+// ErrLastAndrei si se degrada al único Andrei
+func (dao *UserDAO) ApplyChange(change *UserChange, outbox func(*UserChangeEffects) OutboxWriter) (*UserChangeEffects, error) {
+	effects := &UserChangeEffects{}
+	user := change.User
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if user.Role == models.RoleAndrei && change.RoleChanged() {
+			var andreis []uint
+			err := tx.Model(&models.User{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("role = ?", models.RoleAndrei).
+				Pluck("id", &andreis).Error
+			if err != nil {
+				return err
+			}
+			if len(andreis) <= 1 {
+				return ErrLastAndrei
+			}
+		}
+
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND role = ? AND status = ?", user.ID, user.Role, user.Status).
+			Updates(map[string]interface{}{
+				"username": change.Username,
+				"email":    change.Email,
+				"role":     change.Role,
+				"status":   change.Status,
+			})
+		if result.Error != nil {
+			return translateError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		leavesDaemon := user.Role == models.RoleDaemon && change.Role != models.RoleDaemon
+		leavesNetworkAdmin := user.Role == models.RoleNetworkAdmin && change.Role != models.RoleNetworkAdmin
+
+		if leavesDaemon {
+			if err := removeDaemonRelations(tx, user.ID, effects); err != nil {
+				return err
+			}
+		}
+		if change.Role == models.RoleDaemon && user.Role != models.RoleDaemon {
+			if err := ensureStatistics(tx, user.ID, effects); err != nil {
+				return err
+			}
+		}
+		if leavesNetworkAdmin {
+			if err := removeNetworkAdminRelations(tx, user.ID, effects); err != nil {
+				return err
+			}
+		}
+
+		if !change.RoleChanged() && change.Status == models.StatusActive {
+			switch user.Status {
+			case models.StatusPunished:
+				if err := cancelActivePunishments(tx, user.ID, effects); err != nil {
+					return err
+				}
+			case models.StatusCaptured:
+				if err := releaseCapturesCounted(tx, user.ID, effects); err != nil {
+					return err
+				}
+			}
+		}
+
+		return writeOutbox(tx, outbox(effects))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return effects, nil
+}
+
+// removeDaemonRelations deshace lo que solo corresponde a un daemon
+func removeDaemonRelations(tx *gorm.DB, userID uint, effects *UserChangeEffects) error {
+	result := tx.Where("user_id = ?", userID).Delete(&models.Statistic{})
+	if result.Error != nil {
+		return result.Error
+	}
+	effects.StatisticsRemoved = result.RowsAffected
+
+	if err := cancelActivePunishments(tx, userID, effects); err != nil {
+		return err
+	}
+	if err := releaseCapturesCounted(tx, userID, effects); err != nil {
+		return err
+	}
+
+	result = tx.Where("user_id = ?", userID).Delete(&models.SquadMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	effects.SquadMemberships = result.RowsAffected
+
+	result = tx.Model(&models.Squad{}).Where("leader_id = ?", userID).Update("leader_id", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	effects.SquadsLed = result.RowsAffected
+	return nil
+}
+
+// removeNetworkAdminRelations deshace lo que solo corresponde a un network admin
+func removeNetworkAdminRelations(tx *gorm.DB, userID uint, effects *UserChangeEffects) error {
+	if err := releaseCapturesCounted(tx, userID, effects); err != nil {
+		return err
+	}
+
+	var membership models.TeamMember
+	err := tx.Where("user_id = ?", userID).First(&membership).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	effects.TeamMemberships = 1
+	return removeTeamMember(tx, &membership)
+}
+
+// ensureStatistics restaura las estadísticas borradas de un ex daemon o, si
+// nunca las tuvo, las crea
+func ensureStatistics(tx *gorm.DB, userID uint, effects *UserChangeEffects) error {
+	result := tx.Model(&models.Statistic{}).Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		effects.StatisticsAdded = result.RowsAffected
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&models.Statistic{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	if err := tx.Create(&models.Statistic{UserID: userID}).Error; err != nil {
+		return err
+	}
+	effects.StatisticsAdded = 1
+	return nil
+}
+
+func cancelActivePunishments(tx *gorm.DB, userID uint, effects *UserChangeEffects) error {
+	result := tx.Model(&models.Punishment{}).
+		Where("target_id = ? AND status = ?", userID, models.PunishmentStatusActive).
+		Update("status", models.PunishmentStatusCancelled)
+	if result.Error != nil {
+		return result.Error
+	}
+	effects.PunishmentsClosed = result.RowsAffected
+	return nil
+}
+
+// releaseCapturesCounted cuenta las capturas vigentes y las libera
+func releaseCapturesCounted(tx *gorm.DB, userID uint, effects *UserChangeEffects) error {
+	var captures []models.Capture
+	err := tx.Select("id", "daemon_id", "target_id").
+		Where("(daemon_id = ? OR target_id = ?) AND status = ?", userID, userID, models.CaptureStatusCaptured).
+		Find(&captures).Error
+	if err != nil {
+		return err
+	}
+	if len(captures) == 0 {
+		return nil
+	}
+	effects.ReleasedCaptures = append(effects.ReleasedCaptures, captures...)
+	effects.CapturesReleased += int64(len(captures))
+	return releaseCaptures(tx, userID)
+}
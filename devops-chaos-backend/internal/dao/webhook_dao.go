@@ -0,0 +1,147 @@
+package dao
+
+import (
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookDAO struct{}
+
+func NewWebhookDAO() *WebhookDAO {
+	return &WebhookDAO{}
+}
+
+var deliveryListSpec = &ListSpec{
+	SortFields: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+	},
+	FilterFields: map[string]string{
+		"status":     "status",
+		"event_type": "event_type",
+	},
+	DateColumn:   "created_at",
+	DefaultSort:  "created_at",
+	DefaultOrder: "desc",
+}
+
+// DeliveriesOf restringe a las entregas de un webhook
+func DeliveriesOf(webhookID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("webhook_id = ?", webhookID)
+	}
+}
+
+// Crear webhook
+func (dao *WebhookDAO) Create(webhook *models.Webhook) error {
+	return config.DB.Create(webhook).Error
+}
+
+// Buscar webhook por ID
+func (dao *WebhookDAO) FindByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := config.DB.First(&webhook, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Listar webhooks
+func (dao *WebhookDAO) FindAll() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := config.DB.Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// Webhooks activos
+func (dao *WebhookDAO) FindActive() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := config.DB.Where("active = ?", true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+// Actualizar webhook
+func (dao *WebhookDAO) Update(webhook *models.Webhook) error {
+	return config.DB.Save(webhook).Error
+}
+
+// Eliminar webhook; sus entregas pendientes no se envían
+func (dao *WebhookDAO) Delete(id uint) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.WebhookDelivery{}).
+			Where("webhook_id = ? AND status IN ?", id, []string{models.DeliveryStatusPending, models.DeliveryStatusDelivering}).
+			Updates(map[string]interface{}{
+				"status":     models.DeliveryStatusDead,
+				"last_error": "webhook deleted",
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&models.Webhook{}, id).Error
+	})
+}
+
+// Encolar entregas
+func (dao *WebhookDAO) CreateDeliveries(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return config.DB.Create(&deliveries).Error
+}
+
+// Listar entregas con paginación y filtros
+func (dao *WebhookDAO) ListDeliveries(q *ListQuery) ([]models.WebhookDelivery, *PageInfo, error) {
+	return findPage[models.WebhookDelivery](deliveryListSpec, q)
+}
+
+// Buscar entrega de un webhook
+func (dao *WebhookDAO) FindDelivery(webhookID, id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := config.DB.Where("id = ? AND webhook_id = ?", id, webhookID).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ClaimDue toma hasta limit entregas vencidas y las marca como en curso hasta
+// lease. Una entrega en curso cuyo lease venció (p.ej. el proceso se cayó) se
+// vuelve a tomar. SKIP LOCKED permite varias instancias sin duplicar envíos
+func (dao *WebhookDAO) ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []string{models.DeliveryStatusPending, models.DeliveryStatusDelivering}, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":          models.DeliveryStatusDelivering,
+				"next_attempt_at": now.Add(lease),
+			}).Error
+	})
+	return deliveries, err
+}
+
+// Guardar el resultado de un intento
+func (dao *WebhookDAO) SaveAttempt(delivery *models.WebhookDelivery) error {
+	return config.DB.Model(delivery).Select(
+		"status", "attempts", "next_attempt_at", "last_attempt_at",
+		"last_status_code", "last_error", "delivered_at",
+	).Updates(delivery).Error
+}
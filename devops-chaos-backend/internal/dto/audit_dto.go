@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Entrada del registro de auditoría
+type AuditLogResponse struct {
+	ID        uint            `json:"id"`
+	EventID   string          `json:"event_id"`
+	Event     string          `json:"event"`
+	ActorID   *uint           `json:"actor_id,omitempty"`
+	SubjectID *uint           `json:"subject_id,omitempty"`
+	Summary   string          `json:"summary"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
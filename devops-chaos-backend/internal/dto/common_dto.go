@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // Response paginada genérica
 type PaginatedResponse struct {
 	Success    bool        `json:"success"`
@@ -9,6 +11,21 @@ type PaginatedResponse struct {
 	Limit      int         `json:"limit"`
 	TotalItems int64       `json:"total_items"`
 	TotalPages int         `json:"total_pages"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	HasMore    bool        `json:"has_more"`
+}
+
+// Parámetros comunes de listado: paginación, orden y filtros
+type ListParams struct {
+	Page    int
+	Limit   int
+	Cursor  string            // Cursor opaco; si se envía se ignora Page
+	Sort    string            // Campo de orden público (p.ej. "created_at")
+	Order   string            // "asc" o "desc"
+	Filters map[string]string // Filtros de igualdad por campo público
+	From    *time.Time        // Inicio del rango de fechas (inclusive)
+	To      *time.Time        // Fin del rango de fechas (inclusive)
+	Search  string            // Texto libre
 }
 
 // Response estándar de la API
@@ -19,9 +36,16 @@ type ApiResponse struct {
 	Error   string      `json:"error,omitempty"`
 }
 
-// Response de error
-type ErrorResponse struct {
+// Response de error en formato RFC 7807 (application/problem+json)
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+
+	// Extensiones compatibles con el formato de error anterior
 	Success bool   `json:"success"`
 	Message string `json:"message"`
-	Error   string `json:"error"`
 }
@@ -4,12 +4,14 @@ import "time"
 
 // Response del dashboard de Andrei
 type AndreiDashboardResponse struct {
-	WelcomeMessage string                 `json:"welcome_message"`
-	SystemStats    SystemStats            `json:"system_stats"`
-	RecentReports  []ReportListItem       `json:"recent_reports"`
-	TopDaemons     []TopPerformerResponse `json:"top_daemons"`
-	RecentActivity []ActivityItem         `json:"recent_activity"`
-	AllCaptures    []CaptureDetailItem    `json:"all_captures"`
+	WelcomeMessage  string                 `json:"welcome_message"`
+	SystemStats     SystemStats            `json:"system_stats"`
+	CriticalReports []ReportListItem       `json:"critical_reports"` // Anónimos críticos abiertos
+	RecentReports   []ReportListItem       `json:"recent_reports"`
+	TopDaemons      []TopPerformerResponse `json:"top_daemons"`
+	SquadTrend      []SquadPerformance     `json:"squad_trend"` // Puntos semanales por escuadrón
+	RecentActivity  []ActivityItem         `json:"recent_activity"`
+	AllCaptures     []CaptureDetailItem    `json:"all_captures"`
 }
 
 // Response del dashboard de Daemon
@@ -32,6 +34,7 @@ type SystemStats struct {
 	PunishedDaemons int `json:"punished_daemons"`
 	PendingReports  int `json:"pending_reports"`
 	TotalReports    int `json:"total_reports"`
+	CriticalReports int `json:"critical_anonymous_reports"`
 }
 
 // Estadísticas del daemon
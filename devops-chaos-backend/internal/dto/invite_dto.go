@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// Request para invitar a alguien (solo Andrei)
+type CreateInviteRequest struct {
+	Role    string `json:"role" binding:"required"`
+	Email   string `json:"email" binding:"omitempty,email"`
+	Channel string `json:"channel" binding:"required"`
+}
+
+// Request opcional para reenviar una invitación por otro canal
+type ResendInviteRequest struct {
+	Channel string `json:"channel"`
+}
+
+// Request para aceptar una invitación; el token va en el cuerpo, nunca en la URL
+type AcceptInviteRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Username string `json:"username" binding:"required"`
+	Email    string `json:"email" binding:"omitempty,email"` // Obligatorio si la invitación no trae email
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// Response de invitación; el enlace solo se incluye al crearla o reenviarla
+// por un canal que no lo entrega por sí mismo
+type InviteResponse struct {
+	ID             uint       `json:"id"`
+	Role           string     `json:"role"`
+	Email          string     `json:"email,omitempty"`
+	Channel        string     `json:"channel"`
+	Status         string     `json:"status"`
+	InvitedByID    uint       `json:"invited_by_id"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	SentCount      int        `json:"sent_count"`
+	LastSentAt     *time.Time `json:"last_sent_at,omitempty"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	AcceptedUserID *uint      `json:"accepted_user_id,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Link           string     `json:"link,omitempty"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// Notificación de la bandeja
+type NotificationResponse struct {
+	ID        uint       `json:"id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Cantidad de notificaciones sin leer
+type UnreadCountResponse struct {
+	Unread int64 `json:"unread"`
+}
+
+// Preferencia por tipo de notificación
+type NotificationPreferenceItem struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Request para actualizar preferencias (tipo -> activado)
+type UpdateNotificationPreferencesRequest struct {
+	Preferences map[string]bool `json:"preferences" binding:"required"`
+}
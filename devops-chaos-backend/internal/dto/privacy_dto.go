@@ -0,0 +1,59 @@
+package dto
+
+import "time"
+
+// Copia de los datos personales de un usuario. De los demás usuarios solo
+// incluye el username
+type PersonalDataExport struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Profile     PersonalProfile      `json:"profile"`
+	Statistics  *StatisticResponse   `json:"statistics,omitempty"`
+	Reports     []PersonalReport     `json:"reports"`
+	Comments    []PersonalComment    `json:"comments"`
+	Captures    []CaptureDetailItem  `json:"captures"`
+	Punishments []PunishmentListItem `json:"punishments"`
+}
+
+// Perfil del usuario que exporta sus datos
+type PersonalProfile struct {
+	ID          uint      `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	Email       string    `json:"email"`
+	Bio         string    `json:"bio"`
+	Role        string    `json:"role"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Reporte escrito por el usuario
+type PersonalReport struct {
+	ID              uint      `json:"id"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	Type            string    `json:"type"`
+	Status          string    `json:"status"`
+	Severity        string    `json:"severity"`
+	Location        string    `json:"location,omitempty"`
+	Assignee        string    `json:"assignee,omitempty"`
+	RejectionReason string    `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Comentario de revisión escrito por el usuario
+type PersonalComment struct {
+	ID        uint      `json:"id"`
+	ReportID  uint      `json:"report_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Resultado de anonimizar a un usuario
+type AnonymizeUserResponse struct {
+	ID           uint      `json:"id"`
+	Username     string    `json:"username"`
+	Email        string    `json:"email"`
+	AnonymizedAt time.Time `json:"anonymized_at"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// Perfil propio; PendingEmail es la dirección nueva aún sin verificar
+type ProfileResponse struct {
+	ID           uint      `json:"id"`
+	Username     string    `json:"username"`
+	DisplayName  string    `json:"display_name"`
+	Email        string    `json:"email"`
+	PendingEmail string    `json:"pending_email,omitempty"`
+	Bio          string    `json:"bio"`
+	AvatarURL    string    `json:"avatar_url,omitempty"`
+	Role         string    `json:"role"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Request para editar el perfil propio; los campos ausentes no cambian.
+// Rol y status no se pueden editar desde aquí
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"display_name"`
+	Bio         *string `json:"bio"`
+}
+
+// Request para cambiar el email; exige la contraseña actual
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Cambio de email pendiente de verificar
+type EmailChangeResponse struct {
+	NewEmail  string    `json:"new_email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Request para confirmar el email nuevo con el token recibido
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// Sesión abierta del usuario
+type SessionResponse struct {
+	ID         uint      `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Cantidad de sesiones cerradas
+type RevokedSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
@@ -6,19 +6,25 @@ import "time"
 type CreateReportRequest struct {
 	Title       string `json:"title" binding:"required"`
 	Description string `json:"description" binding:"required"`
-	Type        string `json:"type" binding:"required"` // "resistance", "capture", "anonymous"
+	Type        string `json:"type" binding:"required"` // "resistance" o "capture"; los anónimos van por /api/resistance/report
+	Severity    string `json:"severity,omitempty" binding:"omitempty,oneof=low medium high critical"`
+	Location    string `json:"location,omitempty" binding:"max=255"`
 }
 
 // Response de reporte
 type ReportResponse struct {
-	ID          uint      `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Type        string    `json:"type"`
-	Status      string    `json:"status"`
-	Author      *UserInfo `json:"author,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID              uint      `json:"id"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	Type            string    `json:"type"`
+	Status          string    `json:"status"`
+	Severity        string    `json:"severity"`
+	Location        string    `json:"location,omitempty"`
+	Author          *UserInfo `json:"author,omitempty"`
+	Assignee        *UserInfo `json:"assignee,omitempty"`
+	RejectionReason string    `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 // Item para lista de reportes
@@ -28,12 +34,67 @@ type ReportListItem struct {
 	Description string    `json:"description"`
 	Type        string    `json:"type"`
 	Status      string    `json:"status"`
+	Severity    string    `json:"severity"`
+	Location    string    `json:"location,omitempty"`
 	AuthorID    *uint     `json:"author_id"`
 	Author      string    `json:"author"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+// Resultado de búsqueda de reportes con fragmentos resaltados (<mark>)
+type ReportSearchResult struct {
+	ReportListItem
+	Rank                 float64 `json:"rank"`
+	TitleHighlight       string  `json:"title_highlight"`
+	DescriptionHighlight string  `json:"description_highlight"`
+}
+
 // Request para actualizar reporte
 type UpdateReportRequest struct {
-	Status string `json:"status" binding:"required"`
+	Status string `json:"status" binding:"required"` // "pending", "in_review", "approved", "rejected"
+	Reason string `json:"reason,omitempty"`          // Obligatorio al rechazar
+}
+
+// Request para asignar un daemon investigador
+type AssignReportRequest struct {
+	AssigneeID uint `json:"assignee_id" binding:"required"`
+}
+
+// Request para comentar un reporte
+type CreateReportCommentRequest struct {
+	Body string `json:"body" binding:"required,max=4000"`
+}
+
+// Comentario de revisión
+type ReportCommentResponse struct {
+	ID         uint      `json:"id"`
+	Author     string    `json:"author"`
+	AuthorRole string    `json:"author_role"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Entrada del historial de estados
+type ReportHistoryItem struct {
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  string    `json:"changed_by"` // "anonymous" si no hay autor
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Campos de formulario que acompañan a un adjunto (multipart, campo "file")
+type UploadAttachmentRequest struct {
+	ReceiptCode string `form:"receipt_code"` // Obligatorio para network admins en reportes anónimos
+}
+
+// Adjunto de evidencia de un reporte
+type ReportAttachmentResponse struct {
+	ID          uint      `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	UploadedBy  string    `json:"uploaded_by"` // "anonymous" en reportes anónimos
+	CreatedAt   time.Time `json:"created_at"`
 }
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // Response para la página de resistencia (Network Admins)
 type ResistancePageResponse struct {
 	WelcomeMessage       string             `json:"welcome_message"`
@@ -13,28 +15,63 @@ type ResistancePageResponse struct {
 
 // Consejos de supervivencia
 type SurvivalTip struct {
-	ID          int    `json:"id"`
+	ID          uint   `json:"id"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Priority    string `json:"priority"` // "low", "medium", "high", "critical"
 	Category    string `json:"category"` // "technical", "social", "security"
+	Upvotes     int    `json:"upvotes"`
+	Status      string `json:"status,omitempty"` // Solo visible para moderadores
 }
 
 // Memes de resistencia
 type ResistanceMeme struct {
-	ID          int    `json:"id"`
+	ID          uint   `json:"id"`
 	Title       string `json:"title"`
 	ImageURL    string `json:"image_url"`
 	Description string `json:"description"`
 	Upvotes     int    `json:"upvotes"`
+	Status      string `json:"status,omitempty"` // Solo visible para moderadores
+}
+
+// Request para enviar un consejo
+type CreateSurvivalTipRequest struct {
+	Title       string `json:"title" binding:"required,max=120"`
+	Description string `json:"description" binding:"required,max=2000"`
+	Priority    string `json:"priority" binding:"required,oneof=low medium high critical"`
+	Category    string `json:"category" binding:"required,oneof=technical social security"`
+}
+
+// Request (multipart) para enviar un meme; la imagen va en el campo "image"
+type CreateResistanceMemeRequest struct {
+	Title       string `form:"title" binding:"required,max=120"`
+	Description string `form:"description" binding:"max=500"`
+}
+
+// Request para moderar un consejo o meme
+type ModerateContentRequest struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+	Reason string `json:"reason,omitempty" binding:"max=500"`
 }
 
 // Estadísticas de la resistencia
 type ResistanceStats struct {
-	TotalNetworkAdmins int `json:"total_network_admins"`
-	CapturedAdmins     int `json:"captured_admins"`
-	FreeAdmins         int `json:"free_admins"`
-	AnonymousReports   int `json:"anonymous_reports_today"`
+	TotalNetworkAdmins   int               `json:"total_network_admins"`
+	CapturedAdmins       int               `json:"captured_admins"`
+	FreeAdmins           int               `json:"free_admins"`
+	AnonymousReports     int               `json:"anonymous_reports_today"`
+	AnonymousReportsWeek int               `json:"anonymous_reports_week"`
+	CapturesToday        int               `json:"captures_today"`
+	CapturesWeek         int               `json:"captures_week"`
+	Trend                []DailyTrendPoint `json:"trend"` // Últimos 30 días
+	Timezone             string            `json:"timezone"`
+}
+
+// Punto diario de la tendencia de la resistencia
+type DailyTrendPoint struct {
+	Date             string `json:"date"` // YYYY-MM-DD en Timezone
+	AnonymousReports int    `json:"anonymous_reports"`
+	Captures         int    `json:"captures"`
 }
 
 // Contactos de emergencia
@@ -49,6 +86,43 @@ type EmergencyContact struct {
 type ReportSuspiciousActivityRequest struct {
 	Title       string `json:"title" binding:"required"`
 	Description string `json:"description" binding:"required"`
-	Location    string `json:"location,omitempty"`
-	Severity    string `json:"severity" binding:"required"` // "low", "medium", "high", "critical"
+	Location    string `json:"location,omitempty" binding:"max=255"`
+	Severity    string `json:"severity" binding:"required,oneof=low medium high critical"`
+	Ticket      string `json:"ticket" binding:"required"` // De GET /resistance/report/challenge
+	Nonce       string `json:"nonce" binding:"required,max=64"`
+}
+
+// Reto para enviar un reporte anónimo: ticket de un solo uso + prueba de trabajo
+type SubmissionChallenge struct {
+	Ticket     string    `json:"ticket"`
+	Difficulty int       `json:"difficulty"`
+	Algorithm  string    `json:"algorithm"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Respuesta al enviar un reporte anónimo; el código no se puede recuperar después
+type AnonymousReportReceipt struct {
+	ReceiptCode string `json:"receipt_code"`
+}
+
+// Request para consultar un reporte con su código de recibo
+type ReceiptLookupRequest struct {
+	Code string `json:"code" binding:"required,max=64"`
+}
+
+// Request para agregar una nota de seguimiento con el código de recibo
+type ReceiptNoteRequest struct {
+	Code string `json:"code" binding:"required,max=64"`
+	Body string `json:"body" binding:"required,max=4000"`
+}
+
+// Estado de un reporte anónimo visto por su remitente
+type ReceiptStatusResponse struct {
+	Title           string                  `json:"title"`
+	Severity        string                  `json:"severity"`
+	Status          string                  `json:"status"`
+	RejectionReason string                  `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+	Comments        []ReportCommentResponse `json:"comments"`
 }
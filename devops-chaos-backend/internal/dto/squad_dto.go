@@ -0,0 +1,64 @@
+package dto
+
+import "time"
+
+// Request para crear un escuadrón (solo Andrei)
+type CreateSquadRequest struct {
+	Name        string `json:"name" binding:"required,min=3,max=80"`
+	Description string `json:"description" binding:"max=1000"`
+	LeaderID    *uint  `json:"leader_id,omitempty"`
+}
+
+// Request para actualizar un escuadrón (solo Andrei)
+type UpdateSquadRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,min=3,max=80"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=1000"`
+	LeaderID    *uint   `json:"leader_id,omitempty"`
+}
+
+// Request para sumar un daemon al escuadrón
+type AddSquadMemberRequest struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
+
+// Response de escuadrón
+type SquadResponse struct {
+	ID          uint              `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Leader      *UserInfo         `json:"leader,omitempty"`
+	Members     []SquadMemberInfo `json:"members"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// Miembro de un escuadrón
+type SquadMemberInfo struct {
+	UserID   uint      `json:"user_id"`
+	Username string    `json:"username"`
+	Status   string    `json:"status"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// Ranking de escuadrones para leaderboard
+type SquadRankingItem struct {
+	Position      int    `json:"position"`
+	SquadID       uint   `json:"squad_id"`
+	Name          string `json:"name"`
+	MemberCount   int    `json:"member_count"`
+	CapturesCount int    `json:"captures_count"`
+	ReportsCount  int    `json:"reports_count"`
+	Points        int    `json:"points"`
+}
+
+// Rendimiento semanal de un escuadrón para el dashboard de Andrei
+type SquadPerformance struct {
+	SquadID uint               `json:"squad_id"`
+	Name    string             `json:"name"`
+	Points  int                `json:"points"` // Total del período
+	Weekly  []SquadWeeklyPoint `json:"weekly"`
+}
+
+type SquadWeeklyPoint struct {
+	WeekStart string `json:"week_start"` // YYYY-MM-DD, lunes en la zona de estadísticas
+	Points    int    `json:"points"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// Evento de nueva captura
+type CaptureEvent struct {
+	CaptureID   uint      `json:"capture_id"`
+	DaemonName  string    `json:"daemon_name"`
+	TargetName  string    `json:"target_name"`
+	Difficulty  string    `json:"difficulty"`
+	Points      int       `json:"points"`
+	CaptureDate time.Time `json:"capture_date"`
+}
+
+// Evento de reporte enviado o aprobado; los anónimos nunca llevan autor
+type ReportEvent struct {
+	ReportID uint   `json:"report_id"`
+	Title    string `json:"title"`
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Status   string `json:"status"`
+	Author   string `json:"author,omitempty"`
+}
@@ -0,0 +1,81 @@
+package dto
+
+import "time"
+
+// Request para formar un equipo
+type CreateTeamRequest struct {
+	Name        string `json:"name" binding:"required,min=3,max=80"`
+	Description string `json:"description" binding:"max=1000"`
+	SafeHouse   string `json:"safe_house" binding:"max=255"`
+}
+
+// Request para actualizar un equipo (solo líder)
+type UpdateTeamRequest struct {
+	Description *string `json:"description,omitempty" binding:"omitempty,max=1000"`
+	SafeHouse   *string `json:"safe_house,omitempty" binding:"omitempty,max=255"`
+}
+
+// Request para sumar un miembro
+type AddTeamMemberRequest struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
+
+// Request para publicar en el tablero
+type CreateTeamMessageRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+// Request para aportar puntos de protección
+type ContributeProtectionRequest struct {
+	Points int `json:"points" binding:"required,min=1"`
+}
+
+// Request para rescatar a un miembro capturado
+type RescueTeamMemberRequest struct {
+	MemberID uint `json:"member_id" binding:"required"`
+}
+
+// Vista pública de un equipo (sin miembros ni refugio)
+type TeamSummary struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MemberCount int    `json:"member_count"`
+}
+
+// Vista completa del equipo para sus miembros
+type TeamDetail struct {
+	ID               uint             `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	SafeHouse        string           `json:"safe_house"`
+	ProtectionPoints int              `json:"protection_points"`
+	Members          []TeamMemberInfo `json:"members"`
+	CreatedAt        time.Time        `json:"created_at"`
+}
+
+// Miembro del equipo
+type TeamMemberInfo struct {
+	UserID   uint      `json:"user_id"`
+	Username string    `json:"username"`
+	Status   string    `json:"status"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// Mensaje del tablero
+type TeamMessageResponse struct {
+	ID        uint      `json:"id"`
+	AuthorID  uint      `json:"author_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Resultado de un rescate
+type TeamRescueResponse struct {
+	CaptureID       uint `json:"capture_id"`
+	RescuedID       uint `json:"rescued_id"`
+	PointsSpent     int  `json:"points_spent"`
+	RemainingPoints int  `json:"remaining_points"`
+}
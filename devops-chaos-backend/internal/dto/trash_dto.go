@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// Elemento de la papelera; Data es la representación habitual de la entidad
+type TrashItem struct {
+	Entity    string      `json:"entity"`
+	ID        uint        `json:"id"`
+	Label     string      `json:"label"`
+	DeletedAt time.Time   `json:"deleted_at"`
+	PurgeAt   *time.Time  `json:"purge_at,omitempty"` // Cuándo la purgará la retención, si está activa
+	Data      interface{} `json:"data"`
+}
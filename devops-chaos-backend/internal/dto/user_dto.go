@@ -32,3 +32,65 @@ type CaptureResponse struct {
 	Message     string `json:"message"`
 	PointsGiven int    `json:"points_given"`
 }
+
+// Fila de una importación de usuarios; en CSV las columnas llevan los mismos
+// nombres y las desconocidas se ignoran (una exportación se puede reimportar)
+type ImportUserRow struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	Password string `json:"password,omitempty"` // Si falta se genera una
+}
+
+// Error de validación de una fila. Row es la línea en un CSV (la cabecera es
+// la 1) o la posición en el arreglo JSON, desde 1
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Usuario válido o creado en una importación
+type ImportedUser struct {
+	Row               int    `json:"row"`
+	ID                uint   `json:"id,omitempty"`
+	Username          string `json:"username"`
+	Email             string `json:"email"`
+	Role              string `json:"role"`
+	PasswordGenerated bool   `json:"password_generated"`
+	InitialPassword   string `json:"initial_password,omitempty"` // Solo se muestra en esta respuesta
+}
+
+// Resultado de una importación o de su simulación
+type ImportUsersResponse struct {
+	DryRun    bool             `json:"dry_run"`
+	TotalRows int              `json:"total_rows"`
+	ValidRows int              `json:"valid_rows"`
+	Created   int              `json:"created"`
+	Errors    []ImportRowError `json:"errors"`
+	Users     []ImportedUser   `json:"users"`
+}
+
+// Efecto de eliminar un usuario sobre una relación
+type DeletionEffect struct {
+	Relation    string `json:"relation"`
+	Policy      string `json:"policy"` // "cascade", "reassign", "anonymize", "retain"
+	Count       int64  `json:"count"`
+	Description string `json:"description"`
+}
+
+// Motivo por el que no se puede eliminar un usuario
+type DeletionBlocker struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Vista previa de la eliminación de un usuario
+type UserDeletionPreview struct {
+	User       UserInfo          `json:"user"`
+	Allowed    bool              `json:"allowed"`
+	Blockers   []DeletionBlocker `json:"blockers,omitempty"`
+	ReassignTo *UserInfo         `json:"reassign_to,omitempty"`
+	Effects    []DeletionEffect  `json:"effects"`
+}
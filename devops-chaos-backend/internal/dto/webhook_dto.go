@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Request para registrar un webhook (solo Andrei)
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=2048"`
+	Description string   `json:"description" binding:"max=255"`
+	Events      []string `json:"events" binding:"required,min=1,dive,required"`
+}
+
+// Request para actualizar un webhook
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url,omitempty" binding:"omitempty,url,max=2048"`
+	Description *string  `json:"description,omitempty" binding:"omitempty,max=255"`
+	Events      []string `json:"events,omitempty" binding:"omitempty,min=1,dive,required"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// Response de webhook; el secreto solo se incluye al crearlo o rotarlo
+type WebhookResponse struct {
+	ID          uint      `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Events      []string  `json:"events"`
+	Active      bool      `json:"active"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Response de una entrega
+type WebhookDeliveryResponse struct {
+	ID             uint       `json:"id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Payload        string     `json:"payload,omitempty"` // Solo en el detalle
+}
+
+// Cuerpo enviado a cada webhook
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
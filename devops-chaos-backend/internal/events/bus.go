@@ -0,0 +1,259 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/models"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tiempo que un evento tomado queda reservado antes de reintentarse
+const outboxLease = time.Minute
+
+// handlerFunc decodifica el payload y ejecuta la reacción de un suscriptor
+type handlerFunc func(ctx context.Context, payload []byte) error
+
+type handlerKey struct {
+	event      string
+	subscriber string
+}
+
+type eventIDKey struct{}
+
+// Bus registra los eventos de dominio en el outbox y los despacha a sus
+// suscriptores. La entrega es al menos una vez: cada reacción debe tolerar
+// repetirse si el proceso cae entre ejecutarla y marcarla como procesada
+type Bus struct {
+	outboxDAO   *dao.OutboxDAO
+	config      *config.OutboxConfig
+	subscribers map[string][]string
+	handlers    map[handlerKey]handlerFunc
+	wake        chan struct{}
+}
+
+func NewBus(cfg *config.OutboxConfig) *Bus {
+	return &Bus{
+		outboxDAO:   dao.NewOutboxDAO(),
+		config:      cfg,
+		subscribers: make(map[string][]string),
+		handlers:    make(map[handlerKey]handlerFunc),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Subscribe registra la reacción de subscriber a los eventos de tipo E. Debe
+// llamarse al arrancar, antes de registrar eventos
+func Subscribe[E Event](bus *Bus, subscriber string, handler func(ctx context.Context, event E) error) {
+	var zero E
+	key := handlerKey{event: zero.EventName(), subscriber: subscriber}
+	if _, exists := bus.handlers[key]; exists {
+		panic(fmt.Sprintf("events: %s already subscribed to %s", subscriber, key.event))
+	}
+
+	bus.handlers[key] = func(ctx context.Context, payload []byte) error {
+		var event E
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		return handler(ctx, event)
+	}
+	bus.subscribers[key.event] = append(bus.subscribers[key.event], subscriber)
+}
+
+// EventID devuelve el ID del evento que se está despachando; es el mismo para
+// todos sus suscriptores y sirve para deduplicar
+func EventID(ctx context.Context) string {
+	id, _ := ctx.Value(eventIDKey{}).(string)
+	return id
+}
+
+// Record escribe los eventos en el outbox con la transacción del cambio que
+// los origina, una fila por suscriptor. Un bus nil no registra nada
+func (b *Bus) Record(tx *gorm.DB, events ...Event) error {
+	if b == nil {
+		return nil
+	}
+
+	now := time.Now()
+	var rows []models.OutboxEvent
+	for _, event := range events {
+		subscribers := b.subscribers[event.EventName()]
+		if len(subscribers) == 0 {
+			continue
+		}
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		eventID, err := newEventID()
+		if err != nil {
+			return err
+		}
+
+		for _, subscriber := range subscribers {
+			rows = append(rows, models.OutboxEvent{
+				EventID:       eventID,
+				EventName:     event.EventName(),
+				Subscriber:    subscriber,
+				Payload:       string(payload),
+				Status:        models.OutboxStatusPending,
+				NextAttemptAt: now,
+			})
+		}
+	}
+	return b.outboxDAO.Append(tx, rows)
+}
+
+// Writer adapta Record al OutboxWriter de los DAOs. events se llama dentro de
+// la transacción, así puede usar IDs recién asignados
+func (b *Bus) Writer(events func() []Event) dao.OutboxWriter {
+	return func(tx *gorm.DB) error {
+		return b.Record(tx, events()...)
+	}
+}
+
+// Emit registra eventos que no acompañan a ningún otro cambio
+func (b *Bus) Emit(events ...Event) error {
+	if b == nil {
+		return nil
+	}
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		return b.Record(tx, events...)
+	})
+	if err == nil {
+		b.Notify()
+	}
+	return err
+}
+
+// Notify despierta al despachador tras un commit; si ya hay un aviso
+// pendiente no hace nada
+func (b *Bus) Notify() {
+	if b == nil {
+		return
+	}
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (b *Bus) Name() string {
+	return "outbox-dispatcher"
+}
+
+// Run despacha al recibir un aviso y, como respaldo, cada PollInterval
+func (b *Bus) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-b.wake:
+		}
+		b.DispatchDue(ctx)
+	}
+}
+
+// DispatchDue toma lotes de eventos vencidos hasta vaciar la cola
+func (b *Bus) DispatchDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		pending, err := b.outboxDAO.ClaimDue(time.Now(), outboxLease, b.config.BatchSize)
+		if err != nil {
+			log.Printf("Failed to claim outbox events: %v", err)
+			return
+		}
+
+		for i := range pending {
+			if ctx.Err() != nil {
+				return // El lease vence y se retoman en el próximo arranque
+			}
+			b.dispatch(ctx, &pending[i])
+			if err := b.outboxDAO.SaveAttempt(&pending[i]); err != nil {
+				log.Printf("Failed to save outbox event %d: %v", pending[i].ID, err)
+			}
+		}
+
+		if len(pending) < b.config.BatchSize {
+			return
+		}
+	}
+}
+
+// dispatch ejecuta la reacción y deja en event el resultado
+func (b *Bus) dispatch(ctx context.Context, event *models.OutboxEvent) {
+	event.Attempts++
+
+	handler, ok := b.handlers[handlerKey{event: event.EventName, subscriber: event.Subscriber}]
+	if !ok {
+		event.Status = models.OutboxStatusDead
+		event.LastError = "no handler registered"
+		return
+	}
+
+	err := runHandler(context.WithValue(ctx, eventIDKey{}, event.EventID), handler, []byte(event.Payload))
+	if err == nil {
+		now := time.Now()
+		event.Status = models.OutboxStatusProcessed
+		event.LastError = ""
+		event.ProcessedAt = &now
+		return
+	}
+
+	log.Printf("Outbox %s -> %s failed (attempt %d): %v", event.EventName, event.Subscriber, event.Attempts, err)
+	event.LastError = err.Error()
+	if event.Attempts >= b.config.MaxAttempts {
+		event.Status = models.OutboxStatusDead
+		return
+	}
+	event.Status = models.OutboxStatusPending
+	event.NextAttemptAt = time.Now().Add(backoff(event.Attempts))
+}
+
+// runHandler convierte un panic del suscriptor en un intento fallido
+func runHandler(ctx context.Context, handler handlerFunc, payload []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, payload)
+}
+
+// Purge borra los eventos procesados más viejos que Retention
+func (b *Bus) Purge(ctx context.Context) {
+	removed, err := b.outboxDAO.PurgeProcessed(time.Now().Add(-b.config.Retention))
+	if err != nil {
+		log.Printf("Failed to purge outbox: %v", err)
+		return
+	}
+	if removed > 0 {
+		log.Printf("Purged %d processed outbox events", removed)
+	}
+}
+
+// backoff exponencial desde un segundo con tope de diez minutos
+func backoff(attempts int) time.Duration {
+	delay := time.Second << min(attempts-1, 10)
+	return min(delay, 10*time.Minute)
+}
+
+func newEventID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,270 @@
+package events
+
+import "time"
+
+// Event es un hecho de dominio ya confirmado
+type Event interface {
+	EventName() string
+}
+
+// Nombres de los eventos de dominio
+const (
+	NameCaptureCreated     = "capture.created"
+	NameMemberRescued      = "member.rescued"
+	NameCaptureReleased    = "capture.released"
+	NameReportSubmitted    = "report.submitted"
+	NameReportApproved     = "report.approved"
+	NameReportRejected     = "report.rejected"
+	NamePunishmentAssigned = "punishment.assigned"
+	NamePointsAwarded      = "points.awarded"
+	NameStatisticsUpdated  = "statistics.updated"
+	NameUsersImported      = "users.imported"
+	NameInviteIssued       = "invite.issued"
+	NameInviteAccepted     = "invite.accepted"
+	NameInviteRevoked      = "invite.revoked"
+	NameTrashRestored      = "trash.restored"
+	NameTrashPurged        = "trash.purged"
+	NameUserUpdated        = "user.updated"
+	NameUserDeleted        = "user.deleted"
+	NameUserAnonymized     = "user.anonymized"
+	NameProfileUpdated     = "profile.updated"
+	NameEmailChanged       = "profile.email_changed"
+	NameSessionsRevoked    = "sessions.revoked"
+)
+
+// Un daemon capturó a un network admin
+type CaptureCreated struct {
+	CaptureID  uint      `json:"capture_id"`
+	DaemonID   uint      `json:"daemon_id"`
+	DaemonName string    `json:"daemon_name"`
+	TargetID   uint      `json:"target_id"`
+	TargetName string    `json:"target_name"`
+	Difficulty string    `json:"difficulty"`
+	Points     int       `json:"points"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+func (CaptureCreated) EventName() string { return NameCaptureCreated }
+
+// Un equipo rescató a un miembro capturado
+type MemberRescued struct {
+	CaptureID     uint   `json:"capture_id"`
+	TeamID        uint   `json:"team_id"`
+	TeamName      string `json:"team_name"`
+	RescuedID     uint   `json:"rescued_id"`
+	InitiatedByID uint   `json:"initiated_by_id"`
+	PointsSpent   int    `json:"points_spent"`
+}
+
+func (MemberRescued) EventName() string { return NameMemberRescued }
+
+// Andrei liberó una captura al cambiar el rol o el status de alguno de sus usuarios
+type CaptureReleased struct {
+	CaptureID    uint `json:"capture_id"`
+	DaemonID     uint `json:"daemon_id"`
+	TargetID     uint `json:"target_id"`
+	ReleasedByID uint `json:"released_by_id"`
+}
+
+func (CaptureReleased) EventName() string { return NameCaptureReleased }
+
+// Datos comunes de los eventos de reportes; los anónimos nunca llevan autor
+type ReportInfo struct {
+	ReportID   uint   `json:"report_id"`
+	Title      string `json:"title"`
+	Type       string `json:"type"`
+	Severity   string `json:"severity"`
+	Status     string `json:"status"`
+	AuthorID   *uint  `json:"author_id,omitempty"`
+	AuthorName string `json:"author_name,omitempty"`
+}
+
+// Se envió un reporte
+type ReportSubmitted struct {
+	ReportInfo
+}
+
+func (ReportSubmitted) EventName() string { return NameReportSubmitted }
+
+// Se aprobó un reporte
+type ReportApproved struct {
+	ReportInfo
+	ReviewerID uint `json:"reviewer_id"`
+}
+
+func (ReportApproved) EventName() string { return NameReportApproved }
+
+// Se rechazó un reporte
+type ReportRejected struct {
+	ReportInfo
+	ReviewerID uint   `json:"reviewer_id"`
+	Reason     string `json:"reason"`
+}
+
+func (ReportRejected) EventName() string { return NameReportRejected }
+
+// Andrei asignó un castigo o recompensa
+type PunishmentAssigned struct {
+	PunishmentID uint       `json:"punishment_id"`
+	TargetID     uint       `json:"target_id"`
+	TargetName   string     `json:"target_name"`
+	AssignerID   uint       `json:"assigner_id"`
+	AssignerName string     `json:"assigner_name"`
+	Type         string     `json:"type"`
+	Description  string     `json:"description"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+func (PunishmentAssigned) EventName() string { return NamePunishmentAssigned }
+
+// Un daemon sumó puntos
+type PointsAwarded struct {
+	UserID uint   `json:"user_id"`
+	Points int    `json:"points"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+	Reason string `json:"reason"` // Nombre del evento que los otorgó
+}
+
+func (PointsAwarded) EventName() string { return NamePointsAwarded }
+
+// Andrei editó estadísticas a mano
+type StatisticsUpdated struct {
+	UserID        uint `json:"user_id"`
+	CapturesCount int  `json:"captures_count"`
+	ReportsCount  int  `json:"reports_count"`
+	Points        int  `json:"points"`
+}
+
+func (StatisticsUpdated) EventName() string { return NameStatisticsUpdated }
+
+// Andrei importó usuarios en lote
+type UsersImported struct {
+	ImportedByID uint   `json:"imported_by_id"`
+	UserIDs      []uint `json:"user_ids"`
+	Format       string `json:"format"`
+}
+
+func (UsersImported) EventName() string { return NameUsersImported }
+
+// Andrei envió o reenvió una invitación
+type InviteIssued struct {
+	InviteID    uint      `json:"invite_id"`
+	InvitedByID uint      `json:"invited_by_id"`
+	Role        string    `json:"role"`
+	Channel     string    `json:"channel"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Resent      bool      `json:"resent"`
+}
+
+func (InviteIssued) EventName() string { return NameInviteIssued }
+
+// Alguien aceptó una invitación y se creó su usuario
+type InviteAccepted struct {
+	InviteID    uint   `json:"invite_id"`
+	InvitedByID uint   `json:"invited_by_id"`
+	UserID      uint   `json:"user_id"`
+	Username    string `json:"username"`
+	Role        string `json:"role"`
+}
+
+func (InviteAccepted) EventName() string { return NameInviteAccepted }
+
+// Andrei revocó una invitación pendiente
+type InviteRevoked struct {
+	InviteID    uint `json:"invite_id"`
+	RevokedByID uint `json:"revoked_by_id"`
+}
+
+func (InviteRevoked) EventName() string { return NameInviteRevoked }
+
+// Andrei restauró una fila eliminada
+type TrashRestored struct {
+	Entity       string `json:"entity"`
+	EntityID     uint   `json:"entity_id"`
+	Label        string `json:"label"`
+	RestoredByID uint   `json:"restored_by_id"`
+}
+
+func (TrashRestored) EventName() string { return NameTrashRestored }
+
+// Se borró definitivamente una fila de la papelera; sin PurgedByID fue la
+// política de retención
+type TrashPurged struct {
+	Entity     string `json:"entity"`
+	EntityID   uint   `json:"entity_id"`
+	Label      string `json:"label"`
+	PurgedByID *uint  `json:"purged_by_id,omitempty"`
+}
+
+func (TrashPurged) EventName() string { return NameTrashPurged }
+
+// Valor anterior y nuevo de un campo
+type ValueChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Andrei cambió username, email, rol o status de un usuario. Changes lleva
+// los campos modificados salvo el email, que solo se marca para no guardar
+// las direcciones; Effects cuenta las filas afectadas por relación
+type UserUpdated struct {
+	UserID       uint                   `json:"user_id"`
+	UpdatedByID  uint                   `json:"updated_by_id"`
+	Changes      map[string]ValueChange `json:"changes,omitempty"`
+	EmailChanged bool                   `json:"email_changed,omitempty"`
+	Effects      map[string]int64       `json:"effects,omitempty"`
+}
+
+func (UserUpdated) EventName() string { return NameUserUpdated }
+
+// Andrei eliminó un usuario; Effects cuenta las filas afectadas por relación
+type UserDeleted struct {
+	UserID         uint             `json:"user_id"`
+	Username       string           `json:"username"`
+	Role           string           `json:"role"`
+	DeletedByID    uint             `json:"deleted_by_id"`
+	ReassignedToID *uint            `json:"reassigned_to_id,omitempty"`
+	Effects        map[string]int64 `json:"effects,omitempty"`
+}
+
+func (UserDeleted) EventName() string { return NameUserDeleted }
+
+// Andrei reemplazó los datos personales de un usuario por un seudónimo. No
+// lleva el username anterior para no volver a guardarlo
+type UserAnonymized struct {
+	UserID         uint   `json:"user_id"`
+	Pseudonym      string `json:"pseudonym"`
+	Role           string `json:"role"`
+	AnonymizedByID uint   `json:"anonymized_by_id"`
+}
+
+func (UserAnonymized) EventName() string { return NameUserAnonymized }
+
+// Un usuario cambió su propio perfil; Fields lista los campos tocados
+type ProfileUpdated struct {
+	UserID uint     `json:"user_id"`
+	Fields []string `json:"fields"`
+}
+
+func (ProfileUpdated) EventName() string { return NameProfileUpdated }
+
+// Un usuario confirmó su email nuevo. No lleva las direcciones para no
+// guardarlas en la auditoría
+type EmailChanged struct {
+	UserID        uint `json:"user_id"`
+	EmailChangeID uint `json:"email_change_id"`
+}
+
+func (EmailChanged) EventName() string { return NameEmailChanged }
+
+// Un usuario cerró una o varias de sus sesiones
+type SessionsRevoked struct {
+	UserID uint   `json:"user_id"`
+	Count  int64  `json:"count"`
+	Reason string `json:"reason"` // "revoked", "others_revoked" o "password_changed"
+}
+
+func (SessionsRevoked) EventName() string { return NameSessionsRevoked }
@@ -0,0 +1,26 @@
+package invites
+
+import (
+	"context"
+	"time"
+)
+
+// Invitation es lo que un canal necesita para entregar una invitación
+type Invitation struct {
+	Email     string // Puede estar vacío si el canal no lo usa
+	Role      string
+	Link      string // Contiene el token en claro; no se guarda en ningún lado
+	InvitedBy string
+	ExpiresAt time.Time
+}
+
+// Channel entrega invitaciones por un medio concreto
+type Channel interface {
+	// Name es el identificador que Andrei elige al invitar ("email", "link")
+	Name() string
+	// RequiresEmail indica si la invitación debe llevar un email de destino
+	RequiresEmail() bool
+	// Deliver entrega la invitación. Devuelve true si el enlace debe
+	// mostrarse a Andrei para que lo haga llegar él mismo
+	Deliver(ctx context.Context, invitation Invitation) (showLink bool, err error)
+}
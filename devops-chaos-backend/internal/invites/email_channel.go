@@ -0,0 +1,43 @@
+package invites
+
+import (
+	"context"
+	"devops-chaos-backend/internal/mail"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EmailChannel envía la invitación por correo
+type EmailChannel struct {
+	mailer mail.Mailer
+}
+
+func NewEmailChannel(mailer mail.Mailer) *EmailChannel {
+	return &EmailChannel{mailer: mailer}
+}
+
+func (c *EmailChannel) Name() string {
+	return "email"
+}
+
+func (c *EmailChannel) RequiresEmail() bool {
+	return true
+}
+
+func (c *EmailChannel) Deliver(ctx context.Context, invitation Invitation) (bool, error) {
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s invited you to join as %s.\r\n\r\n", invitation.InvitedBy, invitation.Role)
+	fmt.Fprintf(&body, "Choose your username and password here:\r\n%s\r\n\r\n", invitation.Link)
+	fmt.Fprintf(&body, "The link can be used once and expires on %s.\r\n", invitation.ExpiresAt.UTC().Format(time.RFC1123))
+
+	err := c.mailer.Send(ctx, mail.Message{
+		To:      invitation.Email,
+		Subject: "You have been invited to the DevOps Chaos network",
+		Body:    body.String(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("send invite email: %w", err)
+	}
+	return false, nil
+}
@@ -0,0 +1,22 @@
+package invites
+
+import "context"
+
+// LinkChannel no envía nada: Andrei recibe el enlace y lo imprime o lo comparte
+type LinkChannel struct{}
+
+func NewLinkChannel() *LinkChannel {
+	return &LinkChannel{}
+}
+
+func (c *LinkChannel) Name() string {
+	return "link"
+}
+
+func (c *LinkChannel) RequiresEmail() bool {
+	return false
+}
+
+func (c *LinkChannel) Deliver(ctx context.Context, invitation Invitation) (bool, error) {
+	return true, nil
+}
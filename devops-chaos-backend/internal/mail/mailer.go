@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// Message es un correo de texto plano
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer envía correos
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// SMTPMailer envía correos por SMTP
+type SMTPMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{host: host, port: port, username: username, password: password, from: from}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message Message) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+	if err := smtp.SendMail(addr, auth, m.from, []string{message.To}, m.encode(message)); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+func (m *SMTPMailer) encode(message Message) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", message.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(message.Body)
+	return msg.Bytes()
+}
@@ -0,0 +1,51 @@
+package middleware_test
+
+import (
+	"devops-chaos-backend/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIsAnonymousRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		method string
+		route  string
+		path   string
+		mark   bool
+		want   bool
+	}{
+		{"anonymous route", "POST", "/api/resistance/report", "/api/resistance/report", false, true},
+		{"upload with receipt code", "POST", "/api/reports/:id/attachments", "/api/reports/7/attachments", true, true},
+		{"upload without receipt code", "POST", "/api/reports/:id/attachments", "/api/reports/7/attachments", false, false},
+		{"regular route", "GET", "/api/reports/:id", "/api/reports/7", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			r := gin.New()
+			// Igual que el logger: se consulta después del handler
+			r.Use(func(c *gin.Context) {
+				c.Next()
+				got = middleware.IsAnonymousRequest(c)
+			})
+			r.Handle(tt.method, tt.route, func(c *gin.Context) {
+				if tt.mark {
+					c.Set("anonymousRequest", true)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+			if got != tt.want {
+				t.Fatalf("IsAnonymousRequest = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
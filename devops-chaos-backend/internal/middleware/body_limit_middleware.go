@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimitMiddleware limita el tamaño del cuerpo de las peticiones.
+// routeLimits permite un límite distinto por ruta (p.ej. subida de archivos)
+func BodyLimitMiddleware(maxBytes int64, routeLimits map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := maxBytes
+		if routeLimit, ok := routeLimits[c.FullPath()]; ok {
+			limit = routeLimit
+		}
+
+		// Rechazar de entrada si el cliente declara un cuerpo demasiado grande
+		if c.Request.ContentLength > limit {
+			abortWithError(c, apperrors.TooLarge("request_too_large", "Request body exceeds the configured limit"))
+			return
+		}
+
+		// Cortar la lectura si el cuerpo real excede el límite
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
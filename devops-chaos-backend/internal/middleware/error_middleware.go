@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dto"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const problemContentType = "application/problem+json"
+
+// ErrorMiddleware traduce los errores registrados con c.Error a respuestas problem+json
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		appErr := apperrors.From(c.Errors.Last().Err)
+		if appErr.Kind == apperrors.KindInternal {
+			log.Printf("Internal error on %s %s: %v", c.Request.Method, c.Request.URL.Path, appErr)
+		}
+
+		WriteProblem(c, appErr)
+	}
+}
+
+// WriteProblem escribe un error de dominio como RFC 7807
+func WriteProblem(c *gin.Context, appErr *apperrors.Error) {
+	status := appErr.Status()
+
+	c.Header("Content-Type", problemContentType)
+	if appErr.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(appErr.RetryAfter.Seconds())))
+	}
+	c.JSON(status, dto.ProblemDetails{
+		Type:     "/problems/" + appErr.Code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   appErr.Message,
+		Instance: c.Request.URL.Path,
+		Code:     appErr.Code,
+		Success:  false,
+		Message:  appErr.Message,
+	})
+}
+
+// abortWithError registra el error y detiene la cadena de handlers
+func abortWithError(c *gin.Context, err error) {
+	c.Error(err)
+	c.Abort()
+}
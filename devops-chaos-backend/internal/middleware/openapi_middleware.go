@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/openapi"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIValidationMiddleware rechaza las peticiones cuyos parámetros o cuerpo
+// JSON no cumplen la especificación, antes de llegar al handler. Las rutas
+// que no están en la especificación pasan sin validar (la comprobación de
+// arranque impide que existan)
+func OpenAPIValidationMiddleware(doc *openapi.Document) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, ok := doc.Lookup(c.Request.Method, c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+
+		body, err := doc.ValidateRequest(op, &openapi.Request{
+			PathParams:  pathParams,
+			Query:       c.Request.URL.Query(),
+			ContentType: c.GetHeader("Content-Type"),
+			Body:        c.Request.Body,
+		})
+		c.Request.Body = body // El handler vuelve a leer el cuerpo
+
+		var validationErr *openapi.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			code := "invalid_parameter"
+			if validationErr.In == "body" {
+				code = "invalid_request"
+			}
+			abortWithError(c, apperrors.Validation(code, validationErr.Error()))
+		case err != nil:
+			abortWithError(c, err) // p.ej. el cuerpo supera el límite
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"devops-chaos-backend/internal/controllers"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/middleware"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOpenAPIValidationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(middleware.ErrorMiddleware())
+	r.Use(middleware.OpenAPIValidationMiddleware(controllers.NewAPISpec("test")))
+
+	// El handler comprueba que el cuerpo le llega intacto después de validarlo
+	r.POST("/api/auth/login", func(c *gin.Context) {
+		var req dto.LoginRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.String(http.StatusTeapot, err.Error())
+			return
+		}
+		c.String(http.StatusOK, req.Username)
+	})
+	r.GET("/api/users/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, c.Param("id"))
+	})
+	r.GET("/undocumented", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantBody string // Cuerpo si pasa, código de error si no
+	}{
+		{"valid body", "POST", "/api/auth/login", `{"username":"andrei","password":"chaos"}`, http.StatusOK, "andrei"},
+		{"missing required field", "POST", "/api/auth/login", `{"username":"andrei"}`, http.StatusBadRequest, "invalid_request"},
+		{"empty required string", "POST", "/api/auth/login", `{"username":"","password":"chaos"}`, http.StatusBadRequest, "invalid_request"},
+		{"wrong type", "POST", "/api/auth/login", `{"username":1,"password":"chaos"}`, http.StatusBadRequest, "invalid_request"},
+		{"malformed JSON", "POST", "/api/auth/login", `{"username":`, http.StatusBadRequest, "invalid_request"},
+		{"missing body", "POST", "/api/auth/login", "", http.StatusBadRequest, "invalid_request"},
+		{"valid path parameter", "GET", "/api/users/7", "", http.StatusOK, "7"},
+		{"invalid path parameter", "GET", "/api/users/abc", "", http.StatusBadRequest, "invalid_parameter"},
+		{"route outside the spec", "GET", "/undocumented", "", http.StatusOK, "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantCode, w.Body.String())
+			}
+			if w.Code == http.StatusOK {
+				if w.Body.String() != tt.wantBody {
+					t.Fatalf("body = %q, want %q", w.Body.String(), tt.wantBody)
+				}
+				return
+			}
+
+			var problem dto.ProblemDetails
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("response is not problem+json: %v", err)
+			}
+			if problem.Code != tt.wantBody {
+				t.Fatalf("code = %q, want %q (%s)", problem.Code, tt.wantBody, problem.Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Recibo de un reporte anónimo: solo se guarda el hash del código entregado al remitente
+type AnonymousReceipt struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ReportID  uint      `json:"report_id" gorm:"uniqueIndex;not null"`
+	CodeHash  string    `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
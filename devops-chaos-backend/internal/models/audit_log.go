@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Entrada del registro de auditoría, una por evento de dominio. EventID es
+// único porque el outbox puede entregar el mismo evento más de una vez
+type AuditLog struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	EventID   string    `json:"event_id" gorm:"uniqueIndex;not null"`
+	EventName string    `json:"event_name" gorm:"index;not null"`
+	ActorID   *uint     `json:"actor_id,omitempty" gorm:"index"`
+	SubjectID *uint     `json:"subject_id,omitempty" gorm:"index"`
+	Summary   string    `json:"summary" gorm:"not null"`
+	Payload   string    `json:"payload" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Cambio de email pendiente de verificar; solo se guarda el hash del token
+// enviado a la dirección nueva
+type EmailChange struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	UserID      uint       `json:"user_id" gorm:"index;not null"`
+	NewEmail    string     `json:"new_email" gorm:"not null"`
+	TokenHash   string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
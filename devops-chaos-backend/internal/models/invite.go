@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Invitación para unirse con un rol; solo se guarda el hash del token enviado
+type Invite struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	TokenHash      string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	Role           string     `json:"role" gorm:"not null"`
+	Email          string     `json:"email"` // Opcional: si está vacío lo elige quien acepta
+	Channel        string     `json:"channel" gorm:"not null"`
+	Status         string     `json:"status" gorm:"default:'pending';index"`
+	InvitedByID    uint       `json:"invited_by_id" gorm:"not null"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"index"`
+	SentCount      int        `json:"sent_count" gorm:"default:0"`
+	LastSentAt     *time.Time `json:"last_sent_at,omitempty"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	AcceptedUserID *uint      `json:"accepted_user_id,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Constantes para estados de invitación. "expired" no se guarda: es una
+// invitación pendiente cuyo ExpiresAt ya pasó
+const (
+	InviteStatusPending  = "pending"
+	InviteStatusAccepted = "accepted"
+	InviteStatusRevoked  = "revoked"
+	InviteStatusExpired  = "expired"
+)
+
+// EffectiveStatus devuelve el estado teniendo en cuenta la expiración
+func (i *Invite) EffectiveStatus(now time.Time) string {
+	if i.Status == InviteStatusPending && !now.Before(i.ExpiresAt) {
+		return InviteStatusExpired
+	}
+	return i.Status
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Notificación en la bandeja de un usuario
+type Notification struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"index;not null"`
+	Type      string     `json:"type" gorm:"not null"`
+	Title     string     `json:"title" gorm:"not null"`
+	Body      string     `json:"body" gorm:"type:text"`
+	Read      bool       `json:"read" gorm:"default:false;index"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"index"`
+}
+
+// Preferencia de un usuario para un tipo de notificación; sin registro el tipo está activo
+type NotificationPreference struct {
+	ID      uint   `json:"id" gorm:"primaryKey"`
+	UserID  uint   `json:"user_id" gorm:"uniqueIndex:idx_notification_preference;not null"`
+	Type    string `json:"type" gorm:"uniqueIndex:idx_notification_preference;not null"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Constantes para tipos de notificación
+const (
+	NotificationPunishmentAssigned   = "punishment_assigned"
+	NotificationReportApproved       = "report_approved"
+	NotificationReportRejected       = "report_rejected"
+	NotificationLeaderboardOvertaken = "leaderboard_overtaken"
+	NotificationCaptured             = "captured"
+	NotificationReleased             = "released"
+)
+
+// Tipos de notificación que puede recibir cada rol
+var NotificationTypesByRole = map[string][]string{
+	RoleDaemon: {
+		NotificationPunishmentAssigned,
+		NotificationReportApproved,
+		NotificationReportRejected,
+		NotificationLeaderboardOvertaken,
+	},
+	RoleNetworkAdmin: {
+		NotificationCaptured,
+		NotificationReleased,
+	},
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Evento de dominio pendiente para un suscriptor. Se escribe en la misma
+// transacción que el cambio que lo origina: una fila por suscriptor, así cada
+// reacción se reintenta por separado
+type OutboxEvent struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	EventID       string     `json:"event_id" gorm:"index;not null"`
+	EventName     string     `json:"event_name" gorm:"not null"`
+	Subscriber    string     `json:"subscriber" gorm:"not null"`
+	Payload       string     `json:"payload" gorm:"type:text;not null"`
+	Status        string     `json:"status" gorm:"default:'pending';index:idx_outbox_due,priority:1"`
+	Attempts      int        `json:"attempts" gorm:"default:0"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"index:idx_outbox_due,priority:2"`
+	LastError     string     `json:"last_error,omitempty"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Evento ya aplicado por un suscriptor cuya reacción no es idempotente por sí
+// misma (contadores, puntos). Se inserta en la transacción de la reacción para
+// que una entrega repetida no la aplique dos veces
+type ProcessedEvent struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Subscriber string    `json:"subscriber" gorm:"uniqueIndex:idx_processed_event;not null"`
+	EventID    string    `json:"event_id" gorm:"uniqueIndex:idx_processed_event;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
+
+// Constantes para estados del outbox
+const (
+	OutboxStatusPending    = "pending"
+	OutboxStatusProcessing = "processing"
+	OutboxStatusProcessed  = "processed"
+	OutboxStatusDead       = "dead"
+)
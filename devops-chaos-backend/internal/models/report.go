@@ -1,22 +1,31 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type Report struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	Title       string         `json:"title" gorm:"not null"`
-	Description string         `json:"description" gorm:"type:text"`
-	Type        string         `json:"type"`      // "resistance", "capture", "anonymous"
-	AuthorID    *uint          `json:"author_id"` // nil for anonymous reports
-	Author      *User          `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
-	Status      string         `json:"status" gorm:"default:'pending'"` // "pending", "approved", "rejected"
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Title           string         `json:"title" gorm:"not null"`
+	Description     string         `json:"description" gorm:"type:text"`
+	Type            string         `json:"type"`      // "resistance", "capture", "anonymous"
+	AuthorID        *uint          `json:"author_id"` // nil for anonymous reports
+	Author          *User          `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	Status          string         `json:"status" gorm:"default:'pending'"` // "pending", "in_review", "approved", "rejected"
+	AssigneeID      *uint          `json:"assignee_id" gorm:"index"`        // Daemon delegado para investigar
+	Assignee        *User          `json:"assignee,omitempty" gorm:"foreignKey:AssigneeID"`
+	RejectionReason string         `json:"rejection_reason,omitempty" gorm:"type:text"`
+	Severity        string         `json:"severity" gorm:"index;default:'medium'"` // "low", "medium", "high", "critical"
+	SeverityLevel   int            `json:"-" gorm:"index;default:2"`               // Orden numérico de Severity
+	Location        string         `json:"location,omitempty"`
+	ContentHash     string         `json:"-" gorm:"size:64;index"` // Huella para detectar duplicados anónimos
+	SimHash         int64          `json:"-"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 // Constantes para tipos de reporte
@@ -26,9 +35,45 @@ const (
 	ReportTypeAnonymous  = "anonymous"
 )
 
+// Constantes para severidad de reporte
+const (
+	ReportSeverityLow      = "low"
+	ReportSeverityMedium   = "medium"
+	ReportSeverityHigh     = "high"
+	ReportSeverityCritical = "critical"
+)
+
+// severityLevels permite ordenar por severidad
+var severityLevels = map[string]int{
+	ReportSeverityLow:      1,
+	ReportSeverityMedium:   2,
+	ReportSeverityHigh:     3,
+	ReportSeverityCritical: 4,
+}
+
+// IsValidSeverity indica si la severidad es una de las conocidas
+func IsValidSeverity(severity string) bool {
+	_, ok := severityLevels[severity]
+	return ok
+}
+
+// BeforeSave mantiene SeverityLevel sincronizado con Severity
+func (r *Report) BeforeSave(tx *gorm.DB) error {
+	if r.Severity == "" {
+		r.Severity = ReportSeverityMedium
+	}
+	level, ok := severityLevels[r.Severity]
+	if !ok {
+		return fmt.Errorf("invalid report severity %q", r.Severity)
+	}
+	r.SeverityLevel = level
+	return nil
+}
+
 // Constantes para status de reporte
 const (
 	ReportStatusPending  = "pending"
+	ReportStatusInReview = "in_review"
 	ReportStatusApproved = "approved"
 	ReportStatusRejected = "rejected"
 )
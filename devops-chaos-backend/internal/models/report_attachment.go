@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Archivo de evidencia adjunto a un reporte
+type ReportAttachment struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	ReportID    uint           `json:"report_id" gorm:"index;not null"`
+	UploaderID  *uint          `json:"uploader_id"` // nil en reportes anónimos
+	Uploader    *User          `json:"uploader,omitempty" gorm:"foreignKey:UploaderID"`
+	FileName    string         `json:"file_name" gorm:"not null"`
+	ContentType string         `json:"content_type" gorm:"not null"`
+	Size        int64          `json:"size"`
+	SHA256      string         `json:"sha256" gorm:"size:64"`
+	StorageKey  string         `json:"-" gorm:"uniqueIndex;not null"`
+	CreatedAt   time.Time      `json:"created_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Comentario de revisión sobre un reporte
+type ReportComment struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	ReportID  uint           `json:"report_id" gorm:"index;not null"`
+	AuthorID  *uint          `json:"author_id"` // nil para notas del remitente anónimo
+	Author    *User          `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	Body      string         `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// Entrada del historial de estados de un reporte
+type ReportStatusChange struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ReportID    uint      `json:"report_id" gorm:"index;not null"`
+	FromStatus  string    `json:"from_status"` // vacío en la creación
+	ToStatus    string    `json:"to_status"`
+	ChangedByID *uint     `json:"changed_by_id"` // nil para reportes anónimos
+	ChangedBy   *User     `json:"changed_by,omitempty" gorm:"foreignKey:ChangedByID"`
+	AssigneeID  *uint     `json:"assignee_id"` // Asignado tras el cambio, si aplica
+	Reason      string    `json:"reason" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at"`
+}
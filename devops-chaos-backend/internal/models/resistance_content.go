@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Consejo de supervivencia enviado por la resistencia
+type SurvivalTip struct {
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	Title            string         `json:"title" gorm:"not null"`
+	Description      string         `json:"description" gorm:"type:text"`
+	Priority         string         `json:"priority" gorm:"default:'medium'"` // "low", "medium", "high", "critical"
+	Category         string         `json:"category"`                         // "technical", "social", "security"
+	SubmittedByID    *uint          `json:"submitted_by_id"`                  // nil para contenido sembrado
+	Status           string         `json:"status" gorm:"index;default:'pending'"`
+	Upvotes          int            `json:"upvotes" gorm:"default:0"`
+	ModerationReason string         `json:"moderation_reason,omitempty" gorm:"type:text"`
+	ModeratedAt      *time.Time     `json:"moderated_at"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// Meme de la resistencia; la imagen vive en el BlobStore o en una URL estática
+type ResistanceMeme struct {
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	Title            string         `json:"title" gorm:"not null"`
+	Description      string         `json:"description" gorm:"type:text"`
+	ImageURL         string         `json:"image_url"` // Solo para memes sembrados
+	ImageKey         string         `json:"-"`         // Llave en el BlobStore
+	ImageType        string         `json:"image_type"`
+	ImageSize        int64          `json:"image_size"`
+	SubmittedByID    *uint          `json:"submitted_by_id"`
+	Status           string         `json:"status" gorm:"index;default:'pending'"`
+	Upvotes          int            `json:"upvotes" gorm:"default:0"`
+	ModerationReason string         `json:"moderation_reason,omitempty" gorm:"type:text"`
+	ModeratedAt      *time.Time     `json:"moderated_at"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// Voto único de un usuario sobre un consejo o meme
+type ContentVote struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ContentType string    `json:"content_type" gorm:"uniqueIndex:idx_content_vote;not null"`
+	ContentID   uint      `json:"content_id" gorm:"uniqueIndex:idx_content_vote;not null"`
+	UserID      uint      `json:"user_id" gorm:"uniqueIndex:idx_content_vote;not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Contacto de emergencia de la resistencia
+type EmergencyContact struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"not null"`
+	Role        string    `json:"role"`
+	Contact     string    `json:"contact"`
+	Description string    `json:"description"`
+	SortOrder   int       `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Constantes para tipos de contenido votable
+const (
+	ContentTypeTip  = "tip"
+	ContentTypeMeme = "meme"
+)
+
+// Constantes para status de moderación
+const (
+	ContentStatusPending  = "pending"
+	ContentStatusApproved = "approved"
+	ContentStatusRejected = "rejected"
+)
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Sesión abierta al iniciar sesión; el JWT lleva TokenID y deja de valer
+// cuando la sesión se revoca o vence
+type Session struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"user_id" gorm:"index;not null"`
+	TokenID    string     `json:"-" gorm:"size:32;uniqueIndex;not null"`
+	UserAgent  string     `json:"user_agent" gorm:"size:255"`
+	IPAddress  string     `json:"ip_address" gorm:"size:64"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"index"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IsActive indica si el token de la sesión todavía se acepta
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Escuadrón de daemons organizado por Andrei
+type Squad struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"uniqueIndex;not null"`
+	Description string         `json:"description" gorm:"type:text"`
+	LeaderID    *uint          `json:"leader_id"`
+	Leader      *User          `json:"leader,omitempty" gorm:"foreignKey:LeaderID"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relaciones
+	Members []SquadMember `json:"members,omitempty" gorm:"foreignKey:SquadID"`
+}
+
+// Pertenencia de un daemon a un escuadrón (un escuadrón por daemon)
+type SquadMember struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	SquadID   uint      `json:"squad_id" gorm:"index;not null"`
+	UserID    uint      `json:"user_id" gorm:"uniqueIndex;not null"`
+	User      User      `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Puntos que una captura o un reporte aprobado aportaron a un escuadrón. Se
+// registran al momento del hecho, así cambiar de escuadrón no reescribe el historial
+type SquadScore struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	SquadID   uint      `json:"squad_id" gorm:"index;not null"`
+	UserID    uint      `json:"user_id" gorm:"index;not null"`
+	Source    string    `json:"source" gorm:"uniqueIndex:idx_squad_score_source;not null"` // "capture", "report"
+	SourceID  uint      `json:"source_id" gorm:"uniqueIndex:idx_squad_score_source;not null"`
+	Points    int       `json:"points"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// Constantes para el origen de los puntos de escuadrón
+const (
+	SquadScoreSourceCapture = "capture"
+	SquadScoreSourceReport  = "report"
+)
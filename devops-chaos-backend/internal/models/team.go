@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Célula de la resistencia formada por network admins
+type Team struct {
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	Name             string         `json:"name" gorm:"uniqueIndex;not null"`
+	Description      string         `json:"description" gorm:"type:text"`
+	SafeHouse        string         `json:"safe_house"` // Punto de encuentro, solo visible para miembros
+	ProtectionPoints int            `json:"protection_points" gorm:"default:0"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relaciones
+	Members []TeamMember `json:"members,omitempty" gorm:"foreignKey:TeamID"`
+}
+
+// Pertenencia de un network admin a un equipo (un equipo por admin)
+type TeamMember struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TeamID    uint      `json:"team_id" gorm:"index;not null"`
+	UserID    uint      `json:"user_id" gorm:"uniqueIndex;not null"`
+	User      User      `json:"user" gorm:"foreignKey:UserID"`
+	Role      string    `json:"role" gorm:"default:'member'"` // "leader", "member"
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Mensaje del tablero privado del equipo
+type TeamMessage struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	TeamID    uint           `json:"team_id" gorm:"index;not null"`
+	AuthorID  uint           `json:"author_id" gorm:"not null"`
+	Author    User           `json:"author" gorm:"foreignKey:AuthorID"`
+	Body      string         `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// Aporte de puntos de protección de un miembro al fondo del equipo
+type TeamContribution struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TeamID    uint      `json:"team_id" gorm:"index;not null"`
+	UserID    uint      `json:"user_id" gorm:"index;not null"`
+	Points    int       `json:"points"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Rescate de un miembro capturado
+type TeamRescue struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	TeamID        uint      `json:"team_id" gorm:"index;not null"`
+	CaptureID     uint      `json:"capture_id" gorm:"not null"`
+	RescuedID     uint      `json:"rescued_id" gorm:"not null"`
+	InitiatedByID uint      `json:"initiated_by_id" gorm:"not null"`
+	PointsSpent   int       `json:"points_spent"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Constantes para roles dentro del equipo
+const (
+	TeamRoleLeader = "leader"
+	TeamRoleMember = "member"
+)
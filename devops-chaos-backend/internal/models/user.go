@@ -11,12 +11,22 @@ type User struct {
 	Username  string         `json:"username" gorm:"unique;not null"`
 	Email     string         `json:"email" gorm:"unique;not null"`
 	Password  string         `json:"-" gorm:"not null"`
-	Role      string         `json:"role" gorm:"not null"`           // "andrei", "daemon", "network_admin"
+	Role      string         `json:"role" gorm:"not null"`           // "andrei", "daemon", "network_admin", "moderator"
 	Status    string         `json:"status" gorm:"default:'active'"` // "active", "captured", "punished"
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// Fecha en que se reemplazaron sus datos personales; ya no puede iniciar sesión
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty"`
+
+	// Perfil que cada usuario edita por su cuenta
+	DisplayName string `json:"display_name" gorm:"size:64"`
+	Bio         string `json:"bio" gorm:"type:text"`
+	AvatarKey   string `json:"-"`
+	AvatarType  string `json:"-"`
+	AvatarSize  int64  `json:"-"`
+
 	// Relaciones
 	Reports     []Report     `json:"reports,omitempty" gorm:"foreignKey:AuthorID"`
 	Statistics  *Statistic   `json:"statistics,omitempty" gorm:"foreignKey:UserID"`
@@ -28,11 +38,44 @@ const (
 	RoleAndrei       = "andrei"
 	RoleDaemon       = "daemon"
 	RoleNetworkAdmin = "network_admin"
+	RoleModerator    = "moderator" // Modera el contenido de la resistencia
 )
 
+// Roles lista los roles válidos
+var Roles = []string{RoleAndrei, RoleDaemon, RoleNetworkAdmin, RoleModerator}
+
+// IsValidRole indica si role es uno de los roles conocidos
+func IsValidRole(role string) bool {
+	for _, valid := range Roles {
+		if role == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // Constantes para status
 const (
 	StatusActive   = "active"
 	StatusCaptured = "captured"
 	StatusPunished = "punished"
 )
+
+// StatusesByRole lista los status que admite cada rol: solo los network admins
+// pueden estar capturados y solo los daemons castigados
+var StatusesByRole = map[string][]string{
+	RoleAndrei:       {StatusActive},
+	RoleDaemon:       {StatusActive, StatusPunished},
+	RoleNetworkAdmin: {StatusActive, StatusCaptured},
+	RoleModerator:    {StatusActive},
+}
+
+// IsValidStatusForRole indica si status es válido para role
+func IsValidStatusForRole(role, status string) bool {
+	for _, valid := range StatusesByRole[role] {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Endpoint externo que recibe eventos firmados
+type Webhook struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	URL         string         `json:"url" gorm:"not null"`
+	Description string         `json:"description"`
+	Secret      string         `json:"-" gorm:"not null"` // Se usa para firmar, solo se muestra al crear o rotar
+	Events      []string       `json:"events" gorm:"serializer:json"`
+	Active      bool           `json:"active" gorm:"default:true"`
+	CreatedByID uint           `json:"created_by_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// Entrega de un evento a un webhook (outbox persistente)
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	WebhookID      uint       `json:"webhook_id" gorm:"index;not null"`
+	EventID        string     `json:"event_id" gorm:"index;not null"`
+	EventType      string     `json:"event_type" gorm:"not null"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"default:'pending';index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int        `json:"attempts" gorm:"default:0"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"index:idx_webhook_delivery_due,priority:2"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Constantes para estados de entrega
+const (
+	DeliveryStatusPending    = "pending"
+	DeliveryStatusDelivering = "delivering"
+	DeliveryStatusSucceeded  = "succeeded"
+	DeliveryStatusDead       = "dead" // Agotó los reintentos
+)
+
+// Eventos que se pueden suscribir
+const (
+	WebhookEventCaptureCreated     = "capture.created"
+	WebhookEventPunishmentAssigned = "punishment.assigned"
+	WebhookEventReportApproved     = "report.approved"
+	WebhookEventReportRejected     = "report.rejected"
+	WebhookEventPing               = "ping"
+)
+
+// WebhookEventTypes lista los eventos válidos para el filtro de un webhook
+var WebhookEventTypes = []string{
+	WebhookEventCaptureCreated,
+	WebhookEventPunishmentAssigned,
+	WebhookEventReportApproved,
+	WebhookEventReportRejected,
+}
+
+// Subscribes indica si el webhook recibe el tipo de evento
+func (w *Webhook) Subscribes(eventType string) bool {
+	if eventType == WebhookEventPing {
+		return true
+	}
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+// Package openapi describe la API con un documento OpenAPI 3, valida las
+// peticiones contra él y comprueba que coincida con las rutas registradas
+package openapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+// Document es la raíz de la especificación
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Tags       []Tag                 `json:"tags,omitempty"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+
+	schemas *schemaRegistry
+	routes  map[string]*Operation // "GET /api/users/:id" -> operación
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// SecurityRequirement asocia un esquema de seguridad con sus scopes
+type SecurityRequirement map[string][]string
+
+// PathItem agrupa las operaciones de una ruta por método en minúsculas
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+	Roles       []string              `json:"x-roles,omitempty"` // Roles que pueden llamarla; vacío = cualquiera autenticado
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // "path", "query" o "header"
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Response struct {
+	Description string               `json:"description,omitempty"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+	Ref         string               `json:"$ref,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	Responses       map[string]*Response      `json:"responses,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// NewDocument crea un documento vacío
+func NewDocument(info Info) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas:         make(map[string]*Schema),
+			Responses:       make(map[string]*Response),
+			SecuritySchemes: make(map[string]SecurityScheme),
+		},
+		routes: make(map[string]*Operation),
+	}
+	doc.schemas = newSchemaRegistry(doc.Components.Schemas)
+	return doc
+}
+
+var ginParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Add registra una operación para una ruta escrita como en gin ("/users/:id")
+func (d *Document) Add(method, path string, op *Operation) {
+	method = strings.ToUpper(method)
+	specPath := ginParam.ReplaceAllString(path, "{$1}")
+
+	item, ok := d.Paths[specPath]
+	if !ok {
+		item = make(PathItem)
+		d.Paths[specPath] = item
+	}
+	if op.Responses == nil {
+		op.Responses = make(map[string]*Response)
+	}
+	item[strings.ToLower(method)] = op
+	d.routes[method+" "+path] = op
+}
+
+// Lookup devuelve la operación de una ruta registrada en gin
+func (d *Document) Lookup(method, path string) (*Operation, bool) {
+	op, ok := d.routes[strings.ToUpper(method)+" "+path]
+	return op, ok
+}
+
+// Schema genera (y registra en components) el esquema del tipo de v
+func (d *Document) Schema(v interface{}) *Schema {
+	return d.schemas.schemaOf(v)
+}
+
+// FormSchema genera el esquema de un formulario multipart a partir de las
+// etiquetas form de v; files son los campos de archivo
+func (d *Document) FormSchema(v interface{}, files ...string) *Schema {
+	return d.schemas.formSchemaOf(v, files)
+}
+
+// Ref devuelve una referencia a un esquema ya registrado en components
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// ResponseRef devuelve una referencia a una respuesta de components
+func ResponseRef(name string) *Response {
+	return &Response{Ref: "#/components/responses/" + name}
+}
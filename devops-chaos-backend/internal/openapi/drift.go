@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Route es una ruta registrada en el router ("GET", "/api/users/:id")
+type Route struct {
+	Method string
+	Path   string
+}
+
+// DriftError lista las rutas sin documentar y las operaciones sin ruta
+type DriftError struct {
+	Undocumented []string
+	Unrouted     []string
+}
+
+func (e *DriftError) Error() string {
+	var parts []string
+	if len(e.Undocumented) > 0 {
+		parts = append(parts, "routes missing from the spec: "+strings.Join(e.Undocumented, ", "))
+	}
+	if len(e.Unrouted) > 0 {
+		parts = append(parts, "spec operations without a route: "+strings.Join(e.Unrouted, ", "))
+	}
+	return "OpenAPI spec and router drifted apart; " + strings.Join(parts, "; ")
+}
+
+// CheckRoutes compara las rutas del router con las operaciones del documento
+func (d *Document) CheckRoutes(routes []Route) error {
+	drift := &DriftError{}
+	routed := make(map[string]bool, len(routes))
+
+	for _, route := range routes {
+		key := strings.ToUpper(route.Method) + " " + route.Path
+		routed[key] = true
+		if _, ok := d.routes[key]; !ok {
+			drift.Undocumented = append(drift.Undocumented, key)
+		}
+	}
+	for key := range d.routes {
+		if !routed[key] {
+			drift.Unrouted = append(drift.Unrouted, key)
+		}
+	}
+
+	if len(drift.Undocumented) == 0 && len(drift.Unrouted) == 0 {
+		return nil
+	}
+	sort.Strings(drift.Undocumented)
+	sort.Strings(drift.Unrouted)
+	return drift
+}
+
+// Summary resume el documento para el log de arranque
+func (d *Document) Summary() string {
+	return fmt.Sprintf("%s %s: %d operations on %d paths", d.Info.Title, d.Info.Version, len(d.routes), len(d.Paths))
+}
@@ -0,0 +1,309 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema es el subconjunto de JSON Schema que usa OpenAPI 3.0 y que entiende
+// el validador
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// Constructores de esquemas simples para parámetros y respuestas
+func String() *Schema  { return &Schema{Type: "string"} }
+func Integer() *Schema { return &Schema{Type: "integer"} }
+func Boolean() *Schema { return &Schema{Type: "boolean"} }
+func Binary() *Schema  { return &Schema{Type: "string", Format: "binary"} }
+
+// Enum es un string limitado a values
+func Enum(values ...string) *Schema {
+	schema := String()
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, value)
+	}
+	return schema
+}
+
+// ID es un identificador numérico como los que leen los controllers (uint32)
+func ID() *Schema {
+	minimum, maximum := 0.0, float64(1<<32-1)
+	return &Schema{Type: "integer", Format: "int64", Minimum: &minimum, Maximum: &maximum}
+}
+
+// ArrayOf es una lista de items
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+// Object arma un objeto con las propiedades indicadas, todas obligatorias
+func Object(properties map[string]*Schema) *Schema {
+	schema := &Schema{Type: "object", Properties: properties}
+	for name := range properties {
+		schema.Required = append(schema.Required, name)
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// Any acepta cualquier valor JSON
+func Any() *Schema { return &Schema{} }
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry genera esquemas por reflexión sobre las etiquetas json y
+// binding de los DTOs y registra los structs con nombre en components
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry(schemas map[string]*Schema) *schemaRegistry {
+	return &schemaRegistry{schemas: schemas, names: make(map[reflect.Type]string)}
+}
+
+func (r *schemaRegistry) schemaOf(v interface{}) *Schema {
+	if v == nil {
+		return Any()
+	}
+	return r.typeSchema(reflect.TypeOf(v))
+}
+
+func (r *schemaRegistry) typeSchema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return Any()
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := r.typeSchema(t.Elem())
+		if schema.Ref != "" {
+			// nullable no se puede combinar con $ref en 3.0
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		minimum := 0.0
+		return &Schema{Type: "integer", Format: "int64", Minimum: &minimum}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return String()
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return ArrayOf(r.typeSchema(t.Elem()))
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return r.structRef(t)
+	default:
+		return Any()
+	}
+}
+
+// structRef registra el struct en components la primera vez y devuelve su $ref.
+// Se reserva el nombre antes de recorrer los campos para admitir recursión
+func (r *schemaRegistry) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return r.structSchema(t)
+	}
+	if name, ok := r.names[t]; ok {
+		return Ref(name)
+	}
+
+	name := componentName(t)
+	r.names[t] = name
+	r.schemas[name] = &Schema{}
+	*r.schemas[name] = *r.structSchema(t)
+	return Ref(name)
+}
+
+// componentName usa el nombre del tipo para los DTOs y lo prefija con el
+// paquete para los demás (models.User y dto.UserInfo no chocan)
+func componentName(t reflect.Type) string {
+	pkg := path.Base(t.PkgPath())
+	if pkg == "dto" {
+		return t.Name()
+	}
+	return pkg + "." + t.Name()
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(schema, t, "json")
+	sort.Strings(schema.Required)
+	return schema
+}
+
+func (r *schemaRegistry) addFields(schema *Schema, t reflect.Type, tagName string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get(tagName)
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		// Structs embebidos sin nombre se aplanan como hace encoding/json
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.addFields(schema, embedded, tagName)
+				continue
+			}
+		}
+		if name == "" {
+			if tagName != "json" {
+				continue
+			}
+			name = field.Name
+		}
+
+		property := r.typeSchema(field.Type)
+		if applyBinding(property, field) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// formSchemaOf describe un formulario multipart: los campos con etiqueta form
+// de v y los archivos indicados, que son obligatorios
+func (r *schemaRegistry) formSchemaOf(v interface{}, files []string) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	if v != nil {
+		t := reflect.TypeOf(v)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		r.addFields(schema, t, "form")
+	}
+	for _, file := range files {
+		schema.Properties[file] = Binary()
+		schema.Required = append(schema.Required, file)
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// applyBinding traduce las reglas de binding (go-playground/validator) que
+// tienen equivalente en JSON Schema. Devuelve si el campo es obligatorio.
+// Con omitempty el valor vacío es válido, así que no se fija ni el mínimo ni
+// el formato
+func applyBinding(schema *Schema, field reflect.StructField) bool {
+	rules := strings.Split(field.Tag.Get("binding"), ",")
+	required, omitEmpty := false, false
+
+	target := schema
+	if len(schema.AllOf) == 1 {
+		target = schema.AllOf[0]
+	}
+
+	for _, rule := range rules {
+		if rule == "dive" {
+			break // Las reglas siguientes aplican a los elementos
+		}
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			required = true
+		case "omitempty":
+			omitEmpty = true
+		case "email":
+			if !omitEmpty {
+				target.Format = "email"
+			}
+		case "url":
+			if !omitEmpty {
+				target.Format = "uri"
+			}
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				target.Enum = append(target.Enum, option)
+			}
+		case "min", "max":
+			limit, err := strconv.Atoi(value)
+			if err != nil || (key == "min" && omitEmpty) {
+				continue
+			}
+			setLimit(target, key, limit)
+		}
+	}
+
+	if required && target.Type == "string" && target.MinLength == nil && !schema.Nullable {
+		one := 1
+		target.MinLength = &one
+	}
+	return required
+}
+
+func setLimit(schema *Schema, key string, limit int) {
+	switch schema.Type {
+	case "string":
+		if key == "min" {
+			schema.MinLength = &limit
+		} else {
+			schema.MaxLength = &limit
+		}
+	case "array":
+		if key == "min" {
+			schema.MinItems = &limit
+		} else {
+			schema.MaxItems = &limit
+		}
+	case "integer", "number":
+		value := float64(limit)
+		if key == "min" {
+			schema.Minimum = &value
+		} else {
+			schema.Maximum = &value
+		}
+	}
+}
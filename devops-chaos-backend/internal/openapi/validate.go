@@ -0,0 +1,333 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationError describe por qué una petición no cumple la especificación
+type ValidationError struct {
+	In      string // "path", "query" o "body"
+	Name    string // Parámetro o ruta JSON del valor inválido
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Name == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Name, e.Message)
+}
+
+// Request es lo que el validador necesita de una petición
+type Request struct {
+	PathParams  map[string]string
+	Query       url.Values
+	ContentType string
+	Body        io.ReadCloser // Se reemplaza por una copia si se lee
+}
+
+// ValidateRequest comprueba parámetros y cuerpo JSON de una petición contra
+// la operación. Solo valida cuerpos JSON; multipart y demás los valida el
+// handler. Devuelve el cuerpo (leído o no) para que el handler lo vuelva a leer
+func (d *Document) ValidateRequest(op *Operation, req *Request) (io.ReadCloser, error) {
+	for _, param := range op.Parameters {
+		var value string
+		var present bool
+		switch param.In {
+		case "path":
+			value, present = req.PathParams[param.Name]
+		case "query":
+			present = req.Query.Has(param.Name)
+			value = req.Query.Get(param.Name)
+		default:
+			continue
+		}
+
+		if !present || value == "" {
+			if param.Required {
+				return req.Body, &ValidationError{In: param.In, Name: param.Name, Message: "is required"}
+			}
+			continue
+		}
+		if err := d.validateParam(param, value); err != nil {
+			return req.Body, err
+		}
+	}
+
+	if op.RequestBody == nil || req.Body == nil || req.Body == http.NoBody {
+		return d.checkMissingBody(op, req.Body)
+	}
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok || media.Schema == nil || !isJSON(req.ContentType, op.RequestBody) {
+		return req.Body, nil
+	}
+
+	raw, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	body := io.NopCloser(bytes.NewReader(raw))
+	if err != nil {
+		return body, err
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return d.checkMissingBody(op, body)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return body, &ValidationError{In: "body", Message: "body is not valid JSON: " + err.Error()}
+	}
+	if err := d.validateValue(media.Schema, value, "body"); err != nil {
+		return body, err
+	}
+	return body, nil
+}
+
+func (d *Document) checkMissingBody(op *Operation, body io.ReadCloser) (io.ReadCloser, error) {
+	if op.RequestBody != nil && op.RequestBody.Required {
+		return body, &ValidationError{In: "body", Message: "request body is required"}
+	}
+	return body, nil
+}
+
+// isJSON indica si el cuerpo se debe validar como JSON: lo es si lo declara o
+// si no declara un tipo que la operación acepte por otra vía (los handlers
+// leen JSON aunque falte el Content-Type)
+func isJSON(contentType string, body *RequestBody) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "" {
+		return true
+	}
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		return true
+	}
+	_, declared := body.Content[mediaType]
+	return !declared
+}
+
+func (d *Document) validateParam(param Parameter, value string) error {
+	schema := d.resolve(param.Schema)
+	invalid := func(message string) error {
+		return &ValidationError{In: param.In, Name: param.Name, Message: message}
+	}
+
+	var typed interface{} = value
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return invalid("must be an integer")
+		}
+		typed = json.Number(strconv.FormatInt(n, 10))
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return invalid("must be a number")
+		}
+		typed = json.Number(value)
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return invalid("must be true or false")
+		}
+		typed = b
+	}
+
+	if err := d.validateValue(schema, typed, param.Name); err != nil {
+		err.(*ValidationError).In = param.In
+		return err
+	}
+	return nil
+}
+
+// validateValue valida un valor decodificado con UseNumber
+func (d *Document) validateValue(schema *Schema, value interface{}, at string) error {
+	schema = d.resolve(schema)
+	invalid := func(format string, args ...interface{}) error {
+		return &ValidationError{In: "body", Name: at, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if value == nil {
+		if schema.Nullable || schema.Type == "" && len(schema.AllOf) == 0 && len(schema.OneOf) == 0 {
+			return nil
+		}
+		return invalid("must not be null")
+	}
+
+	for _, part := range schema.AllOf {
+		if err := d.validateValue(part, value, at); err != nil {
+			return err
+		}
+	}
+	if len(schema.OneOf) > 0 {
+		matches := 0
+		for _, option := range schema.OneOf {
+			if d.validateValue(option, value, at) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return invalid("does not match exactly one of the allowed shapes")
+		}
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		return invalid("must be one of %s", enumList(schema.Enum))
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return invalid("must be an object")
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				return &ValidationError{In: "body", Name: at + "." + name, Message: "is required"}
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := schema.Properties[name]
+			if !ok {
+				property = schema.AdditionalProperties
+			}
+			if property == nil {
+				continue // Se admiten propiedades no declaradas
+			}
+			if err := d.validateValue(property, object[name], at+"."+name); err != nil {
+				return err
+			}
+		}
+
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return invalid("must be an array")
+		}
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			return invalid("must have at least %d items", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			return invalid("must have at most %d items", *schema.MaxItems)
+		}
+		if schema.Items != nil {
+			for i, item := range items {
+				if err := d.validateValue(schema.Items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return invalid("must be a string")
+		}
+		length := utf8.RuneCountInString(s)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			if *schema.MinLength == 1 {
+				return invalid("must not be empty")
+			}
+			return invalid("must be at least %d characters", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			return invalid("must be at most %d characters", *schema.MaxLength)
+		}
+		if err := checkFormat(schema.Format, s); err != "" {
+			return invalid("%s", err)
+		}
+
+	case "integer", "number":
+		n, ok := value.(json.Number)
+		if !ok {
+			return invalid("must be a number")
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return invalid("must be a number")
+		}
+		if schema.Type == "integer" {
+			if _, err := n.Int64(); err != nil {
+				return invalid("must be an integer")
+			}
+		}
+		if schema.Minimum != nil && f < *schema.Minimum {
+			return invalid("must be at least %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && f > *schema.Maximum {
+			return invalid("must be at most %v", *schema.Maximum)
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return invalid("must be true or false")
+		}
+	}
+	return nil
+}
+
+// resolve sigue las referencias a components
+func (d *Document) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	if schema == nil {
+		return Any()
+	}
+	return schema
+}
+
+// checkFormat es deliberadamente más laxo que el binding de los handlers,
+// que tiene la última palabra
+func checkFormat(format, value string) string {
+	switch format {
+	case "email":
+		local, domain, ok := strings.Cut(value, "@")
+		if !ok || local == "" || domain == "" || strings.ContainsAny(value, " \t\r\n") {
+			return "must be an email address"
+		}
+	case "uri":
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" {
+			return "must be an absolute URL"
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return "must be an RFC3339 date-time"
+		}
+	}
+	return ""
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, option := range enum {
+		if fmt.Sprint(option) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumList(enum []interface{}) string {
+	options := make([]string, len(enum))
+	for i, option := range enum {
+		options[i] = fmt.Sprint(option)
+	}
+	return strings.Join(options, ", ")
+}
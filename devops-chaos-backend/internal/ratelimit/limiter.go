@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter limita eventos por llave con una ventana fija en memoria
+type Limiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	entries map[string]*windowEntry
+}
+
+type windowEntry struct {
+	count   int
+	resetAt time.Time
+}
+
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		window:  window,
+		entries: make(map[string]*windowEntry),
+	}
+}
+
+// Allow registra un evento para key; si se excede el límite devuelve false y
+// el tiempo restante hasta que se reinicie la ventana
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := l.entries[key]
+	if !ok || now.After(entry.resetAt) {
+		entry = &windowEntry{resetAt: now.Add(l.window)}
+		l.entries[key] = entry
+	}
+
+	if entry.count >= l.limit {
+		return false, entry.resetAt.Sub(now)
+	}
+
+	entry.count++
+	return true, 0
+}
+
+// Cleanup elimina las ventanas vencidas
+func (l *Limiter) Cleanup() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range l.entries {
+		if now.After(entry.resetAt) {
+			delete(l.entries, key)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package realtime
+
+// Tipos de eventos publicados en /api/stream
+const (
+	EventCaptureCreated     = "capture.created"
+	EventReportSubmitted    = "report.submitted"
+	EventReportApproved     = "report.approved"
+	EventPunishmentAssigned = "punishment.assigned"
+	EventLeaderboardUpdated = "leaderboard.updated"
+	EventNotification       = "notification.created"
+)
@@ -0,0 +1,164 @@
+package realtime
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Event es un mensaje publicado a las conexiones en vivo
+type Event struct {
+	ID       uint64
+	Type     string
+	Data     any
+	Audience Audience
+}
+
+// Audience indica quién puede recibir un evento: cualquier usuario con uno de
+// los roles o cualquiera de los usuarios listados
+type Audience struct {
+	Roles   []string
+	UserIDs []uint
+}
+
+// ToRoles crea una audiencia por rol
+func ToRoles(roles ...string) Audience {
+	return Audience{Roles: roles}
+}
+
+// ToUsers crea una audiencia de usuarios puntuales
+func ToUsers(userIDs ...uint) Audience {
+	return Audience{UserIDs: userIDs}
+}
+
+func (a Audience) includes(userID uint, role string) bool {
+	for _, r := range a.Roles {
+		if r == role {
+			return true
+		}
+	}
+	for _, id := range a.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Hub reparte eventos en proceso a las suscripciones activas. Cada suscripción
+// tiene un buffer propio: si un cliente lento lo llena se lo desconecta en lugar
+// de frenar a quien publica
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	bufferSize  int
+	lastID      atomic.Uint64
+	closed      bool
+}
+
+func NewHub(bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = 32
+	}
+	return &Hub{
+		subscribers: make(map[*Subscription]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe registra una conexión del usuario
+func (h *Hub) Subscribe(userID uint, role string) *Subscription {
+	sub := &Subscription{
+		UserID: userID,
+		Role:   role,
+		events: make(chan Event, h.bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		sub.close()
+		return sub
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe quita la suscripción y la cierra
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	delete(h.subscribers, sub)
+	h.mu.Unlock()
+	sub.close()
+}
+
+// Publish entrega el evento a cada suscripción de su audiencia sin bloquear.
+// Un hub nil descarta el evento
+func (h *Hub) Publish(event Event) {
+	if h == nil {
+		return
+	}
+	event.ID = h.lastID.Add(1)
+
+	var overflowed []*Subscription
+
+	h.mu.RLock()
+	for sub := range h.subscribers {
+		if !event.Audience.includes(sub.UserID, sub.Role) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			overflowed = append(overflowed, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range overflowed {
+		sub.overflowed.Store(true)
+		h.Unsubscribe(sub)
+	}
+}
+
+// Close desconecta a todos; se usa al apagar el servidor para no esperar
+// conexiones que nunca terminan
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subscribers {
+		sub.close()
+		delete(h.subscribers, sub)
+	}
+}
+
+// Subscription es una conexión en vivo de un usuario
+type Subscription struct {
+	UserID     uint
+	Role       string
+	events     chan Event
+	done       chan struct{}
+	once       sync.Once
+	overflowed atomic.Bool
+}
+
+// Events entrega los eventos pendientes
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Done se cierra cuando el hub termina la suscripción
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Overflowed indica que se cortó por no consumir eventos a tiempo; el cliente
+// debe volver a pedir el snapshot del dashboard
+func (s *Subscription) Overflowed() bool {
+	return s.overflowed.Load()
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() { close(s.done) })
+}
@@ -34,6 +34,10 @@ func (s *Seeder) RunAllSeeders() error {
 		return err
 	}
 
+	if err := s.SeedResistanceContent(); err != nil {
+		return err
+	}
+
 	log.Println("Database seeding completed successfully!")
 	return nil
 }
@@ -115,6 +119,14 @@ func (s *Seeder) SeedUsers() error {
 			Role:     models.RoleNetworkAdmin,
 			Status:   models.StatusActive,
 		},
+		// Moderadores
+		{
+			Username: "moderator",
+			Email:    "moderator@chaos.dev",
+			Password: s.hashPassword("Moderator123!"),
+			Role:     models.RoleModerator,
+			Status:   models.StatusActive,
+		},
 	}
 
 	for _, user := range users {
@@ -323,6 +335,134 @@ func (s *Seeder) SeedPunishments() error {
 	return nil
 }
 
+// SeedResistanceContent crea los consejos, memes y contactos iniciales de la resistencia
+func (s *Seeder) SeedResistanceContent() error {
+	log.Println("Seeding resistance content...")
+
+	tips := []models.SurvivalTip{
+		{
+			Title:       "Change Default Passwords",
+			Description: "Always change default credentials on all network equipment immediately after installation.",
+			Priority:    "critical",
+			Category:    "security",
+			Upvotes:     25,
+		},
+		{
+			Title:       "Monitor Network Traffic",
+			Description: "Keep constant watch on unusual network patterns that might indicate daemon infiltration.",
+			Priority:    "high",
+			Category:    "technical",
+			Upvotes:     18,
+		},
+		{
+			Title:       "Trust No One",
+			Description: "Verify the identity of all personnel requesting network access, even familiar faces.",
+			Priority:    "high",
+			Category:    "social",
+			Upvotes:     15,
+		},
+		{
+			Title:       "Backup Everything",
+			Description: "Maintain secure offline backups of critical configurations and data.",
+			Priority:    "medium",
+			Category:    "technical",
+			Upvotes:     12,
+		},
+		{
+			Title:       "Use Anonymous Reporting",
+			Description: "Report suspicious activities through secure anonymous channels to avoid detection.",
+			Priority:    "medium",
+			Category:    "security",
+			Upvotes:     10,
+		},
+	}
+
+	for _, tip := range tips {
+		var existingTip models.SurvivalTip
+		result := config.DB.Where("title = ?", tip.Title).First(&existingTip)
+		if result.RowsAffected == 0 {
+			tip.Status = models.ContentStatusApproved
+			if err := config.DB.Create(&tip).Error; err != nil {
+				log.Printf("Error creating survival tip %s: %v", tip.Title, err)
+				return err
+			}
+			log.Printf("Created survival tip: %s", tip.Title)
+		}
+	}
+
+	memes := []models.ResistanceMeme{
+		{
+			Title:       "This is Fine Network Admin",
+			ImageURL:    "/static/memes/this-is-fine-netadmin.jpg",
+			Description: "When the firewall is down but you're still monitoring logs",
+			Upvotes:     42,
+		},
+		{
+			Title:       "Distracted Daemon",
+			ImageURL:    "/static/memes/distracted-daemon.jpg",
+			Description: "Daemon looking at new vulnerability while network admin patches old ones",
+			Upvotes:     38,
+		},
+		{
+			Title:       "Drake Network Security",
+			ImageURL:    "/static/memes/drake-netsec.jpg",
+			Description: "Drake rejecting easy passwords, approving 2FA",
+			Upvotes:     56,
+		},
+	}
+
+	for _, meme := range memes {
+		var existingMeme models.ResistanceMeme
+		result := config.DB.Where("title = ?", meme.Title).First(&existingMeme)
+		if result.RowsAffected == 0 {
+			meme.Status = models.ContentStatusApproved
+			if err := config.DB.Create(&meme).Error; err != nil {
+				log.Printf("Error creating meme %s: %v", meme.Title, err)
+				return err
+			}
+			log.Printf("Created meme: %s", meme.Title)
+		}
+	}
+
+	contacts := []models.EmergencyContact{
+		{
+			Name:        "Anonymous Tip Line",
+			Role:        "Intelligence Gathering",
+			Contact:     "Signal: +1-XXX-RESIST",
+			Description: "Secure channel for reporting daemon activities",
+			SortOrder:   1,
+		},
+		{
+			Name:        "Emergency Response Team",
+			Role:        "Incident Response",
+			Contact:     "Encrypted Email: emergency@resistance.net",
+			Description: "24/7 incident response for critical security breaches",
+			SortOrder:   2,
+		},
+		{
+			Name:        "Safe House Network",
+			Role:        "Safe Harbor",
+			Contact:     "Tor: safehouse.onion",
+			Description: "Secure locations for compromised network admins",
+			SortOrder:   3,
+		},
+	}
+
+	for _, contact := range contacts {
+		var existingContact models.EmergencyContact
+		result := config.DB.Where("name = ?", contact.Name).First(&existingContact)
+		if result.RowsAffected == 0 {
+			if err := config.DB.Create(&contact).Error; err != nil {
+				log.Printf("Error creating emergency contact %s: %v", contact.Name, err)
+				return err
+			}
+			log.Printf("Created emergency contact: %s", contact.Name)
+		}
+	}
+
+	return nil
+}
+
 // Helper methods
 
 func (s *Seeder) hashPassword(password string) string {
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/ratelimit"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+)
+
+// Cada cuánto se rota la llave con la que se seudonimiza al remitente
+const pseudonymKeyLifetime = 24 * time.Hour
+
+// AnonymousGuard protege el endpoint de reportes anónimos sin guardar quién envía:
+// limita por un seudónimo efímero (HMAC con llave en memoria que rota) y exige un
+// ticket de un solo uso con prueba de trabajo
+type AnonymousGuard struct {
+	mu              sync.Mutex
+	key             []byte
+	keyExpiresAt    time.Time
+	tickets         map[string]time.Time // hash del ticket -> vencimiento
+	submissions     *ratelimit.Limiter
+	challenges      *ratelimit.Limiter
+	ticketTTL       time.Duration
+	difficulty      int
+	duplicateWindow time.Duration
+}
+
+func NewAnonymousGuard(cfg *config.AnonymousConfig) *AnonymousGuard {
+	return &AnonymousGuard{
+		tickets:         make(map[string]time.Time),
+		submissions:     ratelimit.NewLimiter(cfg.SubmissionLimit, cfg.SubmissionWindow),
+		challenges:      ratelimit.NewLimiter(cfg.ChallengeLimit, cfg.SubmissionWindow),
+		ticketTTL:       cfg.TicketTTL,
+		difficulty:      cfg.PowDifficulty,
+		duplicateWindow: cfg.DuplicateWindow,
+	}
+}
+
+// IssueChallenge entrega un ticket de un solo uso; el ticket no queda asociado al usuario
+func (g *AnonymousGuard) IssueChallenge(userID uint) (*dto.SubmissionChallenge, error) {
+	if allowed, retryAfter := g.challenges.Allow(g.pseudonym(userID)); !allowed {
+		return nil, rateLimited(retryAfter)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	ticket := base64.RawURLEncoding.EncodeToString(raw)
+	expiresAt := time.Now().Add(g.ticketTTL)
+
+	g.mu.Lock()
+	g.tickets[hashTicket(ticket)] = expiresAt
+	g.mu.Unlock()
+
+	return &dto.SubmissionChallenge{
+		Ticket:     ticket,
+		Difficulty: g.difficulty,
+		Algorithm:  "sha256(ticket + \":\" + nonce) with leading zero bits",
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// CheckSubmission aplica el límite por seudónimo, consume el ticket y verifica la prueba de trabajo
+func (g *AnonymousGuard) CheckSubmission(userID uint, ticket, nonce string) error {
+	if allowed, retryAfter := g.submissions.Allow(g.pseudonym(userID)); !allowed {
+		return rateLimited(retryAfter)
+	}
+
+	// El ticket se consume aunque la prueba falle, para obligar a pedir otro
+	if !g.consumeTicket(ticket) {
+		return apperrors.Validation("invalid_ticket", "Submission ticket is missing, expired or already used")
+	}
+
+	if leadingZeroBits(ticket, nonce) < g.difficulty {
+		return apperrors.Validation("invalid_proof_of_work", "Proof of work does not meet the required difficulty")
+	}
+
+	return nil
+}
+
+// DuplicateWindow es el periodo en el que se buscan reportes repetidos
+func (g *AnonymousGuard) DuplicateWindow() time.Duration {
+	return g.duplicateWindow
+}
+
+// Cleanup descarta tickets vencidos y ventanas de límite expiradas
+func (g *AnonymousGuard) Cleanup() {
+	g.submissions.Cleanup()
+	g.challenges.Cleanup()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	for hash, expiresAt := range g.tickets {
+		if now.After(expiresAt) {
+			delete(g.tickets, hash)
+		}
+	}
+}
+
+func (g *AnonymousGuard) consumeTicket(ticket string) bool {
+	if ticket == "" {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	hash := hashTicket(ticket)
+	expiresAt, ok := g.tickets[hash]
+	if !ok {
+		return false
+	}
+	delete(g.tickets, hash)
+	return time.Now().Before(expiresAt)
+}
+
+// pseudonym deriva un identificador efímero que no se puede revertir ni persistir
+func (g *AnonymousGuard) pseudonym(userID uint) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	if g.key == nil || now.After(g.keyExpiresAt) {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(fmt.Sprintf("crypto/rand failed: %v", err))
+		}
+		g.key = key
+		g.keyExpiresAt = now.Add(pseudonymKeyLifetime)
+	}
+
+	mac := hmac.New(sha256.New, g.key)
+	var id [8]byte
+	binary.BigEndian.PutUint64(id[:], uint64(userID))
+	mac.Write(id[:])
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func hashTicket(ticket string) string {
+	sum := sha256.Sum256([]byte(ticket))
+	return hex.EncodeToString(sum[:])
+}
+
+// leadingZeroBits cuenta los bits en cero al inicio de sha256(ticket:nonce)
+func leadingZeroBits(ticket, nonce string) int {
+	sum := sha256.Sum256([]byte(ticket + ":" + nonce))
+	count := 0
+	for _, b := range sum {
+		if b == 0 {
+			count += 8
+			continue
+		}
+		count += bits.LeadingZeros8(b)
+		break
+	}
+	return count
+}
+
+func rateLimited(retryAfter time.Duration) *apperrors.Error {
+	err := apperrors.RateLimited("too_many_reports", "Too many anonymous submissions, try again later")
+	err.RetryAfter = time.Duration(math.Ceil(retryAfter.Seconds())) * time.Second
+	return err
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestGuard(difficulty, submissionLimit int, ticketTTL time.Duration) *AnonymousGuard {
+	return NewAnonymousGuard(&config.AnonymousConfig{
+		SubmissionLimit:  submissionLimit,
+		SubmissionWindow: time.Hour,
+		ChallengeLimit:   100,
+		TicketTTL:        ticketTTL,
+		PowDifficulty:    difficulty,
+		DuplicateWindow:  time.Hour,
+	})
+}
+
+// solve busca un nonce cuyo hash alcance (strong) o no alcance la dificultad
+func solve(t *testing.T, ticket string, difficulty int, strong bool) string {
+	t.Helper()
+	for i := 0; i < 1<<22; i++ {
+		nonce := strconv.Itoa(i)
+		if (leadingZeroBits(ticket, nonce) >= difficulty) == strong {
+			return nonce
+		}
+	}
+	t.Fatalf("no nonce found for difficulty %d", difficulty)
+	return ""
+}
+
+func errorCode(err error) string {
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ""
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+	tests := []struct {
+		nonce string
+		want  int
+	}{
+		{"2", 0},
+		{"0", 1},
+		{"11", 4},
+		{"339", 8},
+		{"234841", 16},
+	}
+
+	for _, tt := range tests {
+		if got := leadingZeroBits("dGVzdC10aWNrZXQ", tt.nonce); got != tt.want {
+			t.Errorf("leadingZeroBits(nonce %q) = %d, want %d", tt.nonce, got, tt.want)
+		}
+	}
+}
+
+func TestCheckSubmission(t *testing.T) {
+	const difficulty = 8
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		submit   func(t *testing.T, g *AnonymousGuard, ticket string) error
+		wantCode string
+	}{
+		{
+			name: "valid proof",
+			ttl:  time.Minute,
+			submit: func(t *testing.T, g *AnonymousGuard, ticket string) error {
+				return g.CheckSubmission(1, ticket, solve(t, ticket, difficulty, true))
+			},
+		},
+		{
+			name: "proof below difficulty",
+			ttl:  time.Minute,
+			submit: func(t *testing.T, g *AnonymousGuard, ticket string) error {
+				return g.CheckSubmission(1, ticket, solve(t, ticket, difficulty, false))
+			},
+			wantCode: "invalid_proof_of_work",
+		},
+		{
+			name: "reused ticket",
+			ttl:  time.Minute,
+			submit: func(t *testing.T, g *AnonymousGuard, ticket string) error {
+				nonce := solve(t, ticket, difficulty, true)
+				if err := g.CheckSubmission(1, ticket, nonce); err != nil {
+					t.Fatalf("first submission: %v", err)
+				}
+				return g.CheckSubmission(1, ticket, nonce)
+			},
+			wantCode: "invalid_ticket",
+		},
+		{
+			name: "ticket consumed by a failed proof",
+			ttl:  time.Minute,
+			submit: func(t *testing.T, g *AnonymousGuard, ticket string) error {
+				g.CheckSubmission(1, ticket, solve(t, ticket, difficulty, false))
+				return g.CheckSubmission(1, ticket, solve(t, ticket, difficulty, true))
+			},
+			wantCode: "invalid_ticket",
+		},
+		{
+			name: "unknown ticket",
+			ttl:  time.Minute,
+			submit: func(t *testing.T, g *AnonymousGuard, ticket string) error {
+				forged := ticket + "x"
+				return g.CheckSubmission(1, forged, solve(t, forged, difficulty, true))
+			},
+			wantCode: "invalid_ticket",
+		},
+		{
+			name: "empty ticket",
+			ttl:  time.Minute,
+			submit: func(t *testing.T, g *AnonymousGuard, ticket string) error {
+				return g.CheckSubmission(1, "", "0")
+			},
+			wantCode: "invalid_ticket",
+		},
+		{
+			name: "expired ticket",
+			ttl:  -time.Second,
+			submit: func(t *testing.T, g *AnonymousGuard, ticket string) error {
+				return g.CheckSubmission(1, ticket, solve(t, ticket, difficulty, true))
+			},
+			wantCode: "invalid_ticket",
+		},
+		{
+			name: "over the submission limit",
+			ttl:  time.Minute,
+			submit: func(t *testing.T, g *AnonymousGuard, ticket string) error {
+				g.CheckSubmission(1, "", "0")
+				g.CheckSubmission(1, "", "0")
+				return g.CheckSubmission(1, ticket, solve(t, ticket, difficulty, true))
+			},
+			wantCode: "too_many_reports",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGuard(difficulty, 2, tt.ttl)
+			challenge, err := g.IssueChallenge(1)
+			if err != nil {
+				t.Fatalf("IssueChallenge: %v", err)
+			}
+			if challenge.Difficulty != difficulty {
+				t.Fatalf("difficulty = %d, want %d", challenge.Difficulty, difficulty)
+			}
+
+			err = tt.submit(t, g, challenge.Ticket)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if code := errorCode(err); code != tt.wantCode {
+				t.Fatalf("code = %q, want %q (%v)", code, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestRateLimitedRetryAfter(t *testing.T) {
+	g := newTestGuard(0, 1, time.Minute)
+	g.CheckSubmission(1, "", "0")
+
+	var appErr *apperrors.Error
+	if !errors.As(g.CheckSubmission(1, "", "0"), &appErr) || appErr.Code != "too_many_reports" {
+		t.Fatalf("expected too_many_reports, got %v", appErr)
+	}
+	if appErr.RetryAfter <= 0 || appErr.RetryAfter%time.Second != 0 {
+		t.Fatalf("RetryAfter = %v, want whole positive seconds", appErr.RetryAfter)
+	}
+
+	// El límite es por seudónimo: otro usuario no se ve afectado
+	if code := errorCode(g.CheckSubmission(2, "", "0")); code != "invalid_ticket" {
+		t.Fatalf("other user code = %q, want invalid_ticket", code)
+	}
+}
+
+func TestPseudonym(t *testing.T) {
+	g := newTestGuard(0, 1, time.Minute)
+
+	first := g.pseudonym(1)
+	if len(first) != 64 {
+		t.Fatalf("pseudonym length = %d, want 64 hex chars", len(first))
+	}
+	if again := g.pseudonym(1); again != first {
+		t.Fatalf("pseudonym not stable while the key is valid: %q != %q", again, first)
+	}
+	if other := g.pseudonym(2); other == first {
+		t.Fatal("different users share a pseudonym")
+	}
+	if fresh := newTestGuard(0, 1, time.Minute).pseudonym(1); fresh == first {
+		t.Fatal("pseudonym does not depend on the guard key")
+	}
+
+	// Al vencer la llave el seudónimo cambia y no se puede enlazar con el anterior
+	g.mu.Lock()
+	g.keyExpiresAt = time.Now().Add(-time.Second)
+	g.mu.Unlock()
+	if rotated := g.pseudonym(1); rotated == first {
+		t.Fatal("pseudonym did not change after key rotation")
+	}
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/storage"
+	"devops-chaos-backend/internal/utils"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxAttachmentsPerReport = 10
+	maxAttachmentNameLength = 200
+)
+
+type AttachmentService struct {
+	reportService *ReportService
+	reportDAO     *dao.ReportDAO
+	attachmentDAO *dao.ReportAttachmentDAO
+	store         storage.BlobStore
+	maxBytes      int64
+}
+
+func NewAttachmentService(store storage.BlobStore, maxBytes int64) *AttachmentService {
+	return &AttachmentService{
+		reportService: NewReportService(nil), // Solo se usan sus reglas de acceso
+		reportDAO:     dao.NewReportDAO(),
+		attachmentDAO: dao.NewReportAttachmentDAO(),
+		store:         store,
+		maxBytes:      maxBytes,
+	}
+}
+
+// Subir evidencia a un reporte
+func (s *AttachmentService) UploadAttachment(ctx context.Context, reportID uint, req *dto.UploadAttachmentRequest, file *multipart.FileHeader, userID uint, userRole string) (*dto.ReportAttachmentResponse, error) {
+	report, err := s.reportDAO.FindByID(reportID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "report_not_found", "Report not found")
+	}
+
+	if err := s.checkCanUpload(report, req.ReceiptCode, userID, userRole); err != nil {
+		return nil, err
+	}
+
+	count, err := s.attachmentDAO.CountByReport(report.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxAttachmentsPerReport {
+		return nil, apperrors.Conflict("attachment_limit_reached",
+			fmt.Sprintf("A report can have at most %d attachments", maxAttachmentsPerReport))
+	}
+
+	if file.Size > s.maxBytes {
+		return nil, apperrors.TooLarge("file_too_large", fmt.Sprintf("File exceeds the %d byte limit", s.maxBytes))
+	}
+
+	data, err := s.readUpload(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// El tipo se decide por el contenido, nunca por lo que declara el cliente
+	contentType, ext, ok := utils.DetectEvidenceType(data, file.Filename)
+	if !ok {
+		return nil, apperrors.Validation("unsupported_file_type",
+			"Only PNG, JPEG, GIF, PDF, plain text and pcap files are accepted")
+	}
+
+	attachment := &models.ReportAttachment{
+		ReportID:    report.ID,
+		ContentType: contentType,
+	}
+
+	if report.Type == models.ReportTypeAnonymous {
+		// Los adjuntos anónimos no guardan uploader, nombre original ni metadatos
+		if !utils.CanStripMetadata(contentType) {
+			return nil, apperrors.Validation("unsupported_anonymous_file_type",
+				"This file type cannot be anonymized; use PNG, JPEG, GIF, plain text or pcap")
+		}
+
+		data, err = utils.StripMetadata(contentType, data)
+		if errors.Is(err, utils.ErrMalformedFile) {
+			return nil, apperrors.Validation("invalid_file", "File is corrupted or malformed")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		attachment.FileName = fmt.Sprintf("evidence-%d%s", count+1, ext)
+	} else {
+		attachment.UploaderID = &userID
+		attachment.FileName = sanitizeFileName(file.Filename, ext)
+	}
+
+	key, err := newStorageKey(fmt.Sprintf("reports/%d", report.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	attachment.SHA256 = hex.EncodeToString(sum[:])
+	attachment.StorageKey = key
+
+	size, err := s.store.Put(ctx, key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	attachment.Size = size
+
+	if err := s.attachmentDAO.Create(attachment); err != nil {
+		s.store.Delete(ctx, key) // No dejar blobs huérfanos
+		return nil, err
+	}
+
+	createdAttachment, err := s.attachmentDAO.FindByID(report.ID, attachment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToAttachmentResponse(createdAttachment), nil
+}
+
+// Listar adjuntos de un reporte
+func (s *AttachmentService) GetAttachments(reportID uint, userID uint, userRole string) ([]dto.ReportAttachmentResponse, error) {
+	report, err := s.findAccessibleReport(reportID, userID, userRole)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentDAO.FindByReport(report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ReportAttachmentResponse, len(attachments))
+	for i := range attachments {
+		items[i] = *s.convertToAttachmentResponse(&attachments[i])
+	}
+
+	return items, nil
+}
+
+// Abrir un adjunto para descarga; el llamador debe cerrar el reader
+func (s *AttachmentService) OpenAttachment(ctx context.Context, reportID, attachmentID uint, userID uint, userRole string) (*models.ReportAttachment, io.ReadCloser, error) {
+	report, err := s.findAccessibleReport(reportID, userID, userRole)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.attachmentDAO.FindByID(report.ID, attachmentID)
+	if err != nil {
+		return nil, nil, apperrors.NotFoundOrInternal(err, "attachment_not_found", "Attachment not found")
+	}
+
+	reader, err := s.store.Open(ctx, attachment.StorageKey)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, nil, apperrors.NotFound("attachment_not_found", "Attachment file is missing")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, reader, nil
+}
+
+// Eliminar adjunto (Andrei o quien lo subió)
+func (s *AttachmentService) DeleteAttachment(reportID, attachmentID uint, userID uint, userRole string) error {
+	report, err := s.findAccessibleReport(reportID, userID, userRole)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := s.attachmentDAO.FindByID(report.ID, attachmentID)
+	if err != nil {
+		return apperrors.NotFoundOrInternal(err, "attachment_not_found", "Attachment not found")
+	}
+
+	isUploader := attachment.UploaderID != nil && *attachment.UploaderID == userID
+	if userRole != models.RoleAndrei && !isUploader {
+		return apperrors.Forbidden("not_owner", "Only Andrei or the uploader can delete this attachment")
+	}
+
+	// Soft delete: el archivo se conserva hasta que se purgue el registro
+	return s.attachmentDAO.Delete(attachment.ID)
+}
+
+func (s *AttachmentService) findAccessibleReport(reportID uint, userID uint, userRole string) (*models.Report, error) {
+	report, err := s.reportDAO.FindByID(reportID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "report_not_found", "Report not found")
+	}
+
+	if !s.reportService.canAccessReport(report, userID, userRole) {
+		return nil, apperrors.Forbidden("report_access_denied", "Not allowed to access this report")
+	}
+
+	return report, nil
+}
+
+// checkCanUpload: quienes pueden comentar, y el remitente de un reporte anónimo
+// aún abierto, que se identifica con su código de recibo
+func (s *AttachmentService) checkCanUpload(report *models.Report, receiptCode string, userID uint, userRole string) error {
+	denied := apperrors.Forbidden("report_access_denied", "Not allowed to attach files to this report")
+
+	if userRole != models.RoleNetworkAdmin {
+		if !s.reportService.canParticipate(report, userID, userRole) {
+			return denied
+		}
+		return nil
+	}
+
+	if report.Type != models.ReportTypeAnonymous ||
+		(report.Status != models.ReportStatusPending && report.Status != models.ReportStatusInReview) {
+		return denied
+	}
+	if strings.TrimSpace(receiptCode) == "" {
+		return apperrors.Forbidden("receipt_code_required", "The report's receipt code is required to attach files")
+	}
+
+	receiptReport, err := s.reportDAO.FindByReceiptHash(hashReceiptCode(receiptCode))
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && receiptReport.ID != report.ID {
+		return denied
+	}
+	return err
+}
+
+// readUpload lee el archivo completo verificando el límite real de bytes
+func (s *AttachmentService) readUpload(file *multipart.FileHeader) ([]byte, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, apperrors.Validation("invalid_file", "Could not read uploaded file")
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, s.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, apperrors.TooLarge("file_too_large", fmt.Sprintf("File exceeds the %d byte limit", s.maxBytes))
+	}
+	if len(data) == 0 {
+		return nil, apperrors.Validation("empty_file", "Uploaded file is empty")
+	}
+
+	return data, nil
+}
+
+func (s *AttachmentService) convertToAttachmentResponse(attachment *models.ReportAttachment) *dto.ReportAttachmentResponse {
+	uploadedBy := "anonymous"
+	if attachment.Uploader != nil {
+		uploadedBy = attachment.Uploader.Username
+	}
+
+	return &dto.ReportAttachmentResponse{
+		ID:          attachment.ID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		SHA256:      attachment.SHA256,
+		UploadedBy:  uploadedBy,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// sanitizeFileName conserva solo el nombre base imprimible y fuerza la extensión detectada
+func sanitizeFileName(name, ext string) string {
+	base := filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	base = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' || r == '/' {
+			return -1
+		}
+		return r
+	}, base)
+	base = strings.TrimSpace(base)
+
+	if base == "" || base == "." || base == ".." {
+		base = "evidence"
+	}
+	if runes := []rune(base); len(runes) > maxAttachmentNameLength {
+		base = string(runes[:maxAttachmentNameLength])
+	}
+	return base + ext
+}
+
+// newStorageKey genera una llave aleatoria no adivinable para el blob bajo prefix
+func newStorageKey(prefix string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return prefix + "/" + hex.EncodeToString(random), nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/models"
+	"encoding/json"
+)
+
+type AuditService struct {
+	auditDAO *dao.AuditDAO
+}
+
+func NewAuditService() *AuditService {
+	return &AuditService{
+		auditDAO: dao.NewAuditDAO(),
+	}
+}
+
+// Listar el registro de auditoría (solo Andrei)
+func (s *AuditService) GetAuditLog(params dto.ListParams) (*dto.PaginatedResponse, error) {
+	entries, page, err := s.auditDAO.List(dao.NewListQuery(params))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.AuditLogResponse, len(entries))
+	for i := range entries {
+		items[i] = convertToAuditLogResponse(&entries[i])
+	}
+	return page.ToResponse("Audit log retrieved successfully", items), nil
+}
+
+func convertToAuditLogResponse(entry *models.AuditLog) dto.AuditLogResponse {
+	response := dto.AuditLogResponse{
+		ID:        entry.ID,
+		EventID:   entry.EventID,
+		Event:     entry.EventName,
+		ActorID:   entry.ActorID,
+		SubjectID: entry.SubjectID,
+		Summary:   entry.Summary,
+		CreatedAt: entry.CreatedAt,
+	}
+	if json.Valid([]byte(entry.Payload)) {
+		response.Data = json.RawMessage(entry.Payload)
+	}
+	return response
+}
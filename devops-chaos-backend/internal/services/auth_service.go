@@ -1,12 +1,16 @@
 package services
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/dao"
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/models"
 	"devops-chaos-backend/internal/utils"
 	"errors"
+	"log"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -14,38 +18,45 @@ import (
 type AuthService struct {
 	userDAO      *dao.UserDAO
 	statisticDAO *dao.StatisticDAO
+	sessionDAO   *dao.SessionDAO
+	bus          *events.Bus
 }
 
-func NewAuthService() *AuthService {
+func NewAuthService(bus *events.Bus) *AuthService {
 	return &AuthService{
 		userDAO:      dao.NewUserDAO(),
 		statisticDAO: dao.NewStatisticDAO(),
+		sessionDAO:   dao.NewSessionDAO(),
+		bus:          bus,
 	}
 }
 
 // Login del usuario
-func (s *AuthService) Login(req *dto.LoginRequest) (*dto.LoginResponse, error) {
+func (s *AuthService) Login(req *dto.LoginRequest, client ClientInfo) (*dto.LoginResponse, error) {
 	// Buscar usuario por username
 	user, err := s.userDAO.FindByUsername(req.Username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("invalid credentials")
+			return nil, apperrors.Unauthorized("invalid_credentials", "Invalid credentials")
 		}
-		return nil, err
+		return nil, apperrors.Internal(err)
 	}
 
-	// Verificar contraseña
-	if !utils.CheckPassword(user.Password, req.Password) {
-		return nil, errors.New("invalid credentials")
+	// Verificar contraseña; un usuario anonimizado no puede volver a entrar
+	if user.AnonymizedAt != nil || !utils.CheckPassword(user.Password, req.Password) {
+		return nil, apperrors.Unauthorized("invalid_credentials", "Invalid credentials")
 	}
 
-	// Generar token JWT
-	token, err := utils.GenerateToken(user.ID, user.Username, user.Role)
+	return newLoginResponse(user, client)
+}
+
+// newLoginResponse abre una sesión y arma la respuesta con su token
+func newLoginResponse(user *models.User, client ClientInfo) (*dto.LoginResponse, error) {
+	token, err := startSession(user, client)
 	if err != nil {
-		return nil, errors.New("could not generate token")
+		return nil, apperrors.Internal(err)
 	}
 
-	// Preparar respuesta
 	response := &dto.LoginResponse{
 		Token: token,
 		User: dto.UserInfo{
@@ -64,12 +75,12 @@ func (s *AuthService) Login(req *dto.LoginRequest) (*dto.LoginResponse, error) {
 func (s *AuthService) Register(req *dto.RegisterRequest, currentUserRole string) (*dto.UserInfo, error) {
 	// Solo Andrei puede registrar usuarios
 	if currentUserRole != models.RoleAndrei {
-		return nil, errors.New("unauthorized: only Andrei can register users")
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can register users")
 	}
 
 	// Validar rol
 	if !s.isValidRole(req.Role) {
-		return nil, errors.New("invalid role")
+		return nil, apperrors.Validation("invalid_role", "Invalid role")
 	}
 
 	// Verificar que username no exista
@@ -78,7 +89,7 @@ func (s *AuthService) Register(req *dto.RegisterRequest, currentUserRole string)
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("username already exists")
+		return nil, apperrors.Conflict("username_taken", "Username already exists")
 	}
 
 	// Verificar que email no exista
@@ -87,13 +98,13 @@ func (s *AuthService) Register(req *dto.RegisterRequest, currentUserRole string)
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("email already exists")
+		return nil, apperrors.Conflict("email_taken", "Email already exists")
 	}
 
 	// Hash de la contraseña
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		return nil, errors.New("could not hash password")
+		return nil, apperrors.Internal(err)
 	}
 
 	// Crear usuario
@@ -131,53 +142,85 @@ func (s *AuthService) Register(req *dto.RegisterRequest, currentUserRole string)
 	return userInfo, nil
 }
 
-// Cambiar contraseña
-func (s *AuthService) ChangePassword(userID uint, req *dto.ChangePasswordRequest) error {
+// Cambiar contraseña y cerrar las demás sesiones del usuario
+func (s *AuthService) ChangePassword(userID, currentSessionID uint, req *dto.ChangePasswordRequest) error {
 	// Buscar usuario
 	user, err := s.userDAO.FindByID(userID)
 	if err != nil {
-		return errors.New("user not found")
+		return apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
 	}
 
 	// Verificar contraseña actual
 	if !utils.CheckPassword(user.Password, req.OldPassword) {
-		return errors.New("current password is incorrect")
+		return apperrors.Validation("incorrect_password", "Current password is incorrect")
 	}
 
 	// Hash nueva contraseña
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
-		return errors.New("could not hash password")
+		return apperrors.Internal(err)
 	}
 
 	// Actualizar contraseña
 	user.Password = hashedPassword
-	return s.userDAO.Update(user)
+	if err := s.userDAO.Update(user); err != nil {
+		return err
+	}
+
+	_, err = revokeOtherSessions(s.sessionDAO, s.bus, userID, currentSessionID, SessionsRevokedPasswordChanged)
+	return err
 }
 
-// Validar token y obtener usuario
-func (s *AuthService) ValidateTokenAndGetUser(tokenString string) (*models.User, error) {
+// Validar token y obtener el usuario y su sesión
+func (s *AuthService) ValidateTokenAndGetUser(tokenString string) (*models.User, *models.Session, error) {
 	// Limpiar token
 	tokenString = strings.Replace(tokenString, "Bearer ", "", 1)
 
 	// Validar token
 	claims, err := utils.ValidateToken(tokenString)
 	if err != nil {
-		return nil, errors.New("invalid token")
+		return nil, nil, apperrors.Unauthorized("invalid_token", "Invalid or expired token")
+	}
+
+	// La sesión debe seguir vigente; los tokens sin sesión ya no se aceptan
+	now := time.Now()
+	session, err := s.sessionDAO.FindByTokenID(claims.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, apperrors.Unauthorized("session_expired", "Session has ended, please log in again")
+		}
+		return nil, nil, apperrors.Internal(err)
+	}
+	if session.UserID != claims.UserID || !session.IsActive(now) {
+		return nil, nil, apperrors.Unauthorized("session_expired", "Session has ended, please log in again")
 	}
 
 	// Buscar usuario
 	user, err := s.userDAO.FindByID(claims.UserID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, apperrors.Unauthorized("invalid_token", "User no longer exists")
+		}
+		return nil, nil, apperrors.Internal(err)
 	}
+	if user.AnonymizedAt != nil {
+		return nil, nil, apperrors.Unauthorized("invalid_token", "User no longer exists")
+	}
+
+	return user, session, nil
+}
 
-	return user, nil
+// Registrar actividad de la sesión. No se llama en las rutas anónimas: last_seen_at
+// junto al created_at de un reporte anónimo delataría a quien lo envió
+func (s *AuthService) TouchSession(session *models.Session) {
+	if err := s.sessionDAO.Touch(session.ID, time.Now()); err != nil {
+		log.Printf("Failed to update session %d activity: %v", session.ID, err)
+	}
 }
 
 // Validar roles
 func (s *AuthService) isValidRole(role string) bool {
-	validRoles := []string{models.RoleAndrei, models.RoleDaemon, models.RoleNetworkAdmin}
+	validRoles := []string{models.RoleAndrei, models.RoleDaemon, models.RoleNetworkAdmin, models.RoleModerator}
 	for _, validRole := range validRoles {
 		if role == validRole {
 			return true
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/dao"
 	"devops-chaos-backend/internal/dto"
 	"devops-chaos-backend/internal/models"
@@ -8,12 +9,15 @@ import (
 	"time"
 )
 
+const dashboardSquadWeeks = 8
+
 type DashboardService struct {
 	userDAO       *dao.UserDAO
 	reportDAO     *dao.ReportDAO
 	punishmentDAO *dao.PunishmentDAO
 	statisticDAO  *dao.StatisticDAO
 	captureDAO    *dao.CaptureDAO
+	squadService  *SquadService
 }
 
 func NewDashboardService() *DashboardService {
@@ -23,6 +27,7 @@ func NewDashboardService() *DashboardService {
 		punishmentDAO: dao.NewPunishmentDAO(),
 		statisticDAO:  dao.NewStatisticDAO(),
 		captureDAO:    dao.NewCaptureDAO(),
+		squadService:  NewSquadService(),
 	}
 }
 
@@ -40,22 +45,12 @@ func (s *DashboardService) GetAndreiDashboard() (*dto.AndreiDashboardResponse, e
 		return nil, err
 	}
 
-	reportItems := make([]dto.ReportListItem, len(recentReports))
-	for i, report := range recentReports {
-		authorName := ""
-		if report.Author != nil {
-			authorName = report.Author.Username
-		}
+	reportItems := s.convertToReportItems(recentReports)
 
-		reportItems[i] = dto.ReportListItem{
-			ID:        report.ID,
-			Title:     report.Title,
-			Type:      report.Type,
-			Status:    report.Status,
-			AuthorID:  report.AuthorID,
-			Author:    authorName,
-			CreatedAt: report.CreatedAt,
-		}
+	// Reportes anónimos críticos sin resolver, siempre arriba
+	criticalReports, err := s.reportDAO.FindOpenBySeverity(models.ReportTypeAnonymous, models.ReportSeverityCritical, 10)
+	if err != nil {
+		return nil, err
 	}
 
 	// Top daemons
@@ -74,6 +69,12 @@ func (s *DashboardService) GetAndreiDashboard() (*dto.AndreiDashboardResponse, e
 		}
 	}
 
+	// Comparativa de escuadrones en las últimas semanas
+	squadTrend, err := s.squadService.GetWeeklyPerformance(dashboardSquadWeeks)
+	if err != nil {
+		return nil, err
+	}
+
 	// Actividad reciente (simplificada)
 	recentActivity := s.generateRecentActivity()
 
@@ -85,25 +86,18 @@ func (s *DashboardService) GetAndreiDashboard() (*dto.AndreiDashboardResponse, e
 
 	captureDetailItems := make([]dto.CaptureDetailItem, len(allCaptures))
 	for i, capture := range allCaptures {
-		captureDetailItems[i] = dto.CaptureDetailItem{
-			ID:          capture.ID,
-			DaemonName:  capture.Daemon.Username,
-			TargetName:  capture.Target.Username,
-			CaptureDate: capture.CaptureDate,
-			Status:      capture.Status,
-			Points:      capture.Points,
-			Difficulty:  capture.Difficulty,
-			Method:      capture.Method,
-		}
+		captureDetailItems[i] = convertToCaptureDetailItem(&capture)
 	}
 
 	response := &dto.AndreiDashboardResponse{
-		WelcomeMessage: "Welcome back, Andrei. The chaos continues...",
-		SystemStats:    *systemStats,
-		RecentReports:  reportItems,
-		TopDaemons:     topPerformers,
-		RecentActivity: recentActivity,
-		AllCaptures:    captureDetailItems,
+		WelcomeMessage:  "Welcome back, Andrei. The chaos continues...",
+		SystemStats:     *systemStats,
+		CriticalReports: s.convertToReportItems(criticalReports),
+		RecentReports:   reportItems,
+		TopDaemons:      topPerformers,
+		SquadTrend:      squadTrend,
+		RecentActivity:  recentActivity,
+		AllCaptures:     captureDetailItems,
 	}
 
 	return response, nil
@@ -114,7 +108,7 @@ func (s *DashboardService) GetDaemonDashboard(userID uint) (*dto.DaemonDashboard
 	// Estadísticas del usuario
 	userStats, err := s.statisticDAO.FindByUserIDWithUser(userID)
 	if err != nil {
-		return nil, err
+		return nil, apperrors.NotFoundOrInternal(err, "statistics_not_found", "Statistics not found")
 	}
 
 	daemonStats := dto.DaemonStats{
@@ -233,7 +227,12 @@ func (s *DashboardService) getSystemStats() (*dto.SystemStats, error) {
 		return nil, err
 	}
 
-	totalReports, err := s.reportDAO.CountByStatus("")
+	totalReports, err := s.reportDAO.CountReports(dao.ReportCountFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	criticalReports, err := s.reportDAO.CountOpenBySeverity(models.ReportTypeAnonymous, models.ReportSeverityCritical)
 	if err != nil {
 		return nil, err
 	}
@@ -246,9 +245,33 @@ func (s *DashboardService) getSystemStats() (*dto.SystemStats, error) {
 		PunishedDaemons: int(punishedDaemons),
 		PendingReports:  int(pendingReports),
 		TotalReports:    int(totalReports),
+		CriticalReports: int(criticalReports),
 	}, nil
 }
 
+func (s *DashboardService) convertToReportItems(reports []models.Report) []dto.ReportListItem {
+	reportItems := make([]dto.ReportListItem, len(reports))
+	for i, report := range reports {
+		authorName := ""
+		if report.Author != nil {
+			authorName = report.Author.Username
+		}
+
+		reportItems[i] = dto.ReportListItem{
+			ID:        report.ID,
+			Title:     report.Title,
+			Type:      report.Type,
+			Status:    report.Status,
+			Severity:  report.Severity,
+			Location:  report.Location,
+			AuthorID:  report.AuthorID,
+			Author:    authorName,
+			CreatedAt: report.CreatedAt,
+		}
+	}
+	return reportItems
+}
+
 func (s *DashboardService) generateRecentActivity() []dto.ActivityItem {
 	// En una implementación real, esto vendría de la base de datos
 	return []dto.ActivityItem{
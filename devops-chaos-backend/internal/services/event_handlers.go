@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/realtime"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const streamLeaderboardSize = 10
+
+// Suscriptores del bus; cada uno tiene su propia fila en el outbox
+const (
+	subscriberStatistics    = "statistics"
+	subscriberPoints        = "points"
+	subscriberSquads        = "squads"
+	subscriberNotifications = "notifications"
+	subscriberRealtime      = "realtime"
+	subscriberWebhooks      = "webhooks"
+	subscriberAudit         = "audit"
+)
+
+type eventHandlers struct {
+	bus           *events.Bus
+	hub           *realtime.Hub
+	statisticDAO  *dao.StatisticDAO
+	squadDAO      *dao.SquadDAO
+	punishmentDAO *dao.PunishmentDAO
+	teamDAO       *dao.TeamDAO
+	auditDAO      *dao.AuditDAO
+	notifier      *NotificationService
+	webhooks      *WebhookService
+}
+
+// RegisterEventHandlers suscribe las reacciones a los eventos de dominio. Una
+// reacción nueva se agrega aquí, sin tocar el servicio que origina el evento
+func RegisterEventHandlers(bus *events.Bus, hub *realtime.Hub) {
+	h := &eventHandlers{
+		bus:           bus,
+		hub:           hub,
+		statisticDAO:  dao.NewStatisticDAO(),
+		squadDAO:      dao.NewSquadDAO(),
+		punishmentDAO: dao.NewPunishmentDAO(),
+		teamDAO:       dao.NewTeamDAO(),
+		auditDAO:      dao.NewAuditDAO(),
+		notifier:      NewNotificationService(hub),
+		webhooks:      NewWebhookService(),
+	}
+
+	// Contadores del daemon; se aplican una vez por evento
+	events.Subscribe(bus, subscriberStatistics, func(ctx context.Context, e events.CaptureCreated) error {
+		return h.statisticDAO.IncrementCaptures(e.DaemonID, subscriberStatistics, events.EventID(ctx))
+	})
+	events.Subscribe(bus, subscriberStatistics, func(ctx context.Context, e events.ReportSubmitted) error {
+		if e.AuthorID == nil {
+			return nil
+		}
+		return h.statisticDAO.IncrementReports(*e.AuthorID, subscriberStatistics, events.EventID(ctx))
+	})
+
+	// Puntos; cada suma emite PointsAwarded y se aplica una vez por evento
+	events.Subscribe(bus, subscriberPoints, func(ctx context.Context, e events.CaptureCreated) error {
+		return h.awardPoints(ctx, e.DaemonID, e.Points, e.EventName())
+	})
+	events.Subscribe(bus, subscriberPoints, func(ctx context.Context, e events.ReportApproved) error {
+		if e.AuthorID == nil {
+			return nil
+		}
+		return h.awardPoints(ctx, *e.AuthorID, approvedReportPoints, e.EventName())
+	})
+	events.Subscribe(bus, subscriberPoints, func(ctx context.Context, e events.PunishmentAssigned) error {
+		if e.Type != models.PunishmentTypeReward {
+			return nil
+		}
+		return h.awardPoints(ctx, e.TargetID, rewardPoints, e.EventName())
+	})
+
+	// Puntaje de escuadrón; RecordScore ignora repetidos
+	events.Subscribe(bus, subscriberSquads, func(ctx context.Context, e events.CaptureCreated) error {
+		return h.squadDAO.RecordScore(e.DaemonID, models.SquadScoreSourceCapture, e.CaptureID, e.Points)
+	})
+	events.Subscribe(bus, subscriberSquads, func(ctx context.Context, e events.ReportApproved) error {
+		if e.AuthorID == nil {
+			return nil
+		}
+		return h.squadDAO.RecordScore(*e.AuthorID, models.SquadScoreSourceReport, e.ReportID, approvedReportPoints)
+	})
+
+	// Bandeja de notificaciones
+	events.Subscribe(bus, subscriberNotifications, func(ctx context.Context, e events.CaptureCreated) error {
+		message := fmt.Sprintf("A daemon captured you (%s difficulty).", e.Difficulty)
+
+		// Solo un equipo puede rescatarlo
+		_, err := h.teamDAO.FindMembership(e.TargetID)
+		if err == nil {
+			message += " Your team can mount a rescue."
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		return h.notifier.Notify([]uint{e.TargetID}, models.NotificationCaptured, "You have been captured", message)
+	})
+	events.Subscribe(bus, subscriberNotifications, func(ctx context.Context, e events.MemberRescued) error {
+		return h.notifier.Notify([]uint{e.RescuedID}, models.NotificationReleased,
+			"You have been rescued",
+			fmt.Sprintf("Your team %s spent %d protection points to break you out", e.TeamName, e.PointsSpent))
+	})
+	events.Subscribe(bus, subscriberNotifications, func(ctx context.Context, e events.CaptureReleased) error {
+		return h.notifier.Notify([]uint{e.TargetID}, models.NotificationReleased,
+			"You have been released",
+			"Andrei released you from a capture")
+	})
+	events.Subscribe(bus, subscriberNotifications, func(ctx context.Context, e events.PunishmentAssigned) error {
+		return h.notifier.Notify([]uint{e.TargetID}, models.NotificationPunishmentAssigned,
+			fmt.Sprintf("New %s from %s", e.Type, e.AssignerName),
+			e.Description)
+	})
+	events.Subscribe(bus, subscriberNotifications, func(ctx context.Context, e events.ReportApproved) error {
+		if e.AuthorID == nil {
+			return nil
+		}
+		return h.notifier.Notify([]uint{*e.AuthorID}, models.NotificationReportApproved,
+			"Your report was approved",
+			fmt.Sprintf("%q was approved (+%d points)", e.Title, approvedReportPoints))
+	})
+	events.Subscribe(bus, subscriberNotifications, func(ctx context.Context, e events.ReportRejected) error {
+		if e.AuthorID == nil {
+			return nil
+		}
+		return h.notifier.Notify([]uint{*e.AuthorID}, models.NotificationReportRejected,
+			"Your report was rejected",
+			fmt.Sprintf("%q was rejected: %s", e.Title, e.Reason))
+	})
+	events.Subscribe(bus, subscriberNotifications, func(ctx context.Context, e events.PointsAwarded) error {
+		return h.notifier.NotifyOvertaken(h.statisticDAO, e.UserID, e.Before, e.After)
+	})
+
+	// Eventos en vivo de /api/stream
+	events.Subscribe(bus, subscriberRealtime, func(ctx context.Context, e events.CaptureCreated) error {
+		h.hub.Publish(realtime.Event{
+			Type:     realtime.EventCaptureCreated,
+			Data:     convertToCaptureEvent(e),
+			Audience: realtime.ToRoles(models.RoleAndrei, models.RoleDaemon),
+		})
+		return nil
+	})
+	events.Subscribe(bus, subscriberRealtime, func(ctx context.Context, e events.ReportSubmitted) error {
+		h.hub.Publish(realtime.Event{
+			Type:     realtime.EventReportSubmitted,
+			Data:     convertToReportEvent(e.ReportInfo),
+			Audience: realtime.ToRoles(models.RoleAndrei),
+		})
+		return nil
+	})
+	events.Subscribe(bus, subscriberRealtime, func(ctx context.Context, e events.ReportApproved) error {
+		audience := realtime.ToRoles(models.RoleAndrei)
+		if e.AuthorID != nil {
+			audience.UserIDs = []uint{*e.AuthorID}
+		}
+		h.hub.Publish(realtime.Event{
+			Type:     realtime.EventReportApproved,
+			Data:     convertToReportEvent(e.ReportInfo),
+			Audience: audience,
+		})
+		return nil
+	})
+	events.Subscribe(bus, subscriberRealtime, func(ctx context.Context, e events.PunishmentAssigned) error {
+		punishment, err := h.punishmentDAO.FindByID(e.PunishmentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil // Ya se eliminó
+		}
+		if err != nil {
+			return err
+		}
+		h.hub.Publish(realtime.Event{
+			Type:     realtime.EventPunishmentAssigned,
+			Data:     convertToPunishmentResponse(punishment),
+			Audience: realtime.ToUsers(e.TargetID),
+		})
+		return nil
+	})
+	events.Subscribe(bus, subscriberRealtime, func(ctx context.Context, e events.PointsAwarded) error {
+		return h.publishLeaderboard()
+	})
+	events.Subscribe(bus, subscriberRealtime, func(ctx context.Context, e events.StatisticsUpdated) error {
+		return h.publishLeaderboard()
+	})
+
+	// Webhooks salientes
+	events.Subscribe(bus, subscriberWebhooks, func(ctx context.Context, e events.CaptureCreated) error {
+		return h.webhooks.Enqueue(events.EventID(ctx), models.WebhookEventCaptureCreated, convertToCaptureEvent(e))
+	})
+	events.Subscribe(bus, subscriberWebhooks, func(ctx context.Context, e events.PunishmentAssigned) error {
+		return h.webhooks.Enqueue(events.EventID(ctx), models.WebhookEventPunishmentAssigned, dto.PunishmentListItem{
+			ID:           e.PunishmentID,
+			TargetName:   e.TargetName,
+			AssignerName: e.AssignerName,
+			Type:         e.Type,
+			Status:       e.Status,
+			CreatedAt:    e.CreatedAt,
+			ExpiresAt:    e.ExpiresAt,
+		})
+	})
+	events.Subscribe(bus, subscriberWebhooks, func(ctx context.Context, e events.ReportApproved) error {
+		return h.webhooks.Enqueue(events.EventID(ctx), models.WebhookEventReportApproved, convertToReportEvent(e.ReportInfo))
+	})
+	events.Subscribe(bus, subscriberWebhooks, func(ctx context.Context, e events.ReportRejected) error {
+		return h.webhooks.Enqueue(events.EventID(ctx), models.WebhookEventReportRejected, convertToReportEvent(e.ReportInfo))
+	})
+
+	// Registro de auditoría
+	subscribeAudit(h, func(e events.CaptureCreated) (*uint, *uint, string) {
+		return &e.DaemonID, &e.TargetID,
+			fmt.Sprintf("%s captured %s (%s, %d points)", e.DaemonName, e.TargetName, e.Difficulty, e.Points)
+	})
+	subscribeAudit(h, func(e events.MemberRescued) (*uint, *uint, string) {
+		return &e.InitiatedByID, &e.RescuedID,
+			fmt.Sprintf("Team %s rescued a member for %d protection points", e.TeamName, e.PointsSpent)
+	})
+	subscribeAudit(h, func(e events.CaptureReleased) (*uint, *uint, string) {
+		return &e.ReleasedByID, &e.TargetID, fmt.Sprintf("Capture #%d released", e.CaptureID)
+	})
+	subscribeAudit(h, func(e events.ReportSubmitted) (*uint, *uint, string) {
+		return e.AuthorID, nil, fmt.Sprintf("Report #%d submitted (%s)", e.ReportID, e.Type)
+	})
+	subscribeAudit(h, func(e events.ReportApproved) (*uint, *uint, string) {
+		return &e.ReviewerID, e.AuthorID, fmt.Sprintf("Report #%d approved", e.ReportID)
+	})
+	subscribeAudit(h, func(e events.ReportRejected) (*uint, *uint, string) {
+		return &e.ReviewerID, e.AuthorID, fmt.Sprintf("Report #%d rejected: %s", e.ReportID, e.Reason)
+	})
+	subscribeAudit(h, func(e events.PunishmentAssigned) (*uint, *uint, string) {
+		return &e.AssignerID, &e.TargetID,
+			fmt.Sprintf("%s assigned %s to %s", e.AssignerName, e.Type, e.TargetName)
+	})
+	subscribeAudit(h, func(e events.PointsAwarded) (*uint, *uint, string) {
+		return nil, &e.UserID, fmt.Sprintf("%+d points (%s), %d -> %d", e.Points, e.Reason, e.Before, e.After)
+	})
+	subscribeAudit(h, func(e events.UsersImported) (*uint, *uint, string) {
+		return &e.ImportedByID, nil, fmt.Sprintf("Imported %d users from %s", len(e.UserIDs), e.Format)
+	})
+	subscribeAudit(h, func(e events.InviteIssued) (*uint, *uint, string) {
+		verb := "Issued"
+		if e.Resent {
+			verb = "Resent"
+		}
+		return &e.InvitedByID, nil, fmt.Sprintf("%s invite #%d for a %s via %s", verb, e.InviteID, e.Role, e.Channel)
+	})
+	subscribeAudit(h, func(e events.InviteAccepted) (*uint, *uint, string) {
+		return &e.UserID, &e.UserID, fmt.Sprintf("%s joined as %s with invite #%d", e.Username, e.Role, e.InviteID)
+	})
+	subscribeAudit(h, func(e events.InviteRevoked) (*uint, *uint, string) {
+		return &e.RevokedByID, nil, fmt.Sprintf("Revoked invite #%d", e.InviteID)
+	})
+	subscribeAudit(h, func(e events.UserUpdated) (*uint, *uint, string) {
+		return &e.UpdatedByID, &e.UserID, fmt.Sprintf("Updated user #%d: %s", e.UserID, describeUserChanges(e))
+	})
+	subscribeAudit(h, func(e events.UserDeleted) (*uint, *uint, string) {
+		return &e.DeletedByID, &e.UserID, fmt.Sprintf("Deleted %s %s", e.Role, e.Username)
+	})
+	subscribeAudit(h, func(e events.UserAnonymized) (*uint, *uint, string) {
+		return &e.AnonymizedByID, &e.UserID, fmt.Sprintf("Anonymized %s #%d as %s", e.Role, e.UserID, e.Pseudonym)
+	})
+	subscribeAudit(h, func(e events.ProfileUpdated) (*uint, *uint, string) {
+		return &e.UserID, &e.UserID, fmt.Sprintf("Updated own profile: %s", strings.Join(e.Fields, ", "))
+	})
+	subscribeAudit(h, func(e events.EmailChanged) (*uint, *uint, string) {
+		return &e.UserID, &e.UserID, "Confirmed a new email address"
+	})
+	subscribeAudit(h, func(e events.SessionsRevoked) (*uint, *uint, string) {
+		return &e.UserID, &e.UserID, fmt.Sprintf("Closed %d session(s) (%s)", e.Count, e.Reason)
+	})
+	subscribeAudit(h, func(e events.TrashRestored) (*uint, *uint, string) {
+		return &e.RestoredByID, nil, fmt.Sprintf("Restored %s #%d (%s)", e.Entity, e.EntityID, e.Label)
+	})
+	subscribeAudit(h, func(e events.TrashPurged) (*uint, *uint, string) {
+		if e.PurgedByID == nil {
+			return nil, nil, fmt.Sprintf("Retention purged %s #%d (%s)", e.Entity, e.EntityID, e.Label)
+		}
+		return e.PurgedByID, nil, fmt.Sprintf("Purged %s #%d (%s)", e.Entity, e.EntityID, e.Label)
+	})
+	subscribeAudit(h, func(e events.StatisticsUpdated) (*uint, *uint, string) {
+		return nil, &e.UserID, fmt.Sprintf("Statistics set to %d captures, %d reports, %d points",
+			e.CapturesCount, e.ReportsCount, e.Points)
+	})
+}
+
+// subscribeAudit registra el evento con quién lo hizo y a quién afecta
+func subscribeAudit[E events.Event](h *eventHandlers, describe func(E) (actorID, subjectID *uint, summary string)) {
+	events.Subscribe(h.bus, subscriberAudit, func(ctx context.Context, e E) error {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		actorID, subjectID, summary := describe(e)
+		return h.auditDAO.Create(&models.AuditLog{
+			EventID:   events.EventID(ctx),
+			EventName: e.EventName(),
+			ActorID:   actorID,
+			SubjectID: subjectID,
+			Summary:   summary,
+			Payload:   string(payload),
+		})
+	})
+}
+
+// awardPoints suma puntos y registra PointsAwarded en la misma transacción; una
+// entrega repetida del mismo evento no vuelve a sumar
+func (h *eventHandlers) awardPoints(ctx context.Context, userID uint, points int, reason string) error {
+	_, applied, err := h.statisticDAO.AddPointsReturning(userID, points, subscriberPoints, events.EventID(ctx), func(tx *gorm.DB, total int) error {
+		return h.bus.Record(tx, events.PointsAwarded{
+			UserID: userID,
+			Points: points,
+			Before: total - points,
+			After:  total,
+			Reason: reason,
+		})
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("No statistics for user %d, skipping %d points (%s)", userID, points, reason)
+		return nil // Reintentar no lo va a resolver
+	}
+	if err == nil && applied {
+		h.bus.Notify()
+	}
+	return err
+}
+
+// Publicar el leaderboard actual a Andrei y los daemons
+func (h *eventHandlers) publishLeaderboard() error {
+	topDaemons, err := h.statisticDAO.FindTopDaemons(streamLeaderboardSize)
+	if err != nil {
+		return err
+	}
+
+	h.hub.Publish(realtime.Event{
+		Type:     realtime.EventLeaderboardUpdated,
+		Data:     convertToRankingItems(topDaemons),
+		Audience: realtime.ToRoles(models.RoleAndrei, models.RoleDaemon),
+	})
+	return nil
+}
+
+// reportInfo arma los datos de un evento de reporte; los anónimos nunca llevan autor
+func reportInfo(report *models.Report) events.ReportInfo {
+	info := events.ReportInfo{
+		ReportID: report.ID,
+		Title:    report.Title,
+		Type:     report.Type,
+		Severity: report.Severity,
+		Status:   report.Status,
+	}
+	if report.Type != models.ReportTypeAnonymous {
+		info.AuthorID = report.AuthorID
+		if report.Author != nil {
+			info.AuthorName = report.Author.Username
+		}
+	}
+	return info
+}
+
+func convertToCaptureEvent(e events.CaptureCreated) dto.CaptureEvent {
+	return dto.CaptureEvent{
+		CaptureID:   e.CaptureID,
+		DaemonName:  e.DaemonName,
+		TargetName:  e.TargetName,
+		Difficulty:  e.Difficulty,
+		Points:      e.Points,
+		CaptureDate: e.CapturedAt,
+	}
+}
+
+func convertToReportEvent(info events.ReportInfo) dto.ReportEvent {
+	return dto.ReportEvent{
+		ReportID: info.ReportID,
+		Title:    info.Title,
+		Type:     info.Type,
+		Severity: info.Severity,
+		Status:   info.Status,
+		Author:   info.AuthorName,
+	}
+}
+
+// describeUserChanges resume los campos cambiados en un orden fijo
+func describeUserChanges(e events.UserUpdated) string {
+	var parts []string
+	for _, field := range []string{"username", "role", "status"} {
+		if change, ok := e.Changes[field]; ok {
+			parts = append(parts, fmt.Sprintf("%s %s → %s", field, change.From, change.To))
+		}
+	}
+	if e.EmailChanged {
+		parts = append(parts, "email")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,411 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/invites"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/utils"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Prefijo de los tokens de invitación, para reconocerlos si se filtran
+const inviteTokenPrefix = "inv_"
+
+// Tiempo máximo para entregar una invitación por un canal externo
+const inviteDeliveryTimeout = 15 * time.Second
+
+type InviteService struct {
+	inviteDAO *dao.InviteDAO
+	userDAO   *dao.UserDAO
+	config    *config.InviteConfig
+	channels  map[string]invites.Channel
+	bus       *events.Bus
+}
+
+func NewInviteService(cfg *config.InviteConfig, channels []invites.Channel, bus *events.Bus) *InviteService {
+	byName := make(map[string]invites.Channel, len(channels))
+	for _, channel := range channels {
+		byName[channel.Name()] = channel
+	}
+	return &InviteService{
+		inviteDAO: dao.NewInviteDAO(),
+		userDAO:   dao.NewUserDAO(),
+		config:    cfg,
+		channels:  byName,
+		bus:       bus,
+	}
+}
+
+// Invitar a alguien con un rol. La invitación se entrega antes de guardarla:
+// si el canal falla no queda nada pendiente
+func (s *InviteService) CreateInvite(req *dto.CreateInviteRequest, currentUserID uint) (*dto.InviteResponse, error) {
+	role := strings.TrimSpace(req.Role)
+	if !models.IsValidRole(role) {
+		return nil, apperrors.Validation("invalid_role",
+			fmt.Sprintf("Role must be one of: %s", strings.Join(models.Roles, ", ")))
+	}
+
+	channel, err := s.channel(req.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if email == "" && channel.RequiresEmail() {
+		return nil, apperrors.Validation("email_required",
+			fmt.Sprintf("Channel '%s' requires an email", channel.Name()))
+	}
+	if email != "" {
+		if err := s.checkEmailAvailable(email); err != nil {
+			return nil, err
+		}
+	}
+
+	inviter, err := s.userDAO.FindByID(currentUserID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+
+	now := time.Now()
+	invite := &models.Invite{
+		Role:        role,
+		Email:       email,
+		Channel:     channel.Name(),
+		Status:      models.InviteStatusPending,
+		InvitedByID: currentUserID,
+		ExpiresAt:   now.Add(s.config.TTL),
+		SentCount:   1,
+		LastSentAt:  &now,
+	}
+
+	link, err := s.issueToken(invite, channel, inviter.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.inviteDAO.Create(invite, s.bus.Writer(func() []events.Event {
+		return []events.Event{inviteIssued(invite, false)}
+	}))
+	if err != nil {
+		return nil, err
+	}
+	s.bus.Notify()
+
+	response := convertToInviteResponse(invite, now)
+	response.Link = link
+	return response, nil
+}
+
+// Listar invitaciones; status acepta "pending", "expired", "accepted" o "revoked"
+func (s *InviteService) GetInvites(params dto.ListParams) (*dto.PaginatedResponse, error) {
+	now := time.Now()
+	q := dao.NewListQuery(params)
+
+	if status, ok := params.Filters["status"]; ok {
+		switch status {
+		case models.InviteStatusPending, models.InviteStatusExpired,
+			models.InviteStatusAccepted, models.InviteStatusRevoked:
+			q.Scope(dao.InvitesWithStatus(status, now))
+		default:
+			return nil, apperrors.Validation("invalid_status",
+				"Status must be one of: pending, expired, accepted, revoked")
+		}
+		delete(q.Filters, "status")
+	}
+
+	list, page, err := s.inviteDAO.List(q)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.InviteResponse, len(list))
+	for i := range list {
+		items[i] = *convertToInviteResponse(&list[i], now)
+	}
+	return page.ToResponse("Invites retrieved successfully", items), nil
+}
+
+// Reenviar una invitación pendiente (también si expiró): genera un token
+// nuevo, invalida el anterior y renueva la expiración
+func (s *InviteService) ResendInvite(id uint, req *dto.ResendInviteRequest, currentUserID uint) (*dto.InviteResponse, error) {
+	invite, err := s.inviteDAO.FindByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "invite_not_found", "Invite not found")
+	}
+	if invite.Status != models.InviteStatusPending {
+		return nil, apperrors.Conflict("invite_not_pending",
+			fmt.Sprintf("Invite is already %s", invite.Status))
+	}
+
+	channelName := invite.Channel
+	if req.Channel != "" {
+		channelName = req.Channel
+	}
+	channel, err := s.channel(channelName)
+	if err != nil {
+		return nil, err
+	}
+	if invite.Email == "" && channel.RequiresEmail() {
+		return nil, apperrors.Validation("email_required",
+			fmt.Sprintf("Channel '%s' requires an email", channel.Name()))
+	}
+
+	inviter, err := s.userDAO.FindByID(currentUserID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+
+	now := time.Now()
+	invite.Channel = channel.Name()
+	invite.ExpiresAt = now.Add(s.config.TTL)
+	invite.SentCount++
+	invite.LastSentAt = &now
+
+	link, err := s.issueToken(invite, channel, inviter.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.inviteDAO.SaveResend(invite, s.bus.Writer(func() []events.Event {
+		return []events.Event{inviteIssued(invite, true)}
+	}))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.Conflict("invite_not_pending", "Invite is no longer pending")
+		}
+		return nil, err
+	}
+	s.bus.Notify()
+
+	response := convertToInviteResponse(invite, now)
+	response.Link = link
+	return response, nil
+}
+
+// Revocar una invitación pendiente
+func (s *InviteService) RevokeInvite(id uint, currentUserID uint) error {
+	invite, err := s.inviteDAO.FindByID(id)
+	if err != nil {
+		return apperrors.NotFoundOrInternal(err, "invite_not_found", "Invite not found")
+	}
+	if invite.Status != models.InviteStatusPending {
+		return apperrors.Conflict("invite_not_pending", fmt.Sprintf("Invite is already %s", invite.Status))
+	}
+
+	err = s.inviteDAO.Revoke(id, time.Now(), s.bus.Writer(func() []events.Event {
+		return []events.Event{events.InviteRevoked{InviteID: id, RevokedByID: currentUserID}}
+	}))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.Conflict("invite_not_pending", "Invite is no longer pending")
+		}
+		return err
+	}
+	s.bus.Notify()
+	return nil
+}
+
+// Aceptar una invitación: quien la recibe elige usuario y contraseña y queda
+// con la sesión iniciada
+func (s *InviteService) AcceptInvite(req *dto.AcceptInviteRequest, client ClientInfo) (*dto.LoginResponse, error) {
+	invite, err := s.inviteDAO.FindByTokenHash(hashToken(req.Token))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.Internal(err)
+	}
+	// Mismo error para token inexistente, usado, revocado o vencido
+	if err != nil || invite.EffectiveStatus(time.Now()) != models.InviteStatusPending {
+		return nil, apperrors.Validation("invalid_invite", "Invite is invalid or has expired")
+	}
+
+	username := strings.TrimSpace(req.Username)
+	if username == "" {
+		return nil, apperrors.Validation("username_required", "Username is required")
+	}
+
+	// Si Andrei indicó el email, es el que se usa
+	email := invite.Email
+	if email == "" {
+		email = strings.TrimSpace(req.Email)
+		if email == "" {
+			return nil, apperrors.Validation("email_required", "Email is required")
+		}
+	}
+
+	takenUsernames, takenEmails, err := s.userDAO.FindTakenIdentities([]string{username}, []string{email})
+	if err != nil {
+		return nil, err
+	}
+	for _, taken := range takenUsernames {
+		if taken == username {
+			return nil, apperrors.Conflict("username_taken", "Username already exists")
+		}
+	}
+	for _, taken := range takenEmails {
+		if taken == email {
+			return nil, apperrors.Conflict("email_taken", "Email already exists")
+		}
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, apperrors.Internal(err)
+	}
+
+	user := &models.User{
+		Username: username,
+		Email:    email,
+		Password: hashedPassword,
+		Role:     invite.Role,
+		Status:   models.StatusActive,
+	}
+
+	err = s.inviteDAO.Accept(invite, user, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.InviteAccepted{
+			InviteID:    invite.ID,
+			InvitedByID: invite.InvitedByID,
+			UserID:      user.ID,
+			Username:    user.Username,
+			Role:        user.Role,
+		}}
+	}))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.Validation("invalid_invite", "Invite is invalid or has expired")
+		}
+		return nil, err
+	}
+	s.bus.Notify()
+
+	return newLoginResponse(user, client)
+}
+
+// channel busca un canal registrado por nombre
+func (s *InviteService) channel(name string) (invites.Channel, error) {
+	name = strings.TrimSpace(name)
+	if channel, ok := s.channels[name]; ok {
+		return channel, nil
+	}
+
+	available := make([]string, 0, len(s.channels))
+	for channelName := range s.channels {
+		available = append(available, channelName)
+	}
+	sort.Strings(available)
+	return nil, apperrors.Validation("invalid_channel",
+		fmt.Sprintf("Channel must be one of: %s", strings.Join(available, ", ")))
+}
+
+// checkEmailAvailable rechaza emails de usuarios existentes (incluidos los
+// eliminados) o con otra invitación vigente
+func (s *InviteService) checkEmailAvailable(email string) error {
+	_, takenEmails, err := s.userDAO.FindTakenIdentities(nil, []string{email})
+	if err != nil {
+		return err
+	}
+	for _, taken := range takenEmails {
+		if taken == email {
+			return apperrors.Conflict("email_taken", "Email already exists")
+		}
+	}
+
+	pending, err := s.inviteDAO.ExistsPendingForEmail(email, time.Now())
+	if err != nil {
+		return err
+	}
+	if pending {
+		return apperrors.Conflict("invite_pending", "There is already a pending invite for this email")
+	}
+	return nil
+}
+
+// issueToken genera un token nuevo para invite, guarda su hash y lo entrega
+// por channel. Devuelve el enlace solo si el canal pide mostrárselo a Andrei
+func (s *InviteService) issueToken(invite *models.Invite, channel invites.Channel, invitedBy string) (string, error) {
+	token, err := newInviteToken()
+	if err != nil {
+		return "", apperrors.Internal(err)
+	}
+	invite.TokenHash = hashToken(token)
+	link := s.config.AcceptURL + "#token=" + token
+
+	ctx, cancel := context.WithTimeout(context.Background(), inviteDeliveryTimeout)
+	defer cancel()
+
+	showLink, err := channel.Deliver(ctx, invites.Invitation{
+		Email:     invite.Email,
+		Role:      invite.Role,
+		Link:      link,
+		InvitedBy: invitedBy,
+		ExpiresAt: invite.ExpiresAt,
+	})
+	if err != nil {
+		log.Printf("Failed to deliver invite via %s: %v", channel.Name(), err)
+		return "", apperrors.Unavailable("invite_delivery_failed",
+			fmt.Sprintf("Could not deliver the invite via %s", channel.Name()), err)
+	}
+
+	if !showLink {
+		return "", nil
+	}
+	return link, nil
+}
+
+func inviteIssued(invite *models.Invite, resent bool) events.InviteIssued {
+	return events.InviteIssued{
+		InviteID:    invite.ID,
+		InvitedByID: invite.InvitedByID,
+		Role:        invite.Role,
+		Channel:     invite.Channel,
+		ExpiresAt:   invite.ExpiresAt,
+		Resent:      resent,
+	}
+}
+
+// newInviteToken genera 256 bits aleatorios en base64 apto para URLs
+func newInviteToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return inviteTokenPrefix + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// hashToken es lo que se guarda de los tokens enviados (invitaciones, verificación de email)
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+func convertToInviteResponse(invite *models.Invite, now time.Time) *dto.InviteResponse {
+	return &dto.InviteResponse{
+		ID:             invite.ID,
+		Role:           invite.Role,
+		Email:          invite.Email,
+		Channel:        invite.Channel,
+		Status:         invite.EffectiveStatus(now),
+		InvitedByID:    invite.InvitedByID,
+		ExpiresAt:      invite.ExpiresAt,
+		SentCount:      invite.SentCount,
+		LastSentAt:     invite.LastSentAt,
+		AcceptedAt:     invite.AcceptedAt,
+		AcceptedUserID: invite.AcceptedUserID,
+		RevokedAt:      invite.RevokedAt,
+		CreatedAt:      invite.CreatedAt,
+	}
+}
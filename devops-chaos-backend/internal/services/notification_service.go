@@ -0,0 +1,182 @@
+package services
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/realtime"
+	"fmt"
+)
+
+type NotificationService struct {
+	notificationDAO *dao.NotificationDAO
+	hub             *realtime.Hub
+}
+
+func NewNotificationService(hub *realtime.Hub) *NotificationService {
+	return &NotificationService{
+		notificationDAO: dao.NewNotificationDAO(),
+		hub:             hub,
+	}
+}
+
+// Notify guarda la notificación para cada usuario que no desactivó el tipo y
+// la empuja por /api/stream
+func (s *NotificationService) Notify(userIDs []uint, notificationType, title, body string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	optedOut, err := s.notificationDAO.FindOptedOut(userIDs, notificationType)
+	if err != nil {
+		return err
+	}
+	skip := make(map[uint]bool, len(optedOut))
+	for _, id := range optedOut {
+		skip[id] = true
+	}
+
+	var notifications []models.Notification
+	for _, userID := range userIDs {
+		if skip[userID] {
+			continue
+		}
+		notifications = append(notifications, models.Notification{
+			UserID: userID,
+			Type:   notificationType,
+			Title:  title,
+			Body:   body,
+		})
+	}
+
+	if err := s.notificationDAO.CreateBatch(notifications); err != nil {
+		return err
+	}
+
+	for i := range notifications {
+		s.hub.Publish(realtime.Event{
+			Type:     realtime.EventNotification,
+			Data:     convertToNotificationResponse(&notifications[i]),
+			Audience: realtime.ToUsers(notifications[i].UserID),
+		})
+	}
+	return nil
+}
+
+// NotifyOvertaken avisa a los daemons que quedaron por debajo de quien sumó puntos
+func (s *NotificationService) NotifyOvertaken(statisticDAO *dao.StatisticDAO, userID uint, before, after int) error {
+	overtaken, err := statisticDAO.FindDaemonsBetweenPoints(userID, before, after)
+	if err != nil {
+		return err
+	}
+
+	return s.Notify(overtaken, models.NotificationLeaderboardOvertaken,
+		"You were overtaken on the leaderboard",
+		fmt.Sprintf("Another daemon just passed you with %d points", after))
+}
+
+// Listar la bandeja del usuario
+func (s *NotificationService) GetNotifications(params dto.ListParams, userID uint) (*dto.PaginatedResponse, error) {
+	query := dao.NewListQuery(params).Scope(dao.NotificationsOf(userID))
+
+	notifications, page, err := s.notificationDAO.List(query)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.NotificationResponse, len(notifications))
+	for i := range notifications {
+		items[i] = convertToNotificationResponse(&notifications[i])
+	}
+
+	return page.ToResponse("Notifications retrieved successfully", items), nil
+}
+
+// Cantidad de no leídas
+func (s *NotificationService) GetUnreadCount(userID uint) (*dto.UnreadCountResponse, error) {
+	count, err := s.notificationDAO.CountUnread(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.UnreadCountResponse{Unread: count}, nil
+}
+
+// Marcar una notificación como leída
+func (s *NotificationService) MarkRead(id, userID uint) error {
+	found, err := s.notificationDAO.MarkRead(userID, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return apperrors.NotFound("notification_not_found", "Notification not found")
+	}
+	return nil
+}
+
+// Marcar todas como leídas
+func (s *NotificationService) MarkAllRead(userID uint) error {
+	_, err := s.notificationDAO.MarkAllRead(userID)
+	return err
+}
+
+// Preferencias del usuario para los tipos que aplican a su rol
+func (s *NotificationService) GetPreferences(userID uint, userRole string) ([]dto.NotificationPreferenceItem, error) {
+	saved, err := s.notificationDAO.FindPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[string]bool, len(saved))
+	for _, preference := range saved {
+		enabled[preference.Type] = preference.Enabled
+	}
+
+	types := models.NotificationTypesByRole[userRole]
+	items := make([]dto.NotificationPreferenceItem, len(types))
+	for i, notificationType := range types {
+		value, ok := enabled[notificationType]
+		items[i] = dto.NotificationPreferenceItem{
+			Type:    notificationType,
+			Enabled: !ok || value,
+		}
+	}
+	return items, nil
+}
+
+// Actualizar preferencias; solo se aceptan tipos que aplican al rol
+func (s *NotificationService) UpdatePreferences(req *dto.UpdateNotificationPreferencesRequest, userID uint, userRole string) ([]dto.NotificationPreferenceItem, error) {
+	allowed := make(map[string]bool)
+	for _, notificationType := range models.NotificationTypesByRole[userRole] {
+		allowed[notificationType] = true
+	}
+
+	preferences := make([]models.NotificationPreference, 0, len(req.Preferences))
+	for notificationType, enabled := range req.Preferences {
+		if !allowed[notificationType] {
+			return nil, apperrors.Validation("invalid_notification_type", fmt.Sprintf("Unknown notification type %q for your role", notificationType))
+		}
+		preferences = append(preferences, models.NotificationPreference{
+			UserID:  userID,
+			Type:    notificationType,
+			Enabled: enabled,
+		})
+	}
+
+	if err := s.notificationDAO.SavePreferences(preferences); err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(userID, userRole)
+}
+
+func convertToNotificationResponse(notification *models.Notification) dto.NotificationResponse {
+	return dto.NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Read:      notification.Read,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/storage"
+	"devops-chaos-backend/internal/utils"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Formatos de la exportación de datos personales
+const (
+	PersonalDataFormatJSON = "json"
+	PersonalDataFormatZIP  = "zip"
+)
+
+const (
+	pseudonymPrefix      = "anon-"
+	anonymizedDomain     = "anonymized.invalid" // Dominio reservado, nunca recibe correo
+	pseudonymAttempts    = 5
+	anonymizedPassLength = 32
+)
+
+type PrivacyService struct {
+	userDAO       *dao.UserDAO
+	reportDAO     *dao.ReportDAO
+	reviewDAO     *dao.ReportReviewDAO
+	captureDAO    *dao.CaptureDAO
+	punishmentDAO *dao.PunishmentDAO
+	statisticDAO  *dao.StatisticDAO
+	store         storage.BlobStore
+	bus           *events.Bus
+}
+
+func NewPrivacyService(store storage.BlobStore, bus *events.Bus) *PrivacyService {
+	return &PrivacyService{
+		userDAO:       dao.NewUserDAO(),
+		reportDAO:     dao.NewReportDAO(),
+		reviewDAO:     dao.NewReportReviewDAO(),
+		captureDAO:    dao.NewCaptureDAO(),
+		punishmentDAO: dao.NewPunishmentDAO(),
+		statisticDAO:  dao.NewStatisticDAO(),
+		store:         store,
+		bus:           bus,
+	}
+}
+
+// Exportar los datos personales del usuario como un JSON o como un ZIP con
+// un archivo por sección
+func (s *PrivacyService) ExportPersonalData(userID uint, format string) ([]byte, error) {
+	if format != PersonalDataFormatJSON && format != PersonalDataFormatZIP {
+		return nil, apperrors.Validation("invalid_export_format", "Export format must be json or zip")
+	}
+
+	export, err := s.collectPersonalData(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == PersonalDataFormatJSON {
+		return json.MarshalIndent(export, "", "  ")
+	}
+
+	sections := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"statistics.json", export.Statistics},
+		{"reports.json", export.Reports},
+		{"comments.json", export.Comments},
+		{"captures.json", export.Captures},
+		{"punishments.json", export.Punishments},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, section := range sections {
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     section.name,
+			Method:   zip.Deflate,
+			Modified: export.GeneratedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(section.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// collectPersonalData reúne perfil, estadísticas, reportes, comentarios,
+// capturas (como daemon o como objetivo) y castigos del usuario
+func (s *PrivacyService) collectPersonalData(userID uint) (*dto.PersonalDataExport, error) {
+	user, err := s.userDAO.FindByID(userID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+
+	export := &dto.PersonalDataExport{
+		GeneratedAt: time.Now().UTC(),
+		Profile: dto.PersonalProfile{
+			ID:          user.ID,
+			Username:    user.Username,
+			DisplayName: user.DisplayName,
+			Email:       user.Email,
+			Bio:         user.Bio,
+			Role:        user.Role,
+			Status:      user.Status,
+			CreatedAt:   user.CreatedAt,
+			UpdatedAt:   user.UpdatedAt,
+		},
+	}
+
+	stats, err := s.statisticDAO.FindByUserIDWithUser(userID)
+	switch {
+	case err == nil:
+		export.Statistics = &dto.StatisticResponse{
+			ID:            stats.ID,
+			UserID:        stats.UserID,
+			Username:      stats.User.Username,
+			CapturesCount: stats.CapturesCount,
+			ReportsCount:  stats.ReportsCount,
+			Ranking:       stats.Ranking,
+			Points:        stats.Points,
+			UpdatedAt:     stats.UpdatedAt,
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	reports, err := s.reportDAO.FindAllByAuthor(userID)
+	if err != nil {
+		return nil, err
+	}
+	export.Reports = make([]dto.PersonalReport, len(reports))
+	for i, report := range reports {
+		export.Reports[i] = dto.PersonalReport{
+			ID:              report.ID,
+			Title:           report.Title,
+			Description:     report.Description,
+			Type:            report.Type,
+			Status:          report.Status,
+			Severity:        report.Severity,
+			Location:        report.Location,
+			RejectionReason: report.RejectionReason,
+			CreatedAt:       report.CreatedAt,
+			UpdatedAt:       report.UpdatedAt,
+		}
+		if report.Assignee != nil {
+			export.Reports[i].Assignee = report.Assignee.Username
+		}
+	}
+
+	comments, err := s.reviewDAO.FindCommentsByAuthor(userID)
+	if err != nil {
+		return nil, err
+	}
+	export.Comments = make([]dto.PersonalComment, len(comments))
+	for i, comment := range comments {
+		export.Comments[i] = dto.PersonalComment{
+			ID:        comment.ID,
+			ReportID:  comment.ReportID,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+		}
+	}
+
+	captured, err := s.captureDAO.FindByDaemonID(userID)
+	if err != nil {
+		return nil, err
+	}
+	capturedBy, err := s.captureDAO.FindByTargetID(userID)
+	if err != nil {
+		return nil, err
+	}
+	export.Captures = make([]dto.CaptureDetailItem, 0, len(captured)+len(capturedBy))
+	for _, capture := range append(captured, capturedBy...) {
+		export.Captures = append(export.Captures, convertToCaptureDetailItem(&capture))
+	}
+
+	punishments, err := s.punishmentDAO.FindAllByTarget(userID)
+	if err != nil {
+		return nil, err
+	}
+	export.Punishments = make([]dto.PunishmentListItem, len(punishments))
+	for i, punishment := range punishments {
+		export.Punishments[i] = dto.PunishmentListItem{
+			ID:           punishment.ID,
+			TargetName:   punishment.Target.Username,
+			AssignerName: punishment.Assigner.Username,
+			Type:         punishment.Type,
+			Status:       punishment.Status,
+			CreatedAt:    punishment.CreatedAt,
+			ExpiresAt:    punishment.ExpiresAt,
+		}
+	}
+
+	return export, nil
+}
+
+// Anonimizar usuario (solo Andrei): username y email pasan a un seudónimo y
+// la cuenta queda sin acceso. Estadísticas e historial de capturas se
+// conservan con el seudónimo. También aplica a usuarios en la papelera
+func (s *PrivacyService) AnonymizeUser(userID, currentUserID uint, currentUserRole string) (*dto.AnonymizeUserResponse, error) {
+	if currentUserRole != models.RoleAndrei {
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can anonymize users")
+	}
+
+	user, err := s.userDAO.FindByIDWithTrashed(userID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+	if user.Role == models.RoleAndrei {
+		return nil, apperrors.Forbidden("cannot_anonymize_andrei", "Andrei accounts cannot be anonymized")
+	}
+	if user.AnonymizedAt != nil {
+		return nil, apperrors.Conflict("already_anonymized", "User is already anonymized")
+	}
+
+	pseudonym, err := s.newPseudonym()
+	if err != nil {
+		return nil, err
+	}
+	password, err := utils.GeneratePassword(anonymizedPassLength)
+	if err != nil {
+		return nil, apperrors.Internal(err)
+	}
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, apperrors.Internal(err)
+	}
+
+	anonymization := &dao.UserAnonymization{
+		UserID:      user.ID,
+		OldUsername: user.Username,
+		Username:    pseudonym,
+		Email:       pseudonym + "@" + anonymizedDomain,
+		Password:    hashedPassword,
+		At:          time.Now(),
+	}
+	err = s.userDAO.Anonymize(anonymization, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.UserAnonymized{
+			UserID:         user.ID,
+			Pseudonym:      pseudonym,
+			Role:           user.Role,
+			AnonymizedByID: currentUserID,
+		}}
+	}))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.Conflict("already_anonymized", "User is already anonymized")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.bus.Notify()
+
+	if user.AvatarKey != "" {
+		if err := s.store.Delete(context.Background(), user.AvatarKey); err != nil {
+			log.Printf("Failed to delete avatar %s: %v", user.AvatarKey, err)
+		}
+	}
+
+	return &dto.AnonymizeUserResponse{
+		ID:           user.ID,
+		Username:     anonymization.Username,
+		Email:        anonymization.Email,
+		AnonymizedAt: anonymization.At,
+	}, nil
+}
+
+// newPseudonym genera un username aleatorio que nadie usa, incluidos los
+// usuarios en la papelera
+func (s *PrivacyService) newPseudonym() (string, error) {
+	for attempt := 0; attempt < pseudonymAttempts; attempt++ {
+		buf := make([]byte, 6)
+		if _, err := rand.Read(buf); err != nil {
+			return "", apperrors.Internal(err)
+		}
+		pseudonym := pseudonymPrefix + hex.EncodeToString(buf)
+
+		usernames, emails, err := s.userDAO.FindTakenIdentities(
+			[]string{pseudonym}, []string{pseudonym + "@" + anonymizedDomain})
+		if err != nil {
+			return "", err
+		}
+		if len(usernames) == 0 && len(emails) == 0 {
+			return pseudonym, nil
+		}
+	}
+	return "", apperrors.Internal(errors.New("could not generate a unique pseudonym"))
+}
+
+func convertToCaptureDetailItem(capture *models.Capture) dto.CaptureDetailItem {
+	return dto.CaptureDetailItem{
+		ID:          capture.ID,
+		DaemonName:  capture.Daemon.Username,
+		TargetName:  capture.Target.Username,
+		CaptureDate: capture.CaptureDate,
+		Status:      capture.Status,
+		Points:      capture.Points,
+		Difficulty:  capture.Difficulty,
+		Method:      capture.Method,
+	}
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/mail"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/storage"
+	"devops-chaos-backend/internal/utils"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxDisplayNameLength = 64
+	maxBioLength         = 500
+	emailTokenPrefix     = "eml_"
+	emailDeliveryTimeout = 15 * time.Second
+)
+
+type ProfileService struct {
+	userDAO        *dao.UserDAO
+	emailChangeDAO *dao.EmailChangeDAO
+	config         *config.ProfileConfig
+	mailer         mail.Mailer // nil si no hay SMTP: no se puede cambiar el email
+	store          storage.BlobStore
+	bus            *events.Bus
+}
+
+func NewProfileService(cfg *config.ProfileConfig, mailer mail.Mailer, store storage.BlobStore, bus *events.Bus) *ProfileService {
+	return &ProfileService{
+		userDAO:        dao.NewUserDAO(),
+		emailChangeDAO: dao.NewEmailChangeDAO(),
+		config:         cfg,
+		mailer:         mailer,
+		store:          store,
+		bus:            bus,
+	}
+}
+
+// Obtener el perfil propio
+func (s *ProfileService) GetProfile(userID uint) (*dto.ProfileResponse, error) {
+	user, err := s.userDAO.FindByID(userID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+
+	response := convertToProfileResponse(user)
+
+	pending, err := s.emailChangeDAO.FindPendingByUser(userID, time.Now())
+	switch {
+	case err == nil:
+		response.PendingEmail = pending.NewEmail
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Editar nombre visible y bio propios
+func (s *ProfileService) UpdateProfile(userID uint, req *dto.UpdateProfileRequest) (*dto.ProfileResponse, error) {
+	updates := make(map[string]interface{})
+	var fields []string
+
+	if req.DisplayName != nil {
+		displayName := strings.TrimSpace(*req.DisplayName)
+		if utf8.RuneCountInString(displayName) > maxDisplayNameLength {
+			return nil, apperrors.Validation("display_name_too_long",
+				fmt.Sprintf("Display name must be at most %d characters", maxDisplayNameLength))
+		}
+		if strings.IndexFunc(displayName, unicode.IsControl) >= 0 {
+			return nil, apperrors.Validation("invalid_display_name", "Display name cannot contain control characters")
+		}
+		updates["display_name"] = displayName
+		fields = append(fields, "display_name")
+	}
+
+	if req.Bio != nil {
+		bio := strings.TrimSpace(*req.Bio)
+		if utf8.RuneCountInString(bio) > maxBioLength {
+			return nil, apperrors.Validation("bio_too_long", fmt.Sprintf("Bio must be at most %d characters", maxBioLength))
+		}
+		updates["bio"] = bio
+		fields = append(fields, "bio")
+	}
+
+	if len(updates) == 0 {
+		return nil, apperrors.Validation("empty_update", "Provide display_name or bio")
+	}
+
+	if err := s.updateProfile(userID, updates, fields); err != nil {
+		return nil, err
+	}
+	return s.GetProfile(userID)
+}
+
+// Pedir el cambio de email: se envía un enlace a la dirección nueva y el
+// email no cambia hasta que se confirma
+func (s *ProfileService) RequestEmailChange(userID uint, req *dto.ChangeEmailRequest) (*dto.EmailChangeResponse, error) {
+	if s.mailer == nil {
+		return nil, apperrors.Unavailable("email_unavailable", "Email delivery is not configured", nil)
+	}
+
+	user, err := s.userDAO.FindByID(userID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+	if !utils.CheckPassword(user.Password, req.Password) {
+		return nil, apperrors.Validation("incorrect_password", "Current password is incorrect")
+	}
+
+	newEmail := strings.TrimSpace(req.NewEmail)
+	if strings.EqualFold(newEmail, user.Email) {
+		return nil, apperrors.Validation("same_email", "New email matches the current one")
+	}
+	if err := s.checkEmailAvailable(newEmail); err != nil {
+		return nil, err
+	}
+
+	token, err := newEmailToken()
+	if err != nil {
+		return nil, apperrors.Internal(err)
+	}
+	change := &models.EmailChange{
+		UserID:    user.ID,
+		NewEmail:  newEmail,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(s.config.EmailChangeTTL),
+	}
+
+	// Se envía antes de guardar: si el envío falla no queda un cambio pendiente
+	if err := s.sendVerification(user, change, token); err != nil {
+		return nil, err
+	}
+	if err := s.emailChangeDAO.Create(change); err != nil {
+		return nil, err
+	}
+
+	return &dto.EmailChangeResponse{NewEmail: change.NewEmail, ExpiresAt: change.ExpiresAt}, nil
+}
+
+// Confirmar el email nuevo con el token del enlace. No exige sesión: el
+// enlace puede abrirse en otro dispositivo
+func (s *ProfileService) ConfirmEmailChange(req *dto.VerifyEmailRequest) (*dto.ProfileResponse, error) {
+	now := time.Now()
+	invalid := apperrors.Validation("invalid_verification_token", "Verification link is invalid or has expired")
+
+	change, err := s.emailChangeDAO.FindByTokenHash(hashToken(req.Token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, invalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if change.ConfirmedAt != nil || !now.Before(change.ExpiresAt) {
+		return nil, invalid
+	}
+	if err := s.checkEmailAvailable(change.NewEmail); err != nil {
+		return nil, err
+	}
+
+	err = s.emailChangeDAO.Confirm(change, now, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.EmailChanged{UserID: change.UserID, EmailChangeID: change.ID}}
+	}))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, invalid
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return nil, apperrors.Conflict("email_taken", "Email already exists")
+	case err != nil:
+		return nil, err
+	}
+	s.bus.Notify()
+
+	return s.GetProfile(change.UserID)
+}
+
+// Subir o reemplazar el avatar propio
+func (s *ProfileService) UploadAvatar(ctx context.Context, userID uint, file *multipart.FileHeader) (*dto.ProfileResponse, error) {
+	user, err := s.userDAO.FindByID(userID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+
+	data, contentType, err := readImage(file, s.config.MaxAvatarBytes, "Avatars must be PNG, JPEG or GIF images")
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := newStorageKey("avatars")
+	if err != nil {
+		return nil, err
+	}
+	size, err := s.store.Put(ctx, key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{"avatar_key": key, "avatar_type": contentType, "avatar_size": size}
+	if err := s.updateProfile(userID, updates, []string{"avatar"}); err != nil {
+		s.store.Delete(ctx, key) // No dejar blobs huérfanos
+		return nil, err
+	}
+	s.deleteAvatarBlob(user.AvatarKey)
+
+	return s.GetProfile(userID)
+}
+
+// Quitar el avatar propio
+func (s *ProfileService) DeleteAvatar(userID uint) error {
+	user, err := s.userDAO.FindByID(userID)
+	if err != nil {
+		return apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+	if user.AvatarKey == "" {
+		return apperrors.NotFound("avatar_not_found", "No avatar to remove")
+	}
+
+	updates := map[string]interface{}{"avatar_key": "", "avatar_type": "", "avatar_size": 0}
+	if err := s.updateProfile(userID, updates, []string{"avatar"}); err != nil {
+		return err
+	}
+	s.deleteAvatarBlob(user.AvatarKey)
+	return nil
+}
+
+// Abrir el avatar de un usuario; el llamador debe cerrar el reader
+func (s *ProfileService) OpenAvatar(ctx context.Context, userID uint) (*models.User, io.ReadCloser, error) {
+	user, err := s.userDAO.FindByID(userID)
+	if err != nil {
+		return nil, nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+	if user.AvatarKey == "" {
+		return nil, nil, apperrors.NotFound("avatar_not_found", "User has no avatar")
+	}
+
+	reader, err := s.store.Open(ctx, user.AvatarKey)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, nil, apperrors.NotFound("avatar_not_found", "Avatar image is missing")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, reader, nil
+}
+
+func (s *ProfileService) updateProfile(userID uint, updates map[string]interface{}, fields []string) error {
+	err := s.userDAO.UpdateProfile(userID, updates, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.ProfileUpdated{UserID: userID, Fields: fields}}
+	}))
+	if err != nil {
+		return apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+	s.bus.Notify()
+	return nil
+}
+
+// checkEmailAvailable incluye a los usuarios en la papelera, que siguen
+// ocupando el índice único
+func (s *ProfileService) checkEmailAvailable(email string) error {
+	_, taken, err := s.userDAO.FindTakenIdentities(nil, []string{email})
+	if err != nil {
+		return err
+	}
+	if len(taken) > 0 {
+		return apperrors.Conflict("email_taken", "Email already exists")
+	}
+	return nil
+}
+
+func (s *ProfileService) sendVerification(user *models.User, change *models.EmailChange, token string) error {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\r\n\r\n", user.Username)
+	body.WriteString("Confirm this address for your DevOps Chaos account here:\r\n")
+	fmt.Fprintf(&body, "%s#token=%s\r\n\r\n", s.config.VerifyEmailURL, token)
+	fmt.Fprintf(&body, "The link can be used once and expires on %s.\r\n", change.ExpiresAt.UTC().Format(time.RFC1123))
+	body.WriteString("If you did not ask for this change, ignore this message.\r\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), emailDeliveryTimeout)
+	defer cancel()
+
+	err := s.mailer.Send(ctx, mail.Message{
+		To:      change.NewEmail,
+		Subject: "Confirm your new email address",
+		Body:    body.String(),
+	})
+	if err != nil {
+		log.Printf("Failed to send email verification for user %d: %v", user.ID, err)
+		return apperrors.Unavailable("email_delivery_failed", "Could not send the verification email", err)
+	}
+	return nil
+}
+
+// deleteAvatarBlob borra un avatar ya desvinculado; un fallo solo deja un huérfano
+func (s *ProfileService) deleteAvatarBlob(key string) {
+	if key == "" {
+		return
+	}
+	if err := s.store.Delete(context.Background(), key); err != nil {
+		log.Printf("Failed to delete avatar %s: %v", key, err)
+	}
+}
+
+func newEmailToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return emailTokenPrefix + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func convertToProfileResponse(user *models.User) *dto.ProfileResponse {
+	response := &dto.ProfileResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		Bio:         user.Bio,
+		Role:        user.Role,
+		Status:      user.Status,
+		CreatedAt:   user.CreatedAt,
+	}
+	if user.AvatarKey != "" {
+		response.AvatarURL = fmt.Sprintf("/api/users/%d/avatar", user.ID)
+	}
+	return response
+}
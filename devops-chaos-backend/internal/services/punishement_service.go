@@ -1,48 +1,50 @@
 package services
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/dao"
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/models"
-	"errors"
-	"math"
 	"time"
 )
 
+const rewardPoints = 20 // Puntos por recompensa
+
 type PunishmentService struct {
 	punishmentDAO *dao.PunishmentDAO
 	userDAO       *dao.UserDAO
-	statisticDAO  *dao.StatisticDAO
+	bus           *events.Bus
 }
 
-func NewPunishmentService() *PunishmentService {
+func NewPunishmentService(bus *events.Bus) *PunishmentService {
 	return &PunishmentService{
 		punishmentDAO: dao.NewPunishmentDAO(),
 		userDAO:       dao.NewUserDAO(),
-		statisticDAO:  dao.NewStatisticDAO(),
+		bus:           bus,
 	}
 }
 
 // Crear castigo/recompensa (solo Andrei)
 func (s *PunishmentService) CreatePunishment(req *dto.CreatePunishmentRequest, assignerID uint, assignerRole string) (*dto.PunishmentResponse, error) {
 	if assignerRole != models.RoleAndrei {
-		return nil, errors.New("unauthorized: only Andrei can assign punishments")
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can assign punishments")
 	}
 
 	// Verificar que el objetivo existe
 	target, err := s.userDAO.FindByID(req.TargetID)
 	if err != nil {
-		return nil, errors.New("target user not found")
+		return nil, apperrors.NotFoundOrInternal(err, "target_not_found", "Target user not found")
 	}
 
 	// Solo se puede castigar/recompensar a Daemons
 	if target.Role != models.RoleDaemon {
-		return nil, errors.New("can only punish/reward daemons")
+		return nil, apperrors.Validation("invalid_punishment_target", "Can only punish/reward daemons")
 	}
 
 	// Validar tipo de castigo
 	if !s.isValidPunishmentType(req.Type) {
-		return nil, errors.New("invalid punishment type")
+		return nil, apperrors.Validation("invalid_punishment_type", "Invalid punishment type")
 	}
 
 	// Parsear fecha de expiración si se proporciona
@@ -50,7 +52,7 @@ func (s *PunishmentService) CreatePunishment(req *dto.CreatePunishmentRequest, a
 	if req.ExpiresAt != "" {
 		parsedTime, err := time.Parse(time.RFC3339, req.ExpiresAt)
 		if err != nil {
-			return nil, errors.New("invalid expires_at format, use RFC3339 (2024-01-15T10:00:00Z)")
+			return nil, apperrors.Validation("invalid_expires_at", "Invalid expires_at format, use RFC3339 (2024-01-15T10:00:00Z)")
 		}
 		expiresAt = &parsedTime
 	}
@@ -65,21 +67,36 @@ func (s *PunishmentService) CreatePunishment(req *dto.CreatePunishmentRequest, a
 		ExpiresAt:   expiresAt,
 	}
 
-	err = s.punishmentDAO.Create(punishment)
+	assigner, err := s.userDAO.FindByID(assignerID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Actualizar status del usuario si es castigo
-	if s.isPunishmentType(req.Type) {
-		target.Status = models.StatusPunished
-		s.userDAO.Update(target)
-	}
-
-	// Si es recompensa, dar puntos adicionales
-	if req.Type == models.PunishmentTypeReward {
-		s.statisticDAO.AddPoints(req.TargetID, 20) // 20 puntos por recompensa
+	targetStatus := ""
+	if isPunishmentType(req.Type) {
+		targetStatus = models.StatusPunished
+	}
+
+	// Los puntos de una recompensa y los avisos reaccionan a PunishmentAssigned
+	err = s.punishmentDAO.CreateWithTargetStatus(punishment, targetStatus, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.PunishmentAssigned{
+			PunishmentID: punishment.ID,
+			TargetID:     target.ID,
+			TargetName:   target.Username,
+			AssignerID:   assigner.ID,
+			AssignerName: assigner.Username,
+			Type:         punishment.Type,
+			Description:  punishment.Description,
+			Status:       punishment.Status,
+			CreatedAt:    punishment.CreatedAt,
+			ExpiresAt:    punishment.ExpiresAt,
+		}}
+	}))
+	if err != nil {
+		return nil, err
 	}
+	s.bus.Notify()
 
 	// Cargar el castigo completo
 	createdPunishment, err := s.punishmentDAO.FindByID(punishment.ID)
@@ -87,54 +104,41 @@ func (s *PunishmentService) CreatePunishment(req *dto.CreatePunishmentRequest, a
 		return nil, err
 	}
 
-	return s.convertToPunishmentResponse(createdPunishment), nil
+	return convertToPunishmentResponse(createdPunishment), nil
 }
 
 // Obtener castigo por ID
 func (s *PunishmentService) GetPunishmentByID(id uint, userID uint, userRole string) (*dto.PunishmentResponse, error) {
 	punishment, err := s.punishmentDAO.FindByID(id)
 	if err != nil {
-		return nil, errors.New("punishment not found")
+		return nil, apperrors.NotFoundOrInternal(err, "punishment_not_found", "Punishment not found")
 	}
 
 	// Control de acceso
 	if !s.canAccessPunishment(punishment, userID, userRole) {
-		return nil, errors.New("unauthorized to access this punishment")
+		return nil, apperrors.Forbidden("punishment_access_denied", "Not allowed to access this punishment")
 	}
 
-	return s.convertToPunishmentResponse(punishment), nil
+	return convertToPunishmentResponse(punishment), nil
 }
 
-// Listar castigos con paginación
-func (s *PunishmentService) GetPunishments(page, limit int, status string, userID uint, userRole string) (*dto.PaginatedResponse, error) {
-	if page <= 0 {
-		page = 1
-	}
-	if limit <= 0 {
-		limit = 10
-	}
-
-	var punishments []models.Punishment
-	var total int64
-	var err error
+// Listar castigos con filtros, orden y paginación según el rol
+func (s *PunishmentService) GetPunishments(params dto.ListParams, userID uint, userRole string) (*dto.PaginatedResponse, error) {
+	query := dao.NewListQuery(params)
 
 	switch userRole {
 	case models.RoleAndrei:
 		// Andrei puede ver todos los castigos
-		if status != "" {
-			punishments, total, err = s.punishmentDAO.FindByStatus(status, page, limit)
-		} else {
-			punishments, total, err = s.punishmentDAO.FindAll(page, limit)
-		}
 
 	case models.RoleDaemon:
 		// Daemon solo ve sus propios castigos
-		punishments, total, err = s.punishmentDAO.FindByTarget(userID, page, limit)
+		query.Scope(dao.PunishmentsByTarget(userID))
 
 	default:
-		return nil, errors.New("unauthorized")
+		return nil, apperrors.Forbidden("insufficient_role", "Role not allowed to list punishments")
 	}
 
+	punishments, page, err := s.punishmentDAO.List(query)
 	if err != nil {
 		return nil, err
 	}
@@ -153,34 +157,24 @@ func (s *PunishmentService) GetPunishments(page, limit int, status string, userI
 		}
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	return &dto.PaginatedResponse{
-		Success:    true,
-		Message:    "Punishments retrieved successfully",
-		Data:       punishmentItems,
-		Page:       page,
-		Limit:      limit,
-		TotalItems: total,
-		TotalPages: totalPages,
-	}, nil
+	return page.ToResponse("Punishments retrieved successfully", punishmentItems), nil
 }
 
 // Actualizar castigo (solo Andrei)
 func (s *PunishmentService) UpdatePunishment(punishmentID uint, req *dto.UpdatePunishmentRequest, userRole string) error {
 	if userRole != models.RoleAndrei {
-		return errors.New("unauthorized: only Andrei can update punishments")
+		return apperrors.Forbidden("insufficient_role", "Only Andrei can update punishments")
 	}
 
 	punishment, err := s.punishmentDAO.FindByID(punishmentID)
 	if err != nil {
-		return errors.New("punishment not found")
+		return apperrors.NotFoundOrInternal(err, "punishment_not_found", "Punishment not found")
 	}
 
 	// Actualizar campos si se proporcionan
 	if req.Status != "" {
 		if !s.isValidPunishmentStatus(req.Status) {
-			return errors.New("invalid status")
+			return apperrors.Validation("invalid_status", "Invalid punishment status")
 		}
 
 		oldStatus := punishment.Status
@@ -204,7 +198,7 @@ func (s *PunishmentService) UpdatePunishment(punishmentID uint, req *dto.UpdateP
 	if req.ExpiresAt != "" {
 		parsedTime, err := time.Parse(time.RFC3339, req.ExpiresAt)
 		if err != nil {
-			return errors.New("invalid expires_at format")
+			return apperrors.Validation("invalid_expires_at", "Invalid expires_at format, use RFC3339 (2024-01-15T10:00:00Z)")
 		}
 		punishment.ExpiresAt = &parsedTime
 	}
@@ -215,12 +209,12 @@ func (s *PunishmentService) UpdatePunishment(punishmentID uint, req *dto.UpdateP
 // Eliminar castigo (solo Andrei)
 func (s *PunishmentService) DeletePunishment(punishmentID uint, userRole string) error {
 	if userRole != models.RoleAndrei {
-		return errors.New("unauthorized: only Andrei can delete punishments")
+		return apperrors.Forbidden("insufficient_role", "Only Andrei can delete punishments")
 	}
 
 	punishment, err := s.punishmentDAO.FindByID(punishmentID)
 	if err != nil {
-		return errors.New("punishment not found")
+		return apperrors.NotFoundOrInternal(err, "punishment_not_found", "Punishment not found")
 	}
 
 	// Si el castigo está activo, actualizar status del usuario
@@ -239,7 +233,7 @@ func (s *PunishmentService) DeletePunishment(punishmentID uint, userRole string)
 func (s *PunishmentService) GetActivePunishments(targetID uint, userID uint, userRole string) ([]dto.PunishmentListItem, error) {
 	// Solo el daemon afectado o Andrei pueden ver castigos activos
 	if userRole != models.RoleAndrei && userID != targetID {
-		return nil, errors.New("unauthorized")
+		return nil, apperrors.Forbidden("not_owner", "Can only view your own active punishments")
 	}
 
 	punishments, err := s.punishmentDAO.FindActiveByTarget(targetID)
@@ -305,7 +299,7 @@ func (s *PunishmentService) isValidPunishmentStatus(status string) bool {
 	return false
 }
 
-func (s *PunishmentService) isPunishmentType(punishmentType string) bool {
+func isPunishmentType(punishmentType string) bool {
 	punishmentTypes := []string{
 		models.PunishmentTypeTimeout,
 		models.PunishmentTypeDemotion,
@@ -319,7 +313,7 @@ func (s *PunishmentService) isPunishmentType(punishmentType string) bool {
 	return false
 }
 
-func (s *PunishmentService) convertToPunishmentResponse(punishment *models.Punishment) *dto.PunishmentResponse {
+func convertToPunishmentResponse(punishment *models.Punishment) *dto.PunishmentResponse {
 	return &dto.PunishmentResponse{
 		ID: punishment.ID,
 		Target: dto.UserInfo{
@@ -1,55 +1,86 @@
 package services
 
 import (
-	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/dao"
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/models"
-	"errors"
+	"fmt"
+	"html"
 	"math"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxSearchQueryLength = 200
+	snippetRadius        = 60
+	approvedReportPoints = 5 // Puntos por reporte aprobado
 )
 
 type ReportService struct {
-	reportDAO    *dao.ReportDAO
-	statisticDAO *dao.StatisticDAO
+	reportDAO *dao.ReportDAO
+	reviewDAO *dao.ReportReviewDAO
+	userDAO   *dao.UserDAO
+	bus       *events.Bus
 }
 
-func NewReportService() *ReportService {
+func NewReportService(bus *events.Bus) *ReportService {
 	return &ReportService{
-		reportDAO:    dao.NewReportDAO(),
-		statisticDAO: dao.NewStatisticDAO(),
+		reportDAO: dao.NewReportDAO(),
+		reviewDAO: dao.NewReportReviewDAO(),
+		userDAO:   dao.NewUserDAO(),
+		bus:       bus,
 	}
 }
 
+// Transiciones permitidas del flujo de revisión
+var reportStatusTransitions = map[string][]string{
+	models.ReportStatusPending:  {models.ReportStatusInReview, models.ReportStatusApproved, models.ReportStatusRejected},
+	models.ReportStatusInReview: {models.ReportStatusPending, models.ReportStatusApproved, models.ReportStatusRejected},
+	models.ReportStatusRejected: {models.ReportStatusInReview},
+	models.ReportStatusApproved: {},
+}
+
 // Crear reporte
 func (s *ReportService) CreateReport(req *dto.CreateReportRequest, userID uint, userRole string) (*dto.ReportResponse, error) {
 	// Validar tipo de reporte según rol
 	if !s.canCreateReportType(req.Type, userRole) {
-		return nil, errors.New("unauthorized to create this type of report")
+		return nil, apperrors.Forbidden("report_type_forbidden", "Not allowed to create this type of report")
 	}
 
-	var authorID *uint
-	if req.Type != models.ReportTypeAnonymous {
-		authorID = &userID
+	severity := req.Severity
+	if severity == "" {
+		severity = models.ReportSeverityMedium
 	}
 
 	report := &models.Report{
 		Title:       req.Title,
 		Description: req.Description,
 		Type:        req.Type,
-		AuthorID:    authorID,
+		AuthorID:    &userID,
 		Status:      models.ReportStatusPending,
+		Severity:    severity,
+		Location:    strings.TrimSpace(req.Location),
 	}
 
-	err := s.reportDAO.Create(report)
+	author, err := s.userDAO.FindByID(userID)
 	if err != nil {
 		return nil, err
 	}
+	report.Author = author
 
-	// Incrementar contador de reportes si no es anónimo
-	if authorID != nil {
-		s.statisticDAO.IncrementReports(*authorID)
+	// El contador de reportes del autor reacciona a ReportSubmitted
+	err = s.reportDAO.CreateWithHistory(report, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.ReportSubmitted{ReportInfo: reportInfo(report)}}
+	}))
+	if err != nil {
+		return nil, err
 	}
+	s.bus.Notify()
 
 	// Cargar el reporte con autor
 	createdReport, err := s.reportDAO.FindByID(report.ID)
@@ -57,63 +88,34 @@ func (s *ReportService) CreateReport(req *dto.CreateReportRequest, userID uint,
 		return nil, err
 	}
 
-	return s.convertToReportResponse(createdReport), nil
+	return convertToReportResponse(createdReport), nil
 }
 
 // Obtener reporte por ID
 func (s *ReportService) GetReportByID(id uint, userID uint, userRole string) (*dto.ReportResponse, error) {
 	report, err := s.reportDAO.FindByID(id)
 	if err != nil {
-		return nil, errors.New("report not found")
+		return nil, apperrors.NotFoundOrInternal(err, "report_not_found", "Report not found")
 	}
 
 	// Control de acceso
 	if !s.canAccessReport(report, userID, userRole) {
-		return nil, errors.New("unauthorized to access this report")
+		return nil, apperrors.Forbidden("report_access_denied", "Not allowed to access this report")
 	}
 
-	return s.convertToReportResponse(report), nil
+	return convertToReportResponse(report), nil
 }
 
-// Listar reportes con paginación
-func (s *ReportService) GetReports(page, limit int, reportType, status string, userID uint, userRole string) (*dto.PaginatedResponse, error) {
-	if page <= 0 {
-		page = 1
-	}
-	if limit <= 0 {
-		limit = 10
-	}
-
-	var reports []models.Report
-	var total int64
-	var err error
-
-	switch userRole {
-	case models.RoleAndrei:
-		// Andrei puede ver todos los reportes
-		if reportType != "" && status != "" {
-			// Filtrar por ambos
-			reports, total, err = s.getReportsByTypeAndStatus(reportType, status, page, limit)
-		} else if reportType != "" {
-			reports, total, err = s.reportDAO.FindByType(reportType, page, limit)
-		} else if status != "" {
-			reports, total, err = s.reportDAO.FindByStatus(status, page, limit)
-		} else {
-			reports, total, err = s.reportDAO.FindAll(page, limit)
-		}
-
-	case models.RoleDaemon:
-		// Daemon solo ve sus propios reportes
-		reports, total, err = s.reportDAO.FindByAuthor(userID, page, limit)
-
-	case models.RoleNetworkAdmin:
-		// Network admin solo ve reportes anónimos (los suyos)
-		reports, total, err = s.reportDAO.FindAnonymous(page, limit)
-
-	default:
-		return nil, errors.New("unauthorized")
+// Listar reportes con filtros, orden y paginación según el rol
+func (s *ReportService) GetReports(params dto.ListParams, userID uint, userRole string) (*dto.PaginatedResponse, error) {
+	visible, err := s.visibilityScope(userID, userRole)
+	if err != nil {
+		return nil, err
 	}
+	query := dao.NewListQuery(params)
+	query.Scope(visible)
 
+	reports, page, err := s.reportDAO.List(query)
 	if err != nil {
 		return nil, err
 	}
@@ -132,67 +134,327 @@ func (s *ReportService) GetReports(page, limit int, reportType, status string, u
 			Description: report.Description,
 			Type:        report.Type,
 			Status:      report.Status,
+			Severity:    report.Severity,
+			Location:    report.Location,
 			AuthorID:    report.AuthorID,
 			Author:      authorName,
 			CreatedAt:   report.CreatedAt,
 		}
 	}
 
+	return page.ToResponse("Reports retrieved successfully", reportItems), nil
+}
+
+// Buscar reportes por texto respetando las mismas reglas de visibilidad que canAccessReport
+func (s *ReportService) SearchReports(text string, page, limit int, userID uint, userRole string) (*dto.PaginatedResponse, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, apperrors.Validation("missing_query", "Query parameter 'q' is required")
+	}
+	if len(text) > maxSearchQueryLength {
+		return nil, apperrors.Validation("query_too_long", "Search query is too long")
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = dao.DefaultPageSize
+	}
+	if limit > dao.MaxPageSize {
+		limit = dao.MaxPageSize
+	}
+
+	scope, err := s.visibilityScope(userID, userRole)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, total, err := s.reportDAO.Search(text, []func(*gorm.DB) *gorm.DB{scope}, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	reports, err := s.reportDAO.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	reportsByID := make(map[uint]models.Report, len(reports))
+	for _, report := range reports {
+		reportsByID[report.ID] = report
+	}
+
+	// Mantener el orden por relevancia devuelto por la búsqueda
+	results := make([]dto.ReportSearchResult, 0, len(rows))
+	for _, row := range rows {
+		report, ok := reportsByID[row.ID]
+		if !ok {
+			continue
+		}
+
+		authorName := ""
+		if report.Author != nil {
+			authorName = report.Author.Username
+		}
+
+		titleHighlight := renderHighlight(row.TitleSnippet)
+		descriptionHighlight := renderHighlight(row.DescriptionSnippet)
+		if row.TitleSnippet == "" && row.DescriptionSnippet == "" {
+			titleHighlight = highlightMatch(report.Title, text, snippetRadius)
+			descriptionHighlight = highlightMatch(report.Description, text, snippetRadius)
+		}
+
+		results = append(results, dto.ReportSearchResult{
+			ReportListItem: dto.ReportListItem{
+				ID:          report.ID,
+				Title:       report.Title,
+				Description: report.Description,
+				Type:        report.Type,
+				Status:      report.Status,
+				Severity:    report.Severity,
+				Location:    report.Location,
+				AuthorID:    report.AuthorID,
+				Author:      authorName,
+				CreatedAt:   report.CreatedAt,
+			},
+			Rank:                 row.Rank,
+			TitleHighlight:       titleHighlight,
+			DescriptionHighlight: descriptionHighlight,
+		})
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(limit)))
 
 	return &dto.PaginatedResponse{
 		Success:    true,
-		Message:    "Reports retrieved successfully",
-		Data:       reportItems,
+		Message:    "Search completed successfully",
+		Data:       results,
 		Page:       page,
 		Limit:      limit,
 		TotalItems: total,
 		TotalPages: totalPages,
+		HasMore:    page < totalPages,
 	}, nil
 }
 
 // Actualizar status de reporte (solo Andrei)
-func (s *ReportService) UpdateReportStatus(reportID uint, req *dto.UpdateReportRequest, userRole string) error {
+func (s *ReportService) UpdateReportStatus(reportID uint, req *dto.UpdateReportRequest, userID uint, userRole string) error {
 	if userRole != models.RoleAndrei {
-		return errors.New("unauthorized: only Andrei can update report status")
+		return apperrors.Forbidden("insufficient_role", "Only Andrei can update report status")
 	}
 
 	report, err := s.reportDAO.FindByID(reportID)
 	if err != nil {
-		return errors.New("report not found")
+		return apperrors.NotFoundOrInternal(err, "report_not_found", "Report not found")
 	}
 
-	// Validar status
+	// Validar status y transición
 	if !s.isValidStatus(req.Status) {
-		return errors.New("invalid status")
+		return apperrors.Validation("invalid_status", "Invalid report status")
+	}
+
+	if !s.canTransition(report.Status, req.Status) {
+		return apperrors.Conflict("invalid_status_transition",
+			fmt.Sprintf("Cannot move report from %s to %s", report.Status, req.Status))
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if req.Status == models.ReportStatusRejected && reason == "" {
+		return apperrors.Validation("rejection_reason_required", "A reason is required to reject a report")
 	}
 
 	oldStatus := report.Status
 	report.Status = req.Status
+	if req.Status == models.ReportStatusRejected {
+		report.RejectionReason = reason
+	} else {
+		report.RejectionReason = ""
+	}
 
-	err = s.reportDAO.Update(report)
+	// Puntos del autor y de su escuadrón, avisos y webhooks reaccionan al resultado
+	var reviewEvents []events.Event
+	switch req.Status {
+	case models.ReportStatusApproved:
+		reviewEvents = append(reviewEvents, events.ReportApproved{ReportInfo: reportInfo(report), ReviewerID: userID})
+	case models.ReportStatusRejected:
+		reviewEvents = append(reviewEvents, events.ReportRejected{ReportInfo: reportInfo(report), ReviewerID: userID, Reason: reason})
+	}
+
+	err = s.reportDAO.UpdateWithHistory(report, &models.ReportStatusChange{
+		FromStatus:  oldStatus,
+		ToStatus:    req.Status,
+		ChangedByID: &userID,
+		AssigneeID:  report.AssigneeID,
+		Reason:      reason,
+	}, s.bus.Writer(func() []events.Event { return reviewEvents }))
 	if err != nil {
 		return err
 	}
 
-	// Si se aprueba un reporte, dar puntos al autor
-	if oldStatus == models.ReportStatusPending && req.Status == models.ReportStatusApproved && report.AuthorID != nil {
-		s.statisticDAO.AddPoints(*report.AuthorID, 5) // 5 puntos por reporte aprobado
+	s.bus.Notify()
+	return nil
+}
+
+// Asignar un daemon para investigar el reporte (solo Andrei)
+func (s *ReportService) AssignReport(reportID uint, req *dto.AssignReportRequest, userID uint, userRole string) (*dto.ReportResponse, error) {
+	if userRole != models.RoleAndrei {
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can assign reports")
 	}
 
-	return nil
+	report, err := s.reportDAO.FindByID(reportID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "report_not_found", "Report not found")
+	}
+
+	if report.Status == models.ReportStatusApproved || report.Status == models.ReportStatusRejected {
+		return nil, apperrors.Conflict("report_closed", "Cannot assign a report that has already been decided")
+	}
+
+	assignee, err := s.userDAO.FindByID(req.AssigneeID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "assignee_not_found", "Assignee not found")
+	}
+
+	if assignee.Role != models.RoleDaemon {
+		return nil, apperrors.Validation("invalid_assignee", "Reports can only be assigned to daemons")
+	}
+
+	// Asignar un reporte pendiente lo pone en revisión
+	oldStatus := report.Status
+	report.AssigneeID = &assignee.ID
+	if report.Status == models.ReportStatusPending {
+		report.Status = models.ReportStatusInReview
+	}
+
+	err = s.reportDAO.UpdateWithHistory(report, &models.ReportStatusChange{
+		FromStatus:  oldStatus,
+		ToStatus:    report.Status,
+		ChangedByID: &userID,
+		AssigneeID:  report.AssigneeID,
+		Reason:      fmt.Sprintf("Assigned to %s", assignee.Username),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedReport, err := s.reportDAO.FindByID(report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToReportResponse(updatedReport), nil
+}
+
+// Comentar un reporte (Andrei, el autor o el daemon asignado)
+func (s *ReportService) AddComment(reportID uint, req *dto.CreateReportCommentRequest, userID uint, userRole string) (*dto.ReportCommentResponse, error) {
+	report, err := s.reportDAO.FindByID(reportID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "report_not_found", "Report not found")
+	}
+
+	if !s.canParticipate(report, userID, userRole) {
+		return nil, apperrors.Forbidden("report_access_denied", "Not allowed to comment on this report")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, apperrors.Validation("empty_comment", "Comment body cannot be empty")
+	}
+
+	comment := &models.ReportComment{
+		ReportID: report.ID,
+		AuthorID: &userID,
+		Body:     body,
+	}
+
+	if err := s.reviewDAO.CreateComment(comment); err != nil {
+		return nil, err
+	}
+
+	createdComment, err := s.reviewDAO.FindCommentByID(comment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToCommentResponse(createdComment), nil
+}
+
+// Comentarios de revisión de un reporte
+func (s *ReportService) GetComments(reportID uint, userID uint, userRole string) ([]dto.ReportCommentResponse, error) {
+	report, err := s.reportDAO.FindByID(reportID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "report_not_found", "Report not found")
+	}
+
+	if !s.canAccessReport(report, userID, userRole) {
+		return nil, apperrors.Forbidden("report_access_denied", "Not allowed to access this report")
+	}
+
+	comments, err := s.reviewDAO.FindComments(report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ReportCommentResponse, len(comments))
+	for i := range comments {
+		items[i] = *convertToCommentResponse(&comments[i])
+	}
+
+	return items, nil
+}
+
+// Historial de estados de un reporte
+func (s *ReportService) GetHistory(reportID uint, userID uint, userRole string) ([]dto.ReportHistoryItem, error) {
+	report, err := s.reportDAO.FindByID(reportID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "report_not_found", "Report not found")
+	}
+
+	if !s.canAccessReport(report, userID, userRole) {
+		return nil, apperrors.Forbidden("report_access_denied", "Not allowed to access this report")
+	}
+
+	history, err := s.reviewDAO.FindHistory(report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ReportHistoryItem, len(history))
+	for i, change := range history {
+		// Los reportes anónimos nunca exponen a su autor
+		changedBy := "anonymous"
+		if change.ChangedBy != nil {
+			changedBy = change.ChangedBy.Username
+		}
+
+		items[i] = dto.ReportHistoryItem{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			ChangedBy:  changedBy,
+			Reason:     change.Reason,
+			CreatedAt:  change.CreatedAt,
+		}
+	}
+
+	return items, nil
 }
 
 // Eliminar reporte
 func (s *ReportService) DeleteReport(reportID uint, userID uint, userRole string) error {
 	report, err := s.reportDAO.FindByID(reportID)
 	if err != nil {
-		return errors.New("report not found")
+		return apperrors.NotFoundOrInternal(err, "report_not_found", "Report not found")
 	}
 
 	// Solo Andrei o el autor pueden eliminar
 	if userRole != models.RoleAndrei && (report.AuthorID == nil || *report.AuthorID != userID) {
-		return errors.New("unauthorized to delete this report")
+		return apperrors.Forbidden("report_access_denied", "Not allowed to delete this report")
 	}
 
 	return s.reportDAO.Delete(reportID)
@@ -201,7 +463,7 @@ func (s *ReportService) DeleteReport(reportID uint, userID uint, userRole string
 // Obtener reportes recientes para dashboard
 func (s *ReportService) GetRecentReports(limit int, userRole string) ([]dto.ReportListItem, error) {
 	if userRole != models.RoleAndrei {
-		return nil, errors.New("unauthorized")
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can view recent reports")
 	}
 
 	reports, err := s.reportDAO.FindRecent(limit)
@@ -222,6 +484,8 @@ func (s *ReportService) GetRecentReports(limit int, userRole string) ([]dto.Repo
 			Description: report.Description,
 			Type:        report.Type,
 			Status:      report.Status,
+			Severity:    report.Severity,
+			Location:    report.Location,
 			AuthorID:    report.AuthorID,
 			Author:      authorName,
 			CreatedAt:   report.CreatedAt,
@@ -233,14 +497,12 @@ func (s *ReportService) GetRecentReports(limit int, userRole string) ([]dto.Repo
 
 // Helper methods
 
+// Los reportes anónimos solo se crean con ResistanceService.ReportSuspiciousActivity,
+// que aplica el límite por seudónimo, la prueba de trabajo y la detección de duplicados
 func (s *ReportService) canCreateReportType(reportType, userRole string) bool {
 	switch userRole {
-	case models.RoleDaemon:
+	case models.RoleDaemon, models.RoleAndrei:
 		return reportType == models.ReportTypeCapture || reportType == models.ReportTypeResistance
-	case models.RoleNetworkAdmin:
-		return reportType == models.ReportTypeAnonymous
-	case models.RoleAndrei:
-		return true // Andrei puede crear cualquier tipo
 	default:
 		return false
 	}
@@ -250,18 +512,60 @@ func (s *ReportService) canAccessReport(report *models.Report, userID uint, user
 	switch userRole {
 	case models.RoleAndrei:
 		return true // Andrei puede ver todo
+	case models.RoleDaemon, models.RoleNetworkAdmin:
+		// Sus reportes o los asignados. Los anónimos no tienen autor: el remitente
+		// los sigue con su código de recibo, nunca desde este listado
+		return s.isAuthor(report, userID) || s.isAssignee(report, userID)
+	default:
+		return false
+	}
+}
+
+// visibilityScope traduce canAccessReport a una condición SQL; ambos deben mantenerse alineados
+func (s *ReportService) visibilityScope(userID uint, userRole string) (func(*gorm.DB) *gorm.DB, error) {
+	switch userRole {
+	case models.RoleAndrei:
+		return func(db *gorm.DB) *gorm.DB { return db }, nil
+	case models.RoleDaemon, models.RoleNetworkAdmin:
+		return dao.ReportsInvolving(userID), nil
+	default:
+		return nil, apperrors.Forbidden("insufficient_role", "Role not allowed to access reports")
+	}
+}
+
+// canParticipate indica quién puede comentar: Andrei, el autor o el daemon asignado
+func (s *ReportService) canParticipate(report *models.Report, userID uint, userRole string) bool {
+	switch userRole {
+	case models.RoleAndrei:
+		return true
 	case models.RoleDaemon:
-		return report.AuthorID != nil && *report.AuthorID == userID // Solo sus reportes
-	case models.RoleNetworkAdmin:
-		return report.Type == models.ReportTypeAnonymous // Solo anónimos
+		return s.isAuthor(report, userID) || s.isAssignee(report, userID)
 	default:
 		return false
 	}
 }
 
+func (s *ReportService) isAuthor(report *models.Report, userID uint) bool {
+	return report.AuthorID != nil && *report.AuthorID == userID
+}
+
+func (s *ReportService) isAssignee(report *models.Report, userID uint) bool {
+	return report.AssigneeID != nil && *report.AssigneeID == userID
+}
+
+func (s *ReportService) canTransition(from, to string) bool {
+	for _, allowed := range reportStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *ReportService) isValidStatus(status string) bool {
 	validStatuses := []string{
 		models.ReportStatusPending,
+		models.ReportStatusInReview,
 		models.ReportStatusApproved,
 		models.ReportStatusRejected,
 	}
@@ -273,15 +577,18 @@ func (s *ReportService) isValidStatus(status string) bool {
 	return false
 }
 
-func (s *ReportService) convertToReportResponse(report *models.Report) *dto.ReportResponse {
+func convertToReportResponse(report *models.Report) *dto.ReportResponse {
 	response := &dto.ReportResponse{
-		ID:          report.ID,
-		Title:       report.Title,
-		Description: report.Description,
-		Type:        report.Type,
-		Status:      report.Status,
-		CreatedAt:   report.CreatedAt,
-		UpdatedAt:   report.UpdatedAt,
+		ID:              report.ID,
+		Title:           report.Title,
+		Description:     report.Description,
+		Type:            report.Type,
+		Status:          report.Status,
+		Severity:        report.Severity,
+		Location:        report.Location,
+		RejectionReason: report.RejectionReason,
+		CreatedAt:       report.CreatedAt,
+		UpdatedAt:       report.UpdatedAt,
 	}
 
 	if report.Author != nil {
@@ -294,22 +601,88 @@ func (s *ReportService) convertToReportResponse(report *models.Report) *dto.Repo
 		}
 	}
 
+	if report.Assignee != nil {
+		response.Assignee = &dto.UserInfo{
+			ID:       report.Assignee.ID,
+			Username: report.Assignee.Username,
+			Email:    report.Assignee.Email,
+			Role:     report.Assignee.Role,
+			Status:   report.Assignee.Status,
+		}
+	}
+
+	return response
+}
+
+// convertToCommentResponse se comparte con el seguimiento anónimo de ResistanceService
+func convertToCommentResponse(comment *models.ReportComment) *dto.ReportCommentResponse {
+	response := &dto.ReportCommentResponse{
+		ID:         comment.ID,
+		Author:     "anonymous reporter",
+		AuthorRole: "reporter",
+		Body:       comment.Body,
+		CreatedAt:  comment.CreatedAt,
+	}
+
+	if comment.Author != nil {
+		response.Author = comment.Author.Username
+		response.AuthorRole = comment.Author.Role
+	}
+
 	return response
 }
 
-func (s *ReportService) getReportsByTypeAndStatus(reportType, status string, page, limit int) ([]models.Report, int64, error) {
-	var reports []models.Report
-	var total int64
+// renderHighlight escapa HTML y convierte los marcadores de resaltado en <mark>
+func renderHighlight(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	return strings.NewReplacer(dao.HighlightStart, "<mark>", dao.HighlightStop, "</mark>").Replace(escaped)
+}
+
+// highlightMatch arma un fragmento alrededor de la primera coincidencia (búsqueda sin Postgres)
+func highlightMatch(text, term string, radius int) string {
+	runes := []rune(text)
+	termRunes := []rune(strings.ToLower(term))
+
+	index := -1
+	for i := 0; i+len(termRunes) <= len(runes); i++ {
+		matched := true
+		for j, termRune := range termRunes {
+			if unicode.ToLower(runes[i+j]) != termRune {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			index = i
+			break
+		}
+	}
 
-	query := config.DB.Model(&models.Report{}).Where("type = ? AND status = ?", reportType, status)
+	if index < 0 {
+		if len(runes) > 2*radius {
+			return html.EscapeString(string(runes[:2*radius])) + "…"
+		}
+		return html.EscapeString(text)
+	}
 
-	query.Count(&total)
+	start := index - radius
+	if start < 0 {
+		start = 0
+	}
+	end := index + len(termRunes) + radius
+	if end > len(runes) {
+		end = len(runes)
+	}
 
-	offset := (page - 1) * limit
-	err := query.Preload("Author").
-		Order("created_at DESC").
-		Offset(offset).Limit(limit).
-		Find(&reports).Error
+	snippet := html.EscapeString(string(runes[start:index])) +
+		"<mark>" + html.EscapeString(string(runes[index:index+len(termRunes)])) + "</mark>" +
+		html.EscapeString(string(runes[index+len(termRunes):end]))
 
-	return reports, total, err
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
 }
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/storage"
+	"devops-chaos-backend/internal/utils"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"time"
+)
+
+type ResistanceContentService struct {
+	contentDAO *dao.ResistanceContentDAO
+	store      storage.BlobStore
+	maxBytes   int64
+}
+
+func NewResistanceContentService(store storage.BlobStore, maxBytes int64) *ResistanceContentService {
+	return &ResistanceContentService{
+		contentDAO: dao.NewResistanceContentDAO(),
+		store:      store,
+		maxBytes:   maxBytes,
+	}
+}
+
+// Enviar consejo (queda pendiente de moderación)
+func (s *ResistanceContentService) SubmitTip(req *dto.CreateSurvivalTipRequest, userID uint) (*dto.SurvivalTip, error) {
+	tip := &models.SurvivalTip{
+		Title:         strings.TrimSpace(req.Title),
+		Description:   strings.TrimSpace(req.Description),
+		Priority:      req.Priority,
+		Category:      req.Category,
+		SubmittedByID: &userID,
+		Status:        models.ContentStatusPending,
+	}
+
+	if err := s.contentDAO.CreateTip(tip); err != nil {
+		return nil, err
+	}
+
+	return convertToTipResponse(tip, true), nil
+}
+
+// Enviar meme con imagen (queda pendiente de moderación)
+func (s *ResistanceContentService) SubmitMeme(ctx context.Context, req *dto.CreateResistanceMemeRequest, file *multipart.FileHeader, userID uint) (*dto.ResistanceMeme, error) {
+	data, contentType, err := readImage(file, s.maxBytes, "Memes must be PNG, JPEG or GIF images")
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := newStorageKey("memes")
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := s.store.Put(ctx, key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	meme := &models.ResistanceMeme{
+		Title:         strings.TrimSpace(req.Title),
+		Description:   strings.TrimSpace(req.Description),
+		ImageKey:      key,
+		ImageType:     contentType,
+		ImageSize:     size,
+		SubmittedByID: &userID,
+		Status:        models.ContentStatusPending,
+	}
+
+	if err := s.contentDAO.CreateMeme(meme); err != nil {
+		s.store.Delete(ctx, key) // No dejar blobs huérfanos
+		return nil, err
+	}
+
+	return convertToMemeResponse(meme, true), nil
+}
+
+// Listar consejos: los network admins solo ven los aprobados
+func (s *ResistanceContentService) GetTips(params dto.ListParams, userRole string) (*dto.PaginatedResponse, error) {
+	query := dao.NewListQuery(params)
+	moderator := isContentModerator(userRole)
+	if !moderator {
+		query.Scope(dao.ContentWithStatus(models.ContentStatusApproved))
+	}
+
+	tips, page, err := s.contentDAO.ListTips(query)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.SurvivalTip, len(tips))
+	for i := range tips {
+		items[i] = *convertToTipResponse(&tips[i], moderator)
+	}
+
+	return page.ToResponse("Survival tips retrieved successfully", items), nil
+}
+
+// Listar memes: los network admins solo ven los aprobados
+func (s *ResistanceContentService) GetMemes(params dto.ListParams, userRole string) (*dto.PaginatedResponse, error) {
+	query := dao.NewListQuery(params)
+	moderator := isContentModerator(userRole)
+	if !moderator {
+		query.Scope(dao.ContentWithStatus(models.ContentStatusApproved))
+	}
+
+	memes, page, err := s.contentDAO.ListMemes(query)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ResistanceMeme, len(memes))
+	for i := range memes {
+		items[i] = *convertToMemeResponse(&memes[i], moderator)
+	}
+
+	return page.ToResponse("Resistance memes retrieved successfully", items), nil
+}
+
+// Votar un consejo aprobado (una vez por usuario)
+func (s *ResistanceContentService) VoteTip(tipID, userID uint) (*dto.SurvivalTip, error) {
+	tip, err := s.contentDAO.FindTipByID(tipID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "tip_not_found", "Survival tip not found")
+	}
+	if tip.Status != models.ContentStatusApproved {
+		return nil, apperrors.NotFound("tip_not_found", "Survival tip not found")
+	}
+
+	if err := s.vote(models.ContentTypeTip, tip.ID, userID); err != nil {
+		return nil, err
+	}
+
+	tip.Upvotes++
+	return convertToTipResponse(tip, false), nil
+}
+
+// Votar un meme aprobado (una vez por usuario)
+func (s *ResistanceContentService) VoteMeme(memeID, userID uint) (*dto.ResistanceMeme, error) {
+	meme, err := s.contentDAO.FindMemeByID(memeID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "meme_not_found", "Meme not found")
+	}
+	if meme.Status != models.ContentStatusApproved {
+		return nil, apperrors.NotFound("meme_not_found", "Meme not found")
+	}
+
+	if err := s.vote(models.ContentTypeMeme, meme.ID, userID); err != nil {
+		return nil, err
+	}
+
+	meme.Upvotes++
+	return convertToMemeResponse(meme, false), nil
+}
+
+// Moderar consejo (Andrei o moderador)
+func (s *ResistanceContentService) ModerateTip(tipID uint, req *dto.ModerateContentRequest, userRole string) (*dto.SurvivalTip, error) {
+	if !isContentModerator(userRole) {
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei or moderators can moderate content")
+	}
+
+	tip, err := s.contentDAO.FindTipByID(tipID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "tip_not_found", "Survival tip not found")
+	}
+
+	now := time.Now()
+	tip.Status = req.Status
+	tip.ModerationReason = strings.TrimSpace(req.Reason)
+	tip.ModeratedAt = &now
+
+	if err := s.contentDAO.UpdateTip(tip); err != nil {
+		return nil, err
+	}
+
+	return convertToTipResponse(tip, true), nil
+}
+
+// Moderar meme (Andrei o moderador)
+func (s *ResistanceContentService) ModerateMeme(memeID uint, req *dto.ModerateContentRequest, userRole string) (*dto.ResistanceMeme, error) {
+	if !isContentModerator(userRole) {
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei or moderators can moderate content")
+	}
+
+	meme, err := s.contentDAO.FindMemeByID(memeID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "meme_not_found", "Meme not found")
+	}
+
+	now := time.Now()
+	meme.Status = req.Status
+	meme.ModerationReason = strings.TrimSpace(req.Reason)
+	meme.ModeratedAt = &now
+
+	if err := s.contentDAO.UpdateMeme(meme); err != nil {
+		return nil, err
+	}
+
+	return convertToMemeResponse(meme, true), nil
+}
+
+// Abrir la imagen de un meme; el llamador debe cerrar el reader
+func (s *ResistanceContentService) OpenMemeImage(ctx context.Context, memeID uint, userRole string) (*models.ResistanceMeme, io.ReadCloser, error) {
+	meme, err := s.contentDAO.FindMemeByID(memeID)
+	if err != nil {
+		return nil, nil, apperrors.NotFoundOrInternal(err, "meme_not_found", "Meme not found")
+	}
+
+	if meme.ImageKey == "" || (meme.Status != models.ContentStatusApproved && !isContentModerator(userRole)) {
+		return nil, nil, apperrors.NotFound("meme_not_found", "Meme not found")
+	}
+
+	reader, err := s.store.Open(ctx, meme.ImageKey)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, nil, apperrors.NotFound("meme_not_found", "Meme image is missing")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return meme, reader, nil
+}
+
+func (s *ResistanceContentService) vote(contentType string, contentID, userID uint) error {
+	voted, err := s.contentDAO.Vote(contentType, contentID, userID)
+	if err != nil {
+		return err
+	}
+	if !voted {
+		return apperrors.Conflict("already_voted", "You have already upvoted this content")
+	}
+	return nil
+}
+
+// readImage valida que el archivo sea una imagen y le quita los metadatos; la
+// comparten memes y avatares
+func readImage(file *multipart.FileHeader, maxBytes int64, unsupportedMessage string) ([]byte, string, error) {
+	if file.Size > maxBytes {
+		return nil, "", apperrors.TooLarge("file_too_large", fmt.Sprintf("File exceeds the %d byte limit", maxBytes))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, "", apperrors.Validation("invalid_file", "Could not read uploaded file")
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxBytes+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, "", apperrors.TooLarge("file_too_large", fmt.Sprintf("File exceeds the %d byte limit", maxBytes))
+	}
+
+	contentType, _, ok := utils.DetectEvidenceType(data, file.Filename)
+	if !ok || !strings.HasPrefix(contentType, "image/") {
+		return nil, "", apperrors.Validation("unsupported_file_type", unsupportedMessage)
+	}
+
+	data, err = utils.StripMetadata(contentType, data)
+	if errors.Is(err, utils.ErrMalformedFile) {
+		return nil, "", apperrors.Validation("invalid_file", "Image is corrupted or malformed")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, contentType, nil
+}
+
+func isContentModerator(userRole string) bool {
+	return userRole == models.RoleAndrei || userRole == models.RoleModerator
+}
+
+func convertToTipResponse(tip *models.SurvivalTip, withStatus bool) *dto.SurvivalTip {
+	response := &dto.SurvivalTip{
+		ID:          tip.ID,
+		Title:       tip.Title,
+		Description: tip.Description,
+		Priority:    tip.Priority,
+		Category:    tip.Category,
+		Upvotes:     tip.Upvotes,
+	}
+	if withStatus {
+		response.Status = tip.Status
+	}
+	return response
+}
+
+func convertToMemeResponse(meme *models.ResistanceMeme, withStatus bool) *dto.ResistanceMeme {
+	imageURL := meme.ImageURL
+	if meme.ImageKey != "" {
+		imageURL = fmt.Sprintf("/api/resistance/memes/%d/image", meme.ID)
+	}
+
+	response := &dto.ResistanceMeme{
+		ID:          meme.ID,
+		Title:       meme.Title,
+		ImageURL:    imageURL,
+		Description: meme.Description,
+		Upvotes:     meme.Upvotes,
+	}
+	if withStatus {
+		response.Status = meme.Status
+	}
+	return response
+}
@@ -1,34 +1,67 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
 	"devops-chaos-backend/internal/dao"
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/models"
-	"errors"
+	"devops-chaos-backend/internal/utils"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// Distancia máxima entre SimHash para considerar un reporte casi duplicado
+	nearDuplicateDistance = 10
+	// Notas de seguimiento que un remitente puede agregar a su reporte
+	maxReporterNotes = 20
+	// Días de la tendencia de la página de resistencia
+	trendDays = 30
+	// Contenido destacado en la página de resistencia
+	resistancePageTips  = 5
+	resistancePageMemes = 3
 )
 
 type ResistanceService struct {
-	reportDAO *dao.ReportDAO
-	userDAO   *dao.UserDAO
+	reportDAO  *dao.ReportDAO
+	reviewDAO  *dao.ReportReviewDAO
+	userDAO    *dao.UserDAO
+	captureDAO *dao.CaptureDAO
+	contentDAO *dao.ResistanceContentDAO
+	guard      *AnonymousGuard
+	bus        *events.Bus
 }
 
-func NewResistanceService() *ResistanceService {
+func NewResistanceService(guard *AnonymousGuard, bus *events.Bus) *ResistanceService {
 	return &ResistanceService{
-		reportDAO: dao.NewReportDAO(),
-		userDAO:   dao.NewUserDAO(),
+		reportDAO:  dao.NewReportDAO(),
+		reviewDAO:  dao.NewReportReviewDAO(),
+		userDAO:    dao.NewUserDAO(),
+		captureDAO: dao.NewCaptureDAO(),
+		contentDAO: dao.NewResistanceContentDAO(),
+		guard:      guard,
+		bus:        bus,
 	}
 }
 
 // Obtener página de resistencia para Network Admins
 func (s *ResistanceService) GetResistancePage(userRole string) (*dto.ResistancePageResponse, error) {
 	if userRole != models.RoleNetworkAdmin {
-		return nil, errors.New("unauthorized: only network admins can access resistance page")
+		return nil, apperrors.Forbidden("insufficient_role", "Only network admins can access resistance page")
 	}
 
-	// Contar reportes anónimos de hoy (simplificado)
-	anonymousReports, err := s.reportDAO.CountByStatus(models.ReportTypeAnonymous)
+	// Total de reportes anónimos de la resistencia (no se puede saber quién envió cuáles)
+	anonymousReports, err := s.reportDAO.CountReports(dao.ReportCountFilter{Type: models.ReportTypeAnonymous})
 	if err != nil {
-		anonymousReports = 0
+		return nil, err
 	}
 
 	// Estadísticas de resistencia
@@ -37,34 +70,206 @@ func (s *ResistanceService) GetResistancePage(userRole string) (*dto.ResistanceP
 		return nil, err
 	}
 
+	// Contenido de la comunidad
+	survivalTips, err := s.getSurvivalTips()
+	if err != nil {
+		return nil, err
+	}
+
+	resistanceMemes, err := s.getResistanceMemes()
+	if err != nil {
+		return nil, err
+	}
+
+	emergencyContacts, err := s.getEmergencyContacts()
+	if err != nil {
+		return nil, err
+	}
+
 	response := &dto.ResistancePageResponse{
 		WelcomeMessage:       "Stay strong, fellow admin. The resistance continues...",
 		UserStatus:           "Active Resistance Member",
-		SurvivalTips:         s.getSurvivalTips(),
-		ResistanceMemes:      s.getResistanceMemes(),
+		SurvivalTips:         survivalTips,
+		ResistanceMemes:      resistanceMemes,
 		AnonymousReportCount: int(anonymousReports),
 		ResistanceStats:      *resistanceStats,
-		EmergencyContacts:    s.getEmergencyContacts(),
+		EmergencyContacts:    emergencyContacts,
 	}
 
 	return response, nil
 }
 
-// Reportar actividad sospechosa de forma anónima
-func (s *ResistanceService) ReportSuspiciousActivity(req *dto.ReportSuspiciousActivityRequest) error {
+// Reto previo a un reporte anónimo
+func (s *ResistanceService) GetSubmissionChallenge(userID uint) (*dto.SubmissionChallenge, error) {
+	return s.guard.IssueChallenge(userID)
+}
+
+// Reportar actividad sospechosa de forma anónima. userID solo se usa en memoria
+// para el límite de envíos; nunca se guarda junto al reporte
+func (s *ResistanceService) ReportSuspiciousActivity(req *dto.ReportSuspiciousActivityRequest, userID uint) (*dto.AnonymousReportReceipt, error) {
+	if err := s.guard.CheckSubmission(userID, req.Ticket, req.Nonce); err != nil {
+		return nil, err
+	}
+
+	contentHash, simHash := utils.ContentFingerprint(req.Title, req.Description)
+	if err := s.checkDuplicate(contentHash, simHash); err != nil {
+		return nil, err
+	}
+
+	code, err := newReceiptCode()
+	if err != nil {
+		return nil, err
+	}
+
 	report := &models.Report{
 		Title:       req.Title,
 		Description: req.Description,
 		Type:        models.ReportTypeAnonymous,
 		AuthorID:    nil, // Anónimo
 		Status:      models.ReportStatusPending,
+		Severity:    req.Severity,
+		Location:    strings.TrimSpace(req.Location),
+		ContentHash: contentHash,
+		SimHash:     int64(simHash),
+	}
+
+	// Solo se guarda el hash: el código es la única forma de volver al reporte
+	err = s.reportDAO.CreateWithReceipt(report, hashReceiptCode(code), s.bus.Writer(func() []events.Event {
+		return []events.Event{events.ReportSubmitted{ReportInfo: reportInfo(report)}}
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	s.bus.Notify()
+	return &dto.AnonymousReportReceipt{ReceiptCode: code}, nil
+}
+
+// Consultar el estado de un reporte anónimo con su código de recibo
+func (s *ResistanceService) GetReceiptStatus(req *dto.ReceiptLookupRequest) (*dto.ReceiptStatusResponse, error) {
+	report, err := s.findByReceipt(req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.reviewDAO.FindComments(report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ReportCommentResponse, len(comments))
+	for i := range comments {
+		items[i] = *convertToCommentResponse(&comments[i])
+	}
+
+	return &dto.ReceiptStatusResponse{
+		Title:           report.Title,
+		Severity:        report.Severity,
+		Status:          report.Status,
+		RejectionReason: report.RejectionReason,
+		CreatedAt:       report.CreatedAt,
+		UpdatedAt:       report.UpdatedAt,
+		Comments:        items,
+	}, nil
+}
+
+// Agregar una nota de seguimiento sin autor usando el código de recibo
+func (s *ResistanceService) AddReceiptNote(req *dto.ReceiptNoteRequest) (*dto.ReportCommentResponse, error) {
+	report, err := s.findByReceipt(req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Status == models.ReportStatusApproved || report.Status == models.ReportStatusRejected {
+		return nil, apperrors.Conflict("report_closed", "This report has already been decided")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, apperrors.Validation("empty_comment", "Note body cannot be empty")
+	}
+
+	count, err := s.reviewDAO.CountReporterNotes(report.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxReporterNotes {
+		return nil, apperrors.Conflict("note_limit_reached",
+			fmt.Sprintf("A report can have at most %d follow-up notes", maxReporterNotes))
+	}
+
+	note := &models.ReportComment{
+		ReportID: report.ID,
+		AuthorID: nil, // Remitente anónimo
+		Body:     body,
+	}
+	if err := s.reviewDAO.CreateComment(note); err != nil {
+		return nil, err
 	}
 
-	return s.reportDAO.Create(report)
+	return convertToCommentResponse(note), nil
 }
 
 // Helper methods
 
+func (s *ResistanceService) findByReceipt(code string) (*models.Report, error) {
+	report, err := s.reportDAO.FindByReceiptHash(hashReceiptCode(code))
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "receipt_not_found", "No report matches this receipt code")
+	}
+	return report, nil
+}
+
+// newReceiptCode genera 160 bits aleatorios en base32, agrupados de a 4 caracteres
+func newReceiptCode() (string, error) {
+	raw := make([]byte, 20)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw)
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+// hashReceiptCode normaliza el código (mayúsculas, sin guiones ni espacios) y lo hashea
+func hashReceiptCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, code)
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkDuplicate rechaza reportes idénticos o casi idénticos a otros anónimos recientes
+func (s *ResistanceService) checkDuplicate(contentHash string, simHash uint64) error {
+	since := time.Now().Add(-s.guard.DuplicateWindow())
+	fingerprints, err := s.reportDAO.FindFingerprintsSince(models.ReportTypeAnonymous, since)
+	if err != nil {
+		return err
+	}
+
+	for _, fingerprint := range fingerprints {
+		if isDuplicateContent(fingerprint, contentHash, simHash) {
+			return apperrors.Conflict("duplicate_report", "A similar anonymous report was already submitted recently")
+		}
+	}
+	return nil
+}
+
+// isDuplicateContent indica si el contenido es idéntico o casi idéntico al de la huella
+func isDuplicateContent(fingerprint dao.ReportFingerprint, contentHash string, simHash uint64) bool {
+	return fingerprint.ContentHash == contentHash ||
+		utils.HammingDistance(uint64(fingerprint.SimHash), simHash) <= nearDuplicateDistance
+}
+
 func (s *ResistanceService) getResistanceStats() (*dto.ResistanceStats, error) {
 	totalNetAdmins, err := s.userDAO.CountByRole(models.RoleNetworkAdmin)
 	if err != nil {
@@ -78,102 +283,115 @@ func (s *ResistanceService) getResistanceStats() (*dto.ResistanceStats, error) {
 
 	freeAdmins := totalNetAdmins - capturedAdmins
 
-	// Reportes anónimos de hoy (simplificado)
-	anonymousReports, _ := s.reportDAO.CountByStatus(models.ReportTypeAnonymous)
+	// Ventanas de tiempo en la zona horaria configurada
+	location := config.StatsLocation()
+	now := time.Now()
+	today := dao.StartOfDay(now, location)
+	week := dao.StartOfWeek(now, location)
+	tomorrow := today.AddDate(0, 0, 1)
+	trendStart := today.AddDate(0, 0, -(trendDays - 1))
+
+	anonymous := dao.ReportCountFilter{Type: models.ReportTypeAnonymous}
+
+	anonymous.Range = dao.Between(today, tomorrow)
+	anonymousToday, err := s.reportDAO.CountReports(anonymous)
+	if err != nil {
+		return nil, err
+	}
+
+	anonymous.Range = dao.Between(week, tomorrow)
+	anonymousWeek, err := s.reportDAO.CountReports(anonymous)
+	if err != nil {
+		return nil, err
+	}
+
+	capturesToday, err := s.captureDAO.CountInRange(dao.Between(today, tomorrow))
+	if err != nil {
+		return nil, err
+	}
+
+	capturesWeek, err := s.captureDAO.CountInRange(dao.Between(week, tomorrow))
+	if err != nil {
+		return nil, err
+	}
+
+	reportTrend, err := s.reportDAO.CountReportsByDay(anonymous, trendStart, tomorrow, location)
+	if err != nil {
+		return nil, err
+	}
+
+	captureTrend, err := s.captureDAO.CountByDay(trendStart, tomorrow, location)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ambas series cubren los mismos días
+	trend := make([]dto.DailyTrendPoint, len(reportTrend))
+	for i, point := range reportTrend {
+		trend[i] = dto.DailyTrendPoint{
+			Date:             point.Day.Format("2006-01-02"),
+			AnonymousReports: int(point.Count),
+		}
+		if i < len(captureTrend) {
+			trend[i].Captures = int(captureTrend[i].Count)
+		}
+	}
 
 	return &dto.ResistanceStats{
-		TotalNetworkAdmins: int(totalNetAdmins),
-		CapturedAdmins:     int(capturedAdmins),
-		FreeAdmins:         int(freeAdmins),
-		AnonymousReports:   int(anonymousReports),
+		TotalNetworkAdmins:   int(totalNetAdmins),
+		CapturedAdmins:       int(capturedAdmins),
+		FreeAdmins:           int(freeAdmins),
+		AnonymousReports:     int(anonymousToday),
+		AnonymousReportsWeek: int(anonymousWeek),
+		CapturesToday:        int(capturesToday),
+		CapturesWeek:         int(capturesWeek),
+		Trend:                trend,
+		Timezone:             location.String(),
 	}, nil
 }
 
-func (s *ResistanceService) getSurvivalTips() []dto.SurvivalTip {
-	return []dto.SurvivalTip{
-		{
-			ID:          1,
-			Title:       "Change Default Passwords",
-			Description: "Always change default credentials on all network equipment immediately after installation.",
-			Priority:    "critical",
-			Category:    "security",
-		},
-		{
-			ID:          2,
-			Title:       "Monitor Network Traffic",
-			Description: "Keep constant watch on unusual network patterns that might indicate daemon infiltration.",
-			Priority:    "high",
-			Category:    "technical",
-		},
-		{
-			ID:          3,
-			Title:       "Trust No One",
-			Description: "Verify the identity of all personnel requesting network access, even familiar faces.",
-			Priority:    "high",
-			Category:    "social",
-		},
-		{
-			ID:          4,
-			Title:       "Backup Everything",
-			Description: "Maintain secure offline backups of critical configurations and data.",
-			Priority:    "medium",
-			Category:    "technical",
-		},
-		{
-			ID:          5,
-			Title:       "Use Anonymous Reporting",
-			Description: "Report suspicious activities through secure anonymous channels to avoid detection.",
-			Priority:    "medium",
-			Category:    "security",
-		},
-	}
-}
-
-func (s *ResistanceService) getResistanceMemes() []dto.ResistanceMeme {
-	return []dto.ResistanceMeme{
-		{
-			ID:          1,
-			Title:       "This is Fine Network Admin",
-			ImageURL:    "/static/memes/this-is-fine-netadmin.jpg",
-			Description: "When the firewall is down but you're still monitoring logs",
-			Upvotes:     42,
-		},
-		{
-			ID:          2,
-			Title:       "Distracted Daemon",
-			ImageURL:    "/static/memes/distracted-daemon.jpg",
-			Description: "Daemon looking at new vulnerability while network admin patches old ones",
-			Upvotes:     38,
-		},
-		{
-			ID:          3,
-			Title:       "Drake Network Security",
-			ImageURL:    "/static/memes/drake-netsec.jpg",
-			Description: "Drake rejecting easy passwords, approving 2FA",
-			Upvotes:     56,
-		},
-	}
-}
-
-func (s *ResistanceService) getEmergencyContacts() []dto.EmergencyContact {
-	return []dto.EmergencyContact{
-		{
-			Name:        "Anonymous Tip Line",
-			Role:        "Intelligence Gathering",
-			Contact:     "Signal: +1-XXX-RESIST",
-			Description: "Secure channel for reporting daemon activities",
-		},
-		{
-			Name:        "Emergency Response Team",
-			Role:        "Incident Response",
-			Contact:     "Encrypted Email: emergency@resistance.net",
-			Description: "24/7 incident response for critical security breaches",
-		},
-		{
-			Name:        "Safe House Network",
-			Role:        "Safe Harbor",
-			Contact:     "Tor: safehouse.onion",
-			Description: "Secure locations for compromised network admins",
-		},
+// Consejos aprobados mejor votados
+func (s *ResistanceService) getSurvivalTips() ([]dto.SurvivalTip, error) {
+	tips, err := s.contentDAO.FindTopTips(resistancePageTips)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.SurvivalTip, len(tips))
+	for i := range tips {
+		items[i] = *convertToTipResponse(&tips[i], false)
+	}
+	return items, nil
+}
+
+// Memes aprobados mejor votados
+func (s *ResistanceService) getResistanceMemes() ([]dto.ResistanceMeme, error) {
+	memes, err := s.contentDAO.FindTopMemes(resistancePageMemes)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ResistanceMeme, len(memes))
+	for i := range memes {
+		items[i] = *convertToMemeResponse(&memes[i], false)
+	}
+	return items, nil
+}
+
+func (s *ResistanceService) getEmergencyContacts() ([]dto.EmergencyContact, error) {
+	contacts, err := s.contentDAO.FindContacts()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.EmergencyContact, len(contacts))
+	for i, contact := range contacts {
+		items[i] = dto.EmergencyContact{
+			Name:        contact.Name,
+			Role:        contact.Role,
+			Contact:     contact.Contact,
+			Description: contact.Description,
+		}
 	}
+	return items, nil
 }
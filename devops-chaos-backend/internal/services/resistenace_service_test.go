@@ -0,0 +1,66 @@
+package services
+
+import (
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/utils"
+	"testing"
+)
+
+func TestIsDuplicateContent(t *testing.T) {
+	const (
+		title       = "Daemon patrol"
+		description = "A daemon patrol was seen near the east server room at 2am, moving towards the bastion host."
+	)
+	hash, sim := utils.ContentFingerprint(title, description)
+	stored := dao.ReportFingerprint{ID: 1, ContentHash: hash, SimHash: int64(sim)}
+
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		want        bool
+	}{
+		{"identical", title, description, true},
+		{"case and punctuation", "daemon PATROL!", "A daemon patrol was seen near the east server room at 2am, moving towards the bastion host!!", true},
+		{"one word changed", title, "A daemon patrol was seen near the east server room at 3am, moving towards the bastion host.", true},
+		{"one word dropped", title, "A daemon patrol was seen near the east server room at 2am, moving towards the bastion.", true},
+		{"unrelated report", "Coffee machine", "The coffee machine on floor three is broken again and someone left a note blaming the daemons for it.", false},
+		{"unrelated report sharing vocabulary", "Squad meeting", "Squad leaders meet on friday to discuss the leaderboard and the new capture difficulty rules.", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentHash, simHash := utils.ContentFingerprint(tt.title, tt.description)
+			if got := isDuplicateContent(stored, contentHash, simHash); got != tt.want {
+				t.Fatalf("isDuplicateContent = %v, want %v (distance %d)",
+					got, tt.want, utils.HammingDistance(sim, simHash))
+			}
+		})
+	}
+}
+
+func TestIsDuplicateContentThreshold(t *testing.T) {
+	base := uint64(0xF0F0_F0F0_F0F0_F0F0)
+	stored := dao.ReportFingerprint{ContentHash: "stored", SimHash: int64(base)}
+
+	tests := []struct {
+		flippedBits int
+		want        bool
+	}{
+		{0, true},
+		{nearDuplicateDistance - 1, true},
+		{nearDuplicateDistance, true},
+		{nearDuplicateDistance + 1, false},
+		{64, false},
+	}
+
+	for _, tt := range tests {
+		mask := uint64(1)<<tt.flippedBits - 1
+		if tt.flippedBits == 64 {
+			mask = ^uint64(0)
+		}
+		if got := isDuplicateContent(stored, "other", base^mask); got != tt.want {
+			t.Errorf("distance %d: isDuplicateContent = %v, want %v", tt.flippedBits, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/utils"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Motivos de SessionsRevoked
+const (
+	SessionsRevokedOne             = "revoked"
+	SessionsRevokedOthers          = "others_revoked"
+	SessionsRevokedPasswordChanged = "password_changed"
+)
+
+const maxUserAgentLength = 255
+
+// ClientInfo identifica el dispositivo que inicia sesión
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+type SessionService struct {
+	sessionDAO     *dao.SessionDAO
+	emailChangeDAO *dao.EmailChangeDAO
+	bus            *events.Bus
+}
+
+func NewSessionService(bus *events.Bus) *SessionService {
+	return &SessionService{
+		sessionDAO:     dao.NewSessionDAO(),
+		emailChangeDAO: dao.NewEmailChangeDAO(),
+		bus:            bus,
+	}
+}
+
+// Listar las sesiones vigentes del usuario marcando la actual
+func (s *SessionService) GetSessions(userID, currentSessionID uint) ([]dto.SessionResponse, error) {
+	sessions, err := s.sessionDAO.FindActiveByUser(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		response[i] = dto.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			Current:    session.ID == currentSessionID,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+		}
+	}
+	return response, nil
+}
+
+// Cerrar una sesión propia; puede ser la actual
+func (s *SessionService) RevokeSession(userID, sessionID uint) error {
+	err := s.sessionDAO.Revoke(userID, sessionID, time.Now(), s.bus.Writer(func() []events.Event {
+		return []events.Event{events.SessionsRevoked{UserID: userID, Count: 1, Reason: SessionsRevokedOne}}
+	}))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.NotFound("session_not_found", "Session not found")
+	}
+	if err != nil {
+		return err
+	}
+	s.bus.Notify()
+	return nil
+}
+
+// Cerrar todas las sesiones propias salvo la actual
+func (s *SessionService) RevokeOtherSessions(userID, currentSessionID uint) (int64, error) {
+	return revokeOtherSessions(s.sessionDAO, s.bus, userID, currentSessionID, SessionsRevokedOthers)
+}
+
+// Borrar sesiones y cambios de email vencidos (worker periódico)
+func (s *SessionService) PurgeExpired(ctx context.Context) {
+	now := time.Now()
+	sessions, err := s.sessionDAO.PurgeExpired(now)
+	if err != nil {
+		log.Printf("Failed to purge expired sessions: %v", err)
+	}
+	changes, err := s.emailChangeDAO.PurgeExpired(now)
+	if err != nil {
+		log.Printf("Failed to purge expired email changes: %v", err)
+	}
+	if sessions > 0 || changes > 0 {
+		log.Printf("Purged %d expired sessions and %d expired email changes", sessions, changes)
+	}
+}
+
+// revokeOtherSessions lo comparten el cierre manual y el cambio de contraseña
+func revokeOtherSessions(sessionDAO *dao.SessionDAO, bus *events.Bus, userID, keepID uint, reason string) (int64, error) {
+	revoked, err := sessionDAO.RevokeOthers(userID, keepID, time.Now(), func(count int64) dao.OutboxWriter {
+		return bus.Writer(func() []events.Event {
+			return []events.Event{events.SessionsRevoked{UserID: userID, Count: count, Reason: reason}}
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	if revoked > 0 {
+		bus.Notify()
+	}
+	return revoked, nil
+}
+
+// startSession abre una sesión y firma su token
+func startSession(user *models.User, client ClientInfo) (string, error) {
+	tokenID, err := newSessionTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+	session := &models.Session{
+		UserID:     user.ID,
+		TokenID:    tokenID,
+		UserAgent:  userAgent,
+		IPAddress:  client.IPAddress,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(utils.TokenTTL),
+	}
+	if err := dao.NewSessionDAO().Create(session); err != nil {
+		return "", err
+	}
+
+	return utils.GenerateToken(user.ID, user.Username, user.Role, tokenID, session.ExpiresAt)
+}
+
+func newSessionTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
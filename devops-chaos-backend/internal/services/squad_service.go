@@ -0,0 +1,282 @@
+package services
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/models"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SquadService struct {
+	squadDAO *dao.SquadDAO
+	userDAO  *dao.UserDAO
+}
+
+func NewSquadService() *SquadService {
+	return &SquadService{
+		squadDAO: dao.NewSquadDAO(),
+		userDAO:  dao.NewUserDAO(),
+	}
+}
+
+// Crear escuadrón; si se indica líder se lo suma como miembro
+func (s *SquadService) CreateSquad(req *dto.CreateSquadRequest) (*dto.SquadResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	exists, err := s.squadDAO.ExistsByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperrors.Conflict("squad_name_taken", "A squad with that name already exists")
+	}
+
+	if req.LeaderID != nil {
+		if err := s.checkRecruit(*req.LeaderID); err != nil {
+			return nil, err
+		}
+	}
+
+	squad := &models.Squad{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+	}
+	if err := s.squadDAO.Create(squad); err != nil {
+		return nil, err
+	}
+
+	if req.LeaderID != nil {
+		if err := s.squadDAO.AddMember(&models.SquadMember{SquadID: squad.ID, UserID: *req.LeaderID}); err != nil {
+			return nil, err
+		}
+		squad.LeaderID = req.LeaderID
+		if err := s.squadDAO.Update(squad); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetSquad(squad.ID)
+}
+
+// Listar escuadrones
+func (s *SquadService) GetSquads() ([]dto.SquadResponse, error) {
+	squads, err := s.squadDAO.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.SquadResponse, len(squads))
+	for i := range squads {
+		response[i] = *convertToSquadResponse(&squads[i])
+	}
+	return response, nil
+}
+
+// Obtener escuadrón por ID
+func (s *SquadService) GetSquad(id uint) (*dto.SquadResponse, error) {
+	squad, err := s.squadDAO.FindByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "squad_not_found", "Squad not found")
+	}
+	return convertToSquadResponse(squad), nil
+}
+
+// Actualizar nombre, descripción o líder; el líder debe ser miembro
+func (s *SquadService) UpdateSquad(id uint, req *dto.UpdateSquadRequest) (*dto.SquadResponse, error) {
+	squad, err := s.squadDAO.FindByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "squad_not_found", "Squad not found")
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if !strings.EqualFold(name, squad.Name) {
+			exists, err := s.squadDAO.ExistsByName(name)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, apperrors.Conflict("squad_name_taken", "A squad with that name already exists")
+			}
+		}
+		squad.Name = name
+	}
+
+	if req.Description != nil {
+		squad.Description = strings.TrimSpace(*req.Description)
+	}
+
+	if req.LeaderID != nil {
+		if !isSquadMember(squad, *req.LeaderID) {
+			return nil, apperrors.Validation("invalid_squad_leader", "Squad leader must be a member of the squad")
+		}
+		squad.LeaderID = req.LeaderID
+	}
+
+	if err := s.squadDAO.Update(squad); err != nil {
+		return nil, err
+	}
+	return s.GetSquad(squad.ID)
+}
+
+// Disolver escuadrón
+func (s *SquadService) DeleteSquad(id uint) error {
+	if _, err := s.squadDAO.FindByID(id); err != nil {
+		return apperrors.NotFoundOrInternal(err, "squad_not_found", "Squad not found")
+	}
+	return s.squadDAO.Delete(id)
+}
+
+// Sumar un daemon al escuadrón
+func (s *SquadService) AddMember(squadID uint, req *dto.AddSquadMemberRequest) (*dto.SquadResponse, error) {
+	if _, err := s.squadDAO.FindByID(squadID); err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "squad_not_found", "Squad not found")
+	}
+
+	if err := s.checkRecruit(req.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := s.squadDAO.AddMember(&models.SquadMember{SquadID: squadID, UserID: req.UserID}); err != nil {
+		return nil, err
+	}
+	return s.GetSquad(squadID)
+}
+
+// Sacar a un daemon del escuadrón
+func (s *SquadService) RemoveMember(squadID, userID uint) error {
+	squad, err := s.squadDAO.FindByID(squadID)
+	if err != nil {
+		return apperrors.NotFoundOrInternal(err, "squad_not_found", "Squad not found")
+	}
+	if !isSquadMember(squad, userID) {
+		return apperrors.NotFound("squad_member_not_found", "Member not found in this squad")
+	}
+	return s.squadDAO.RemoveMember(squadID, userID)
+}
+
+// Leaderboard de escuadrones
+func (s *SquadService) GetLeaderboard(limit int) ([]dto.SquadRankingItem, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	rows, err := s.squadDAO.FindLeaderboard(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard := make([]dto.SquadRankingItem, len(rows))
+	for i, row := range rows {
+		leaderboard[i] = dto.SquadRankingItem{
+			Position:      i + 1,
+			SquadID:       row.SquadID,
+			Name:          row.Name,
+			MemberCount:   row.MemberCount,
+			CapturesCount: row.Captures,
+			ReportsCount:  row.Reports,
+			Points:        row.Points,
+		}
+	}
+	return leaderboard, nil
+}
+
+// Puntos semanales de cada escuadrón en las últimas semanas, completando con ceros
+func (s *SquadService) GetWeeklyPerformance(weeks int) ([]dto.SquadPerformance, error) {
+	location := config.StatsLocation()
+	to := dao.StartOfWeek(time.Now(), location).AddDate(0, 0, 7) // Incluye la semana en curso
+	from := to.AddDate(0, 0, -7*weeks)
+
+	squads, err := s.squadDAO.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.squadDAO.SumPointsByWeek(from, to, location)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make(map[uint]map[string]int, len(squads))
+	for _, row := range rows {
+		if points[row.SquadID] == nil {
+			points[row.SquadID] = make(map[string]int)
+		}
+		points[row.SquadID][row.Week.Format("2006-01-02")] = row.Points
+	}
+
+	performance := make([]dto.SquadPerformance, len(squads))
+	for i, squad := range squads {
+		item := dto.SquadPerformance{SquadID: squad.ID, Name: squad.Name}
+		for w := 0; w < weeks; w++ {
+			week := from.AddDate(0, 0, 7*w).Format("2006-01-02")
+			weekPoints := points[squad.ID][week]
+			item.Points += weekPoints
+			item.Weekly = append(item.Weekly, dto.SquadWeeklyPoint{WeekStart: week, Points: weekPoints})
+		}
+		performance[i] = item
+	}
+	return performance, nil
+}
+
+// Verifica que el usuario sea un daemon sin escuadrón
+func (s *SquadService) checkRecruit(userID uint) error {
+	user, err := s.userDAO.FindByID(userID)
+	if err != nil {
+		return apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+	if user.Role != models.RoleDaemon {
+		return apperrors.Validation("invalid_squad_member", "Only daemons can join a squad")
+	}
+
+	if _, err := s.squadDAO.FindMembership(userID); err == nil {
+		return apperrors.Conflict("already_in_squad", "Daemon already belongs to a squad")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func isSquadMember(squad *models.Squad, userID uint) bool {
+	for _, member := range squad.Members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func convertToSquadResponse(squad *models.Squad) *dto.SquadResponse {
+	response := &dto.SquadResponse{
+		ID:          squad.ID,
+		Name:        squad.Name,
+		Description: squad.Description,
+		Members:     make([]dto.SquadMemberInfo, len(squad.Members)),
+		CreatedAt:   squad.CreatedAt,
+	}
+
+	if squad.Leader != nil {
+		response.Leader = &dto.UserInfo{
+			ID:       squad.Leader.ID,
+			Username: squad.Leader.Username,
+			Email:    squad.Leader.Email,
+			Role:     squad.Leader.Role,
+			Status:   squad.Leader.Status,
+		}
+	}
+
+	for i, member := range squad.Members {
+		response.Members[i] = dto.SquadMemberInfo{
+			UserID:   member.UserID,
+			Username: member.User.Username,
+			Status:   member.User.Status,
+			JoinedAt: member.CreatedAt,
+		}
+	}
+	return response
+}
@@ -1,21 +1,26 @@
 package services
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/dao"
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/models"
-	"errors"
 )
 
 type StatisticsService struct {
 	statisticDAO *dao.StatisticDAO
 	userDAO      *dao.UserDAO
+	squadService *SquadService
+	bus          *events.Bus
 }
 
-func NewStatisticsService() *StatisticsService {
+func NewStatisticsService(bus *events.Bus) *StatisticsService {
 	return &StatisticsService{
 		statisticDAO: dao.NewStatisticDAO(),
 		userDAO:      dao.NewUserDAO(),
+		squadService: NewSquadService(),
+		bus:          bus,
 	}
 }
 
@@ -23,23 +28,23 @@ func NewStatisticsService() *StatisticsService {
 func (s *StatisticsService) GetUserStatistics(targetUserID, currentUserID uint, currentUserRole string) (*dto.StatisticResponse, error) {
 	// Solo Andrei o el mismo usuario pueden ver las estadísticas
 	if currentUserRole != models.RoleAndrei && currentUserID != targetUserID {
-		return nil, errors.New("unauthorized to view these statistics")
+		return nil, apperrors.Forbidden("statistics_access_denied", "Not allowed to view these statistics")
 	}
 
 	// Verificar que el usuario objetivo sea daemon
 	targetUser, err := s.userDAO.FindByID(targetUserID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
 	}
 
 	if targetUser.Role != models.RoleDaemon {
-		return nil, errors.New("statistics only available for daemons")
+		return nil, apperrors.Validation("not_a_daemon", "Statistics only available for daemons")
 	}
 
 	// Obtener estadísticas
 	stats, err := s.statisticDAO.FindByUserIDWithUser(targetUserID)
 	if err != nil {
-		return nil, errors.New("statistics not found")
+		return nil, apperrors.NotFoundOrInternal(err, "statistics_not_found", "Statistics not found")
 	}
 
 	response := &dto.StatisticResponse{
@@ -64,7 +69,7 @@ func (s *StatisticsService) GetLeaderboard(limit int, userRole string) ([]dto.Ra
 
 	// Solo Andrei y Daemons pueden ver el leaderboard
 	if userRole != models.RoleAndrei && userRole != models.RoleDaemon {
-		return nil, errors.New("unauthorized to view leaderboard")
+		return nil, apperrors.Forbidden("insufficient_role", "Not allowed to view leaderboard")
 	}
 
 	topDaemons, err := s.statisticDAO.FindTopDaemons(limit)
@@ -72,35 +77,32 @@ func (s *StatisticsService) GetLeaderboard(limit int, userRole string) ([]dto.Ra
 		return nil, err
 	}
 
-	leaderboard := make([]dto.RankingItem, len(topDaemons))
-	for i, stat := range topDaemons {
-		leaderboard[i] = dto.RankingItem{
-			Position:      i + 1, // Posición basada en orden
-			Username:      stat.User.Username,
-			CapturesCount: stat.CapturesCount,
-			ReportsCount:  stat.ReportsCount,
-			Points:        stat.Points,
-			Status:        stat.User.Status,
-		}
+	return convertToRankingItems(topDaemons), nil
+}
+
+// Leaderboard de escuadrones, con las mismas reglas de acceso que el individual
+func (s *StatisticsService) GetSquadLeaderboard(limit int, userRole string) ([]dto.SquadRankingItem, error) {
+	if userRole != models.RoleAndrei && userRole != models.RoleDaemon {
+		return nil, apperrors.Forbidden("insufficient_role", "Not allowed to view leaderboard")
 	}
 
-	return leaderboard, nil
+	return s.squadService.GetLeaderboard(limit)
 }
 
 // Actualizar estadísticas manualmente (solo Andrei)
 func (s *StatisticsService) UpdateStatistics(targetUserID uint, req *dto.UpdateStatisticRequest, currentUserRole string) error {
 	if currentUserRole != models.RoleAndrei {
-		return errors.New("unauthorized: only Andrei can update statistics")
+		return apperrors.Forbidden("insufficient_role", "Only Andrei can update statistics")
 	}
 
 	// Verificar que el usuario objetivo sea daemon
 	targetUser, err := s.userDAO.FindByID(targetUserID)
 	if err != nil {
-		return errors.New("user not found")
+		return apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
 	}
 
 	if targetUser.Role != models.RoleDaemon {
-		return errors.New("can only update daemon statistics")
+		return apperrors.Validation("not_a_daemon", "Can only update daemon statistics")
 	}
 
 	// Obtener estadísticas actuales
@@ -126,20 +128,47 @@ func (s *StatisticsService) UpdateStatistics(targetUserID uint, req *dto.UpdateS
 		stats.Points = *req.Points
 	}
 
-	err = s.statisticDAO.Update(stats)
+	err = s.statisticDAO.Update(stats, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.StatisticsUpdated{
+			UserID:        stats.UserID,
+			CapturesCount: stats.CapturesCount,
+			ReportsCount:  stats.ReportsCount,
+			Points:        stats.Points,
+		}}
+	}))
 	if err != nil {
 		return err
 	}
 
 	// Recalcular rankings después de la actualización
-	return s.statisticDAO.RecalculateRankings()
+	if err := s.statisticDAO.RecalculateRankings(); err != nil {
+		return err
+	}
+
+	s.bus.Notify()
+	return nil
 }
 
 // Recalcular rankings (solo Andrei)
 func (s *StatisticsService) RecalculateRankings(currentUserRole string) error {
 	if currentUserRole != models.RoleAndrei {
-		return errors.New("unauthorized: only Andrei can recalculate rankings")
+		return apperrors.Forbidden("insufficient_role", "Only Andrei can recalculate rankings")
 	}
 
 	return s.statisticDAO.RecalculateRankings()
 }
+
+func convertToRankingItems(statistics []models.Statistic) []dto.RankingItem {
+	leaderboard := make([]dto.RankingItem, len(statistics))
+	for i, stat := range statistics {
+		leaderboard[i] = dto.RankingItem{
+			Position:      i + 1, // Posición basada en orden
+			Username:      stat.User.Username,
+			CapturesCount: stat.CapturesCount,
+			ReportsCount:  stat.ReportsCount,
+			Points:        stat.Points,
+			Status:        stat.User.Status,
+		}
+	}
+	return leaderboard
+}
@@ -0,0 +1,376 @@
+package services
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/models"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxTeamMembers          = 12
+	teamMessagePageSize     = 100
+	dailyContributionCap    = 20  // Puntos que cada miembro puede aportar por día
+	rescueCost              = 100 // Puntos del fondo que consume un rescate
+	protectionPointsPerTier = 100 // Cada bloque sube un nivel la dificultad de captura
+)
+
+type TeamService struct {
+	teamDAO    *dao.TeamDAO
+	userDAO    *dao.UserDAO
+	captureDAO *dao.CaptureDAO
+	bus        *events.Bus
+}
+
+func NewTeamService(bus *events.Bus) *TeamService {
+	return &TeamService{
+		teamDAO:    dao.NewTeamDAO(),
+		userDAO:    dao.NewUserDAO(),
+		captureDAO: dao.NewCaptureDAO(),
+		bus:        bus,
+	}
+}
+
+// Formar un equipo; quien lo crea queda como líder
+func (s *TeamService) CreateTeam(req *dto.CreateTeamRequest, userID uint) (*dto.TeamDetail, error) {
+	if _, err := s.teamDAO.FindMembership(userID); err == nil {
+		return nil, apperrors.Conflict("already_in_team", "You already belong to a team")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	exists, err := s.teamDAO.ExistsByName(strings.TrimSpace(req.Name))
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperrors.Conflict("team_name_taken", "A team with that name already exists")
+	}
+
+	team := &models.Team{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		SafeHouse:   strings.TrimSpace(req.SafeHouse),
+	}
+
+	if err := s.teamDAO.CreateWithLeader(team, userID); err != nil {
+		return nil, err
+	}
+
+	return s.GetTeam(team.ID, userID)
+}
+
+// Listar equipos sin exponer miembros ni refugios
+func (s *TeamService) GetTeams() ([]dto.TeamSummary, error) {
+	rows, err := s.teamDAO.FindAllWithMemberCount()
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]dto.TeamSummary, len(rows))
+	for i, row := range rows {
+		teams[i] = dto.TeamSummary{
+			ID:          row.ID,
+			Name:        row.Name,
+			Description: row.Description,
+			MemberCount: row.MemberCount,
+		}
+	}
+	return teams, nil
+}
+
+// Equipo del usuario actual
+func (s *TeamService) GetMyTeam(userID uint) (*dto.TeamDetail, error) {
+	member, err := s.teamDAO.FindMembership(userID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "team_not_found", "You do not belong to a team")
+	}
+	return s.GetTeam(member.TeamID, userID)
+}
+
+// Detalle del equipo, solo para sus miembros
+func (s *TeamService) GetTeam(teamID, userID uint) (*dto.TeamDetail, error) {
+	team, _, err := s.loadMembership(teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return convertToTeamDetail(team), nil
+}
+
+// Actualizar descripción o refugio (solo líder)
+func (s *TeamService) UpdateTeam(teamID uint, req *dto.UpdateTeamRequest, userID uint) (*dto.TeamDetail, error) {
+	team, member, err := s.loadMembership(teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != models.TeamRoleLeader {
+		return nil, apperrors.Forbidden("not_team_leader", "Only the team leader can update the team")
+	}
+
+	if req.Description != nil {
+		team.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.SafeHouse != nil {
+		team.SafeHouse = strings.TrimSpace(*req.SafeHouse)
+	}
+
+	if err := s.teamDAO.Update(team); err != nil {
+		return nil, err
+	}
+	return convertToTeamDetail(team), nil
+}
+
+// Sumar un network admin al equipo (solo líder)
+func (s *TeamService) AddMember(teamID uint, req *dto.AddTeamMemberRequest, userID uint) (*dto.TeamDetail, error) {
+	team, member, err := s.loadMembership(teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != models.TeamRoleLeader {
+		return nil, apperrors.Forbidden("not_team_leader", "Only the team leader can add members")
+	}
+	if len(team.Members) >= maxTeamMembers {
+		return nil, apperrors.Conflict("team_full", "The team has reached its member limit")
+	}
+
+	recruit, err := s.userDAO.FindByID(req.UserID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+	if recruit.Role != models.RoleNetworkAdmin {
+		return nil, apperrors.Validation("invalid_team_member", "Only network admins can join a team")
+	}
+
+	if _, err := s.teamDAO.FindMembership(recruit.ID); err == nil {
+		return nil, apperrors.Conflict("already_in_team", "User already belongs to a team")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	err = s.teamDAO.AddMember(&models.TeamMember{
+		TeamID: team.ID,
+		UserID: recruit.ID,
+		Role:   models.TeamRoleMember,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTeam(team.ID, userID)
+}
+
+// Sacar a un miembro; el líder puede sacar a cualquiera y cada miembro puede irse
+func (s *TeamService) RemoveMember(teamID, memberID, userID uint) error {
+	team, member, err := s.loadMembership(teamID, userID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && member.Role != models.TeamRoleLeader {
+		return apperrors.Forbidden("not_team_leader", "Only the team leader can remove other members")
+	}
+
+	target := findTeamMember(team, memberID)
+	if target == nil {
+		return apperrors.NotFound("team_member_not_found", "Member not found in this team")
+	}
+
+	return s.teamDAO.RemoveMember(target)
+}
+
+// Mensajes del tablero privado
+func (s *TeamService) GetMessages(teamID, userID uint) ([]dto.TeamMessageResponse, error) {
+	if _, _, err := s.loadMembership(teamID, userID); err != nil {
+		return nil, err
+	}
+
+	messages, err := s.teamDAO.FindMessages(teamID, teamMessagePageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.TeamMessageResponse, len(messages))
+	for i := range messages {
+		response[i] = convertToTeamMessageResponse(&messages[i])
+	}
+	return response, nil
+}
+
+// Publicar en el tablero privado
+func (s *TeamService) PostMessage(teamID uint, req *dto.CreateTeamMessageRequest, userID uint) (*dto.TeamMessageResponse, error) {
+	if _, _, err := s.loadMembership(teamID, userID); err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, apperrors.Validation("empty_message", "Message body cannot be empty")
+	}
+
+	message := &models.TeamMessage{
+		TeamID:   teamID,
+		AuthorID: userID,
+		Body:     body,
+	}
+	if err := s.teamDAO.CreateMessage(message); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userDAO.FindByID(userID)
+	if err == nil {
+		message.Author = *author
+	}
+
+	response := convertToTeamMessageResponse(message)
+	return &response, nil
+}
+
+// Aportar puntos de protección al fondo común, con tope diario por miembro
+func (s *TeamService) Contribute(teamID uint, req *dto.ContributeProtectionRequest, userID uint) (*dto.TeamDetail, error) {
+	if _, _, err := s.loadMembership(teamID, userID); err != nil {
+		return nil, err
+	}
+
+	since := dao.StartOfDay(time.Now(), config.StatsLocation())
+	contributed, err := s.teamDAO.SumContributionsSince(teamID, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	if contributed+req.Points > dailyContributionCap {
+		return nil, apperrors.Validation("contribution_limit_exceeded", "Daily protection contribution limit exceeded")
+	}
+
+	err = s.teamDAO.Contribute(&models.TeamContribution{
+		TeamID: teamID,
+		UserID: userID,
+		Points: req.Points,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTeam(teamID, userID)
+}
+
+// Rescatar a un miembro capturado gastando puntos del fondo
+func (s *TeamService) RescueMember(teamID uint, req *dto.RescueTeamMemberRequest, userID uint) (*dto.TeamRescueResponse, error) {
+	team, _, err := s.loadMembership(teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rescuer, err := s.userDAO.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if rescuer.Status == models.StatusCaptured {
+		return nil, apperrors.Forbidden("rescuer_captured", "Captured members cannot mount a rescue")
+	}
+
+	target := findTeamMember(team, req.MemberID)
+	if target == nil {
+		return nil, apperrors.NotFound("team_member_not_found", "Member not found in this team")
+	}
+
+	capture, err := s.captureDAO.FindActiveByTargetID(target.UserID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "member_not_captured", "Member is not currently captured")
+	}
+
+	rescue := &models.TeamRescue{
+		TeamID:        team.ID,
+		CaptureID:     capture.ID,
+		RescuedID:     target.UserID,
+		InitiatedByID: userID,
+		PointsSpent:   rescueCost,
+	}
+
+	rescued, err := s.teamDAO.Rescue(rescue, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.MemberRescued{
+			CaptureID:     rescue.CaptureID,
+			TeamID:        team.ID,
+			TeamName:      team.Name,
+			RescuedID:     rescue.RescuedID,
+			InitiatedByID: rescue.InitiatedByID,
+			PointsSpent:   rescue.PointsSpent,
+		}}
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if !rescued {
+		return nil, apperrors.Conflict("insufficient_protection_points", "The team does not have enough protection points for a rescue")
+	}
+	s.bus.Notify()
+
+	return &dto.TeamRescueResponse{
+		CaptureID:       capture.ID,
+		RescuedID:       target.UserID,
+		PointsSpent:     rescueCost,
+		RemainingPoints: team.ProtectionPoints - rescueCost,
+	}, nil
+}
+
+// loadMembership carga el equipo y verifica que el usuario sea miembro. Para
+// quien no pertenece responde como si el equipo no existiera
+func (s *TeamService) loadMembership(teamID, userID uint) (*models.Team, *models.TeamMember, error) {
+	team, err := s.teamDAO.FindByID(teamID)
+	if err != nil {
+		return nil, nil, apperrors.NotFoundOrInternal(err, "team_not_found", "Team not found")
+	}
+
+	member := findTeamMember(team, userID)
+	if member == nil {
+		return nil, nil, apperrors.NotFound("team_not_found", "Team not found")
+	}
+
+	return team, member, nil
+}
+
+func findTeamMember(team *models.Team, userID uint) *models.TeamMember {
+	for i := range team.Members {
+		if team.Members[i].UserID == userID {
+			return &team.Members[i]
+		}
+	}
+	return nil
+}
+
+func convertToTeamDetail(team *models.Team) *dto.TeamDetail {
+	members := make([]dto.TeamMemberInfo, len(team.Members))
+	for i, member := range team.Members {
+		members[i] = dto.TeamMemberInfo{
+			UserID:   member.UserID,
+			Username: member.User.Username,
+			Status:   member.User.Status,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		}
+	}
+
+	return &dto.TeamDetail{
+		ID:               team.ID,
+		Name:             team.Name,
+		Description:      team.Description,
+		SafeHouse:        team.SafeHouse,
+		ProtectionPoints: team.ProtectionPoints,
+		Members:          members,
+		CreatedAt:        team.CreatedAt,
+	}
+}
+
+func convertToTeamMessageResponse(message *models.TeamMessage) dto.TeamMessageResponse {
+	return dto.TeamMessageResponse{
+		ID:        message.ID,
+		AuthorID:  message.AuthorID,
+		Author:    message.Author.Username,
+		Body:      message.Body,
+		CreatedAt: message.CreatedAt,
+	}
+}
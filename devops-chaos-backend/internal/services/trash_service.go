@@ -0,0 +1,391 @@
+package services
+
+import (
+	"context"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/storage"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Entidades con papelera
+const (
+	TrashUsers       = "users"
+	TrashReports     = "reports"
+	TrashPunishments = "punishments"
+)
+
+type TrashService struct {
+	userDAO       *dao.UserDAO
+	reportDAO     *dao.ReportDAO
+	punishmentDAO *dao.PunishmentDAO
+	attachmentDAO *dao.ReportAttachmentDAO
+	store         storage.BlobStore
+	config        *config.TrashConfig
+	bus           *events.Bus
+}
+
+func NewTrashService(store storage.BlobStore, cfg *config.TrashConfig, bus *events.Bus) *TrashService {
+	return &TrashService{
+		userDAO:       dao.NewUserDAO(),
+		reportDAO:     dao.NewReportDAO(),
+		punishmentDAO: dao.NewPunishmentDAO(),
+		attachmentDAO: dao.NewReportAttachmentDAO(),
+		store:         store,
+		config:        cfg,
+		bus:           bus,
+	}
+}
+
+// Listar los elementos eliminados de una entidad (solo Andrei)
+func (s *TrashService) GetTrash(entity string, params dto.ListParams) (*dto.PaginatedResponse, error) {
+	q := dao.NewListQuery(params)
+	message := fmt.Sprintf("Deleted %s retrieved successfully", entity)
+
+	switch entity {
+	case TrashUsers:
+		users, page, err := s.userDAO.ListTrashed(q)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dto.TrashItem, len(users))
+		for i := range users {
+			items[i] = s.userItem(&users[i])
+		}
+		return page.ToResponse(message, items), nil
+
+	case TrashReports:
+		reports, page, err := s.reportDAO.ListTrashed(q)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dto.TrashItem, len(reports))
+		for i := range reports {
+			items[i] = s.reportItem(&reports[i])
+		}
+		return page.ToResponse(message, items), nil
+
+	case TrashPunishments:
+		punishments, page, err := s.punishmentDAO.ListTrashed(q)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dto.TrashItem, len(punishments))
+		for i := range punishments {
+			items[i] = s.punishmentItem(&punishments[i])
+		}
+		return page.ToResponse(message, items), nil
+	}
+	return nil, unknownTrashEntity(entity)
+}
+
+// Restaurar un elemento eliminado
+func (s *TrashService) Restore(entity string, id uint, currentUserID uint) (*dto.TrashItem, error) {
+	switch entity {
+	case TrashUsers:
+		return s.restoreUser(id, currentUserID)
+	case TrashReports:
+		return s.restoreReport(id, currentUserID)
+	case TrashPunishments:
+		return s.restorePunishment(id, currentUserID)
+	}
+	return nil, unknownTrashEntity(entity)
+}
+
+// Borrar definitivamente un elemento de la papelera
+func (s *TrashService) Purge(entity string, id uint, currentUserID uint) error {
+	return s.purge(entity, id, &currentUserID)
+}
+
+// PurgeExpired aplica la política de retención. Los elementos que no se
+// pueden purgar (p.ej. usuarios aún referenciados) se saltan y se reintentan
+// en la próxima pasada
+func (s *TrashService) PurgeExpired(ctx context.Context) {
+	if s.config.Retention <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-s.config.Retention)
+
+	// Primero lo que puede referenciar a usuarios, así estos quedan libres
+	s.purgeExpired(ctx, TrashPunishments, cutoff, s.punishmentDAO.FindDeletedBefore)
+	s.purgeExpired(ctx, TrashReports, cutoff, s.reportDAO.FindDeletedBefore)
+	s.purgeExpired(ctx, TrashUsers, cutoff, s.userDAO.FindDeletedBefore)
+	s.purgeExpiredAttachments(ctx, cutoff)
+}
+
+func (s *TrashService) purgeExpired(ctx context.Context, entity string, cutoff time.Time,
+	find func(cutoff time.Time, afterID uint, limit int) ([]uint, error)) {
+	purged, skipped := 0, 0
+	var afterID uint
+
+	for ctx.Err() == nil {
+		ids, err := find(cutoff, afterID, s.config.BatchSize)
+		if err != nil {
+			log.Printf("Failed to find expired %s in trash: %v", entity, err)
+			break
+		}
+
+		for _, id := range ids {
+			afterID = id
+			if err := s.purge(entity, id, nil); err != nil {
+				if !apperrors.Is(err, apperrors.KindConflict) {
+					log.Printf("Failed to purge %s #%d: %v", entity, id, err)
+				}
+				skipped++
+				continue
+			}
+			purged++
+		}
+
+		if len(ids) < s.config.BatchSize {
+			break
+		}
+	}
+
+	if purged > 0 || skipped > 0 {
+		log.Printf("Trash retention: purged %d %s, skipped %d", purged, entity, skipped)
+	}
+}
+
+// purgeExpiredAttachments borra los adjuntos eliminados de reportes que
+// siguen vivos; los de reportes purgados se van con su reporte
+func (s *TrashService) purgeExpiredAttachments(ctx context.Context, cutoff time.Time) {
+	purged := 0
+	var afterID uint
+
+	for ctx.Err() == nil {
+		attachments, err := s.attachmentDAO.FindDeletedBefore(cutoff, afterID, s.config.BatchSize)
+		if err != nil {
+			log.Printf("Failed to find expired attachments in trash: %v", err)
+			break
+		}
+
+		for _, attachment := range attachments {
+			afterID = attachment.ID
+			if err := s.attachmentDAO.Purge(attachment.ID); err != nil {
+				log.Printf("Failed to purge attachment #%d: %v", attachment.ID, err)
+				continue
+			}
+			s.deleteBlobs(attachment.StorageKey)
+			purged++
+		}
+
+		if len(attachments) < s.config.BatchSize {
+			break
+		}
+	}
+
+	if purged > 0 {
+		log.Printf("Trash retention: purged %d attachments", purged)
+	}
+}
+
+func (s *TrashService) restoreUser(id uint, currentUserID uint) (*dto.TrashItem, error) {
+	user, err := s.userDAO.FindTrashedByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_in_trash", "User not found in trash")
+	}
+
+	// El índice único ya cubre a los eliminados, pero un username o email
+	// puede haberse liberado y reutilizado mientras tanto
+	usernameTaken, emailTaken, err := s.userDAO.FindIdentityConflicts(user.ID, user.Username, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	if usernameTaken {
+		return nil, apperrors.Conflict("username_taken", "Another user already has this username")
+	}
+	if emailTaken {
+		return nil, apperrors.Conflict("email_taken", "Another user already has this email")
+	}
+
+	err = s.userDAO.Restore(user.ID, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.TrashRestored{
+			Entity: TrashUsers, EntityID: user.ID, Label: user.Username, RestoredByID: currentUserID,
+		}}
+	}))
+	if err != nil {
+		return nil, trashError(err, TrashUsers)
+	}
+	s.bus.Notify()
+
+	item := s.userItem(user)
+	return &item, nil
+}
+
+func (s *TrashService) restoreReport(id uint, currentUserID uint) (*dto.TrashItem, error) {
+	report, err := s.reportDAO.FindTrashedByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "report_not_in_trash", "Report not found in trash")
+	}
+
+	err = s.reportDAO.Restore(report.ID, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.TrashRestored{
+			Entity: TrashReports, EntityID: report.ID, Label: report.Title, RestoredByID: currentUserID,
+		}}
+	}))
+	if err != nil {
+		return nil, trashError(err, TrashReports)
+	}
+	s.bus.Notify()
+
+	item := s.reportItem(report)
+	return &item, nil
+}
+
+func (s *TrashService) restorePunishment(id uint, currentUserID uint) (*dto.TrashItem, error) {
+	punishment, err := s.punishmentDAO.FindTrashedByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "punishment_not_in_trash", "Punishment not found in trash")
+	}
+
+	// Preload no encuentra al objetivo si está en la papelera
+	if punishment.Target.ID == 0 {
+		return nil, apperrors.Conflict("target_deleted", "The punished user is deleted; restore them first")
+	}
+
+	// Al eliminar un castigo activo se liberó al objetivo; restaurarlo lo
+	// vuelve a aplicar si sigue vigente
+	targetStatus := ""
+	stillActive := punishment.ExpiresAt == nil || punishment.ExpiresAt.After(time.Now())
+	if punishment.Status == models.PunishmentStatusActive && isPunishmentType(punishment.Type) &&
+		stillActive && punishment.Target.Status == models.StatusActive {
+		targetStatus = models.StatusPunished
+	}
+
+	err = s.punishmentDAO.Restore(punishment, targetStatus, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.TrashRestored{
+			Entity: TrashPunishments, EntityID: punishment.ID, Label: punishmentLabel(punishment), RestoredByID: currentUserID,
+		}}
+	}))
+	if err != nil {
+		return nil, trashError(err, TrashPunishments)
+	}
+	s.bus.Notify()
+
+	if targetStatus != "" {
+		punishment.Target.Status = targetStatus
+	}
+	item := s.punishmentItem(punishment)
+	return &item, nil
+}
+
+// purge borra definitivamente; actorID es nil cuando lo hace la retención
+func (s *TrashService) purge(entity string, id uint, actorID *uint) error {
+	purged := func(label string) dao.OutboxWriter {
+		return s.bus.Writer(func() []events.Event {
+			return []events.Event{events.TrashPurged{Entity: entity, EntityID: id, Label: label, PurgedByID: actorID}}
+		})
+	}
+
+	switch entity {
+	case TrashUsers:
+		user, err := s.userDAO.FindTrashedByID(id)
+		if err != nil {
+			return apperrors.NotFoundOrInternal(err, "user_not_in_trash", "User not found in trash")
+		}
+		if err := s.userDAO.Purge(id, purged(user.Username)); err != nil {
+			return trashError(err, entity)
+		}
+		if user.AvatarKey != "" {
+			s.deleteBlobs(user.AvatarKey)
+		}
+
+	case TrashReports:
+		report, err := s.reportDAO.FindTrashedByID(id)
+		if err != nil {
+			return apperrors.NotFoundOrInternal(err, "report_not_in_trash", "Report not found in trash")
+		}
+		storageKeys, err := s.reportDAO.Purge(id, purged(report.Title))
+		if err != nil {
+			return trashError(err, entity)
+		}
+		s.deleteBlobs(storageKeys...)
+
+	case TrashPunishments:
+		punishment, err := s.punishmentDAO.FindTrashedByID(id)
+		if err != nil {
+			return apperrors.NotFoundOrInternal(err, "punishment_not_in_trash", "Punishment not found in trash")
+		}
+		if err := s.punishmentDAO.Purge(id, purged(punishmentLabel(punishment))); err != nil {
+			return trashError(err, entity)
+		}
+
+	default:
+		return unknownTrashEntity(entity)
+	}
+
+	s.bus.Notify()
+	return nil
+}
+
+// deleteBlobs borra archivos ya desvinculados; un fallo solo deja un huérfano
+func (s *TrashService) deleteBlobs(keys ...string) {
+	for _, key := range keys {
+		if err := s.store.Delete(context.Background(), key); err != nil {
+			log.Printf("Failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+func (s *TrashService) userItem(user *models.User) dto.TrashItem {
+	return s.newTrashItem(TrashUsers, user.ID, user.Username, user.DeletedAt, convertToUserInfo(user))
+}
+
+func (s *TrashService) reportItem(report *models.Report) dto.TrashItem {
+	return s.newTrashItem(TrashReports, report.ID, report.Title, report.DeletedAt, convertToReportResponse(report))
+}
+
+func (s *TrashService) punishmentItem(punishment *models.Punishment) dto.TrashItem {
+	return s.newTrashItem(TrashPunishments, punishment.ID, punishmentLabel(punishment), punishment.DeletedAt,
+		convertToPunishmentResponse(punishment))
+}
+
+func (s *TrashService) newTrashItem(entity string, id uint, label string, deletedAt gorm.DeletedAt, data interface{}) dto.TrashItem {
+	item := dto.TrashItem{
+		Entity:    entity,
+		ID:        id,
+		Label:     label,
+		DeletedAt: deletedAt.Time,
+		Data:      data,
+	}
+	if s.config.Retention > 0 {
+		purgeAt := deletedAt.Time.Add(s.config.Retention)
+		item.PurgeAt = &purgeAt
+	}
+	return item
+}
+
+func punishmentLabel(punishment *models.Punishment) string {
+	if punishment.Target.Username == "" {
+		return fmt.Sprintf("%s for user #%d", punishment.Type, punishment.TargetID)
+	}
+	return fmt.Sprintf("%s for %s", punishment.Type, punishment.Target.Username)
+}
+
+// trashError traduce los errores de restaurar o purgar
+func trashError(err error, entity string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// Otro pedido lo restauró o purgó entre la búsqueda y el cambio
+		return apperrors.Conflict("not_in_trash", fmt.Sprintf("Item is no longer in the %s trash", entity))
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return apperrors.Conflict("still_referenced",
+			"The user still owns reports, captures, punishments or memberships; purge or reassign them first")
+	}
+	return err
+}
+
+func unknownTrashEntity(entity string) error {
+	return apperrors.NotFound("unknown_trash_entity",
+		fmt.Sprintf("No trash for %q; use users, reports or punishments", entity))
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/models"
+)
+
+// Políticas de eliminación por relación
+const (
+	DeletionPolicyCascade   = "cascade"   // Se elimina, cancela o libera junto con el usuario
+	DeletionPolicyReassign  = "reassign"  // Pasa a otro usuario o vuelve a la cola
+	DeletionPolicyAnonymize = "anonymize" // Se conserva sin vínculo con el usuario
+	DeletionPolicyRetain    = "retain"    // Historial que se conserva tal cual
+)
+
+// deletionPlan es una eliminación validada junto con su vista previa
+type deletionPlan struct {
+	deletion *dao.UserDeletion
+	preview  *dto.UserDeletionPreview
+}
+
+// Vista previa de lo que haría eliminar al usuario (solo Andrei)
+func (s *UserService) PreviewDeletion(userID uint, reassignToID *uint, currentUserRole string) (*dto.UserDeletionPreview, error) {
+	if currentUserRole != models.RoleAndrei {
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can delete users")
+	}
+
+	plan, err := s.planDeletion(userID, reassignToID, 0)
+	if err != nil {
+		return nil, err
+	}
+	return plan.preview, nil
+}
+
+// Eliminar usuario (solo Andrei) aplicando la política de cada relación.
+// reassignToID indica el daemon que hereda sus reportes abiertos
+func (s *UserService) DeleteUser(userID uint, reassignToID *uint, currentUserID uint, currentUserRole string) error {
+	if currentUserRole != models.RoleAndrei {
+		return apperrors.Forbidden("insufficient_role", "Only Andrei can delete users")
+	}
+
+	plan, err := s.planDeletion(userID, reassignToID, currentUserID)
+	if err != nil {
+		return err
+	}
+	if !plan.preview.Allowed {
+		blocker := plan.preview.Blockers[0]
+		return apperrors.Forbidden(blocker.Code, blocker.Message)
+	}
+
+	user := plan.deletion.User
+	err = s.userDAO.DeleteWithPolicy(plan.deletion, s.bus.Writer(func() []events.Event {
+		effects := make(map[string]int64, len(plan.preview.Effects))
+		for _, effect := range plan.preview.Effects {
+			if effect.Count > 0 {
+				effects[effect.Relation] = effect.Count
+			}
+		}
+		deleted := events.UserDeleted{
+			UserID:      user.ID,
+			Username:    user.Username,
+			Role:        user.Role,
+			DeletedByID: currentUserID,
+			Effects:     effects,
+		}
+		if plan.deletion.ReassignTo != nil {
+			deleted.ReassignedToID = &plan.deletion.ReassignTo.ID
+		}
+		return []events.Event{deleted}
+	}))
+	if err != nil {
+		return err
+	}
+	s.bus.Notify()
+	return nil
+}
+
+// planDeletion valida la eliminación y calcula sus efectos
+func (s *UserService) planDeletion(userID uint, reassignToID *uint, currentUserID uint) (*deletionPlan, error) {
+	user, err := s.userDAO.FindByID(userID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+
+	deletion := &dao.UserDeletion{User: user, DeletedByID: currentUserID}
+	preview := &dto.UserDeletionPreview{User: convertToUserInfo(user), Allowed: true}
+
+	if reassignToID != nil {
+		if *reassignToID == user.ID {
+			return nil, apperrors.Validation("invalid_reassign_to", "Cannot reassign to the user being deleted")
+		}
+		reassignTo, err := s.userDAO.FindByID(*reassignToID)
+		if err != nil {
+			return nil, apperrors.NotFoundOrInternal(err, "reassign_to_not_found", "User to reassign to not found")
+		}
+		if reassignTo.Role != models.RoleDaemon {
+			return nil, apperrors.Validation("invalid_reassign_to", "Reports can only be reassigned to daemons")
+		}
+		deletion.ReassignTo = reassignTo
+		info := convertToUserInfo(reassignTo)
+		preview.ReassignTo = &info
+	}
+
+	// Bloqueo: nunca se elimina a Andrei
+	if user.Role == models.RoleAndrei {
+		preview.Allowed = false
+		preview.Blockers = append(preview.Blockers, dto.DeletionBlocker{
+			Code: "andrei_protected", Message: "Cannot delete Andrei",
+		})
+	}
+
+	impact, err := s.userDAO.CountDeletionImpact(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reportsPolicy := "Open reports assigned to the user return to the pending queue unassigned"
+	if deletion.ReassignTo != nil {
+		reportsPolicy = "Open reports assigned to the user are reassigned to " + deletion.ReassignTo.Username
+	}
+
+	preview.Effects = []dto.DeletionEffect{
+		{Relation: "statistics", Policy: DeletionPolicyCascade, Count: impact.Statistics,
+			Description: "Statistics are deleted with the user and come back if the user is restored"},
+		{Relation: "active_punishments", Policy: DeletionPolicyCascade, Count: impact.ActivePunishments,
+			Description: "Active punishments are cancelled"},
+		{Relation: "active_captures_as_target", Policy: DeletionPolicyCascade, Count: impact.CapturedAsTarget,
+			Description: "Active captures of the user are released"},
+		{Relation: "active_captures_as_daemon", Policy: DeletionPolicyCascade, Count: impact.CapturedByUser,
+			Description: "Captives of the user are released and become active again"},
+		{Relation: "squad_membership", Policy: DeletionPolicyCascade, Count: impact.SquadMemberships,
+			Description: "The user leaves their squad; its score history is kept"},
+		{Relation: "team_membership", Policy: DeletionPolicyCascade, Count: impact.TeamMemberships,
+			Description: "The user leaves their team; the oldest member becomes leader and an empty team is dissolved"},
+		{Relation: "assigned_reports", Policy: DeletionPolicyReassign, Count: impact.AssignedReports,
+			Description: reportsPolicy},
+		{Relation: "squads_led", Policy: DeletionPolicyReassign, Count: impact.SquadsLed,
+			Description: "Squads led by the user are left without a leader until Andrei picks one"},
+		{Relation: "submitted_content", Policy: DeletionPolicyAnonymize, Count: impact.SubmittedContent,
+			Description: "Survival tips and memes stay published without a submitter"},
+		{Relation: "authored_reports", Policy: DeletionPolicyRetain, Count: impact.AuthoredReports,
+			Description: "Reports written by the user are kept as evidence"},
+		{Relation: "capture_history", Policy: DeletionPolicyRetain, Count: impact.CaptureHistory,
+			Description: "Released and escaped captures are kept for statistics"},
+		{Relation: "punishment_history", Policy: DeletionPolicyRetain, Count: impact.PunishmentHistory,
+			Description: "Completed and cancelled punishments are kept"},
+	}
+
+	return &deletionPlan{deletion: deletion, preview: preview}, nil
+}
+
+func convertToUserInfo(user *models.User) dto.UserInfo {
+	return dto.UserInfo{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		Role:     user.Role,
+		Status:   user.Status,
+	}
+}
@@ -0,0 +1,430 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
+	"devops-chaos-backend/internal/models"
+	"devops-chaos-backend/internal/utils"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Formatos de importación y exportación de usuarios
+const (
+	UserFormatCSV  = "csv"
+	UserFormatJSON = "json"
+)
+
+const (
+	maxImportRows           = 500
+	minPasswordLength       = 6 // Igual que en el registro individual
+	generatedPasswordLength = 16
+)
+
+// Columnas de la exportación CSV
+var userExportColumns = []string{"id", "username", "email", "role", "status", "created_at"}
+
+// importRow es una fila leída con su posición en el archivo
+type importRow struct {
+	line int
+	dto.ImportUserRow
+}
+
+// Importar usuarios desde CSV o JSON (solo Andrei). Se valida todo antes de
+// crear nada: si alguna fila tiene errores no se crea ningún usuario
+func (s *UserService) ImportUsers(r io.Reader, format string, dryRun bool, currentUserID uint, currentUserRole string) (*dto.ImportUsersResponse, error) {
+	if currentUserRole != models.RoleAndrei {
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can import users")
+	}
+
+	var rows []importRow
+	var err error
+	switch format {
+	case UserFormatCSV:
+		rows, err = parseUserCSV(r)
+	case UserFormatJSON:
+		rows, err = parseUserJSON(r)
+	default:
+		return nil, apperrors.Validation("invalid_import_format", "Import format must be csv or json")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, apperrors.Validation("empty_import", "The file contains no users")
+	}
+	if len(rows) > maxImportRows {
+		return nil, apperrors.Validation("too_many_rows",
+			fmt.Sprintf("A single import can contain at most %d users", maxImportRows))
+	}
+
+	response := &dto.ImportUsersResponse{
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Errors:    []dto.ImportRowError{},
+		Users:     []dto.ImportedUser{},
+	}
+
+	valid, err := s.validateImportRows(rows, response)
+	if err != nil {
+		return nil, err
+	}
+	response.ValidRows = len(valid)
+
+	if dryRun || len(response.Errors) > 0 {
+		for _, row := range valid {
+			response.Users = append(response.Users, dto.ImportedUser{
+				Row:               row.line,
+				Username:          row.Username,
+				Email:             row.Email,
+				Role:              row.Role,
+				PasswordGenerated: row.Password == "",
+			})
+		}
+		return response, nil
+	}
+
+	users, passwords, err := buildImportedUsers(valid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.userDAO.CreateBatch(users, s.bus.Writer(func() []events.Event {
+		ids := make([]uint, len(users))
+		for i := range users {
+			ids[i] = users[i].ID
+		}
+		return []events.Event{events.UsersImported{ImportedByID: currentUserID, UserIDs: ids, Format: format}}
+	}))
+	if err != nil {
+		return nil, err
+	}
+	s.bus.Notify()
+
+	for i, user := range users {
+		response.Users = append(response.Users, dto.ImportedUser{
+			Row:               valid[i].line,
+			ID:                user.ID,
+			Username:          user.Username,
+			Email:             user.Email,
+			Role:              user.Role,
+			PasswordGenerated: passwords[i] != "",
+			InitialPassword:   passwords[i],
+		})
+	}
+	response.Created = len(users)
+	return response, nil
+}
+
+// validateImportRows agrega a response los errores por fila y devuelve las válidas
+func (s *UserService) validateImportRows(rows []importRow, response *dto.ImportUsersResponse) ([]importRow, error) {
+	addError := func(row importRow, field, code, message string) {
+		response.Errors = append(response.Errors, dto.ImportRowError{
+			Row: row.line, Field: field, Code: code, Message: message,
+		})
+	}
+
+	usernames := make([]string, 0, len(rows))
+	emails := make([]string, 0, len(rows))
+	for i := range rows {
+		rows[i].Username = strings.TrimSpace(rows[i].Username)
+		rows[i].Email = strings.TrimSpace(rows[i].Email)
+		rows[i].Role = strings.TrimSpace(rows[i].Role)
+		usernames = append(usernames, rows[i].Username)
+		emails = append(emails, rows[i].Email)
+	}
+
+	takenUsernames, takenEmails, err := s.userDAO.FindTakenIdentities(usernames, emails)
+	if err != nil {
+		return nil, err
+	}
+	usernameTaken := make(map[string]bool, len(takenUsernames))
+	for _, username := range takenUsernames {
+		usernameTaken[username] = true
+	}
+	emailTaken := make(map[string]bool, len(takenEmails))
+	for _, email := range takenEmails {
+		emailTaken[email] = true
+	}
+
+	seenUsernames := make(map[string]int)
+	seenEmails := make(map[string]int)
+	var valid []importRow
+
+	for _, row := range rows {
+		errorCount := len(response.Errors)
+
+		switch {
+		case row.Username == "":
+			addError(row, "username", "username_required", "Username is required")
+		case usernameTaken[row.Username]:
+			addError(row, "username", "username_taken", "Username already exists")
+		case seenUsernames[row.Username] > 0:
+			addError(row, "username", "duplicate_username",
+				fmt.Sprintf("Username is repeated from row %d", seenUsernames[row.Username]))
+		default:
+			seenUsernames[row.Username] = row.line
+		}
+
+		switch {
+		case row.Email == "":
+			addError(row, "email", "email_required", "Email is required")
+		case !isValidEmail(row.Email):
+			addError(row, "email", "invalid_email", "Email is not a valid address")
+		case emailTaken[row.Email]:
+			addError(row, "email", "email_taken", "Email already exists")
+		case seenEmails[row.Email] > 0:
+			addError(row, "email", "duplicate_email",
+				fmt.Sprintf("Email is repeated from row %d", seenEmails[row.Email]))
+		default:
+			seenEmails[row.Email] = row.line
+		}
+
+		if !models.IsValidRole(row.Role) {
+			addError(row, "role", "invalid_role",
+				fmt.Sprintf("Role must be one of: %s", strings.Join(models.Roles, ", ")))
+		}
+
+		if row.Password != "" && len(row.Password) < minPasswordLength {
+			addError(row, "password", "password_too_short",
+				fmt.Sprintf("Password must have at least %d characters", minPasswordLength))
+		}
+
+		if len(response.Errors) == errorCount {
+			valid = append(valid, row)
+		}
+	}
+	return valid, nil
+}
+
+// buildImportedUsers genera las contraseñas que faltan y las hashea en
+// paralelo; bcrypt es lento a propósito y un lote grande no cabe en serie.
+// passwords[i] solo tiene valor si se generó
+func buildImportedUsers(rows []importRow) ([]models.User, []string, error) {
+	users := make([]models.User, len(rows))
+	passwords := make([]string, len(rows))
+	plain := make([]string, len(rows))
+
+	for i, row := range rows {
+		plain[i] = row.Password
+		if plain[i] == "" {
+			generated, err := utils.GeneratePassword(generatedPasswordLength)
+			if err != nil {
+				return nil, nil, err
+			}
+			plain[i] = generated
+			passwords[i] = generated
+		}
+		users[i] = models.User{
+			Username: row.Username,
+			Email:    row.Email,
+			Role:     row.Role,
+			Status:   models.StatusActive,
+		}
+	}
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var hashErr error
+	next := make(chan int)
+
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				hashed, err := utils.HashPassword(plain[i])
+				if err != nil {
+					once.Do(func() { hashErr = err })
+					continue
+				}
+				users[i].Password = hashed
+			}
+		}()
+	}
+	for i := range users {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	if hashErr != nil {
+		return nil, nil, apperrors.Internal(hashErr)
+	}
+	return users, passwords, nil
+}
+
+// parseUserCSV lee un CSV con cabecera; las columnas se reconocen por nombre
+func parseUserCSV(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(bufio.NewReader(r))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, importReadError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	for _, required := range []string{"username", "email", "role"} {
+		if _, ok := columns[required]; !ok {
+			return nil, apperrors.Validation("invalid_csv_header",
+				fmt.Sprintf("CSV header must include a %q column", required))
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, importReadError(err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, apperrors.Validation("too_many_rows",
+				fmt.Sprintf("A single import can contain at most %d users", maxImportRows))
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, importRow{
+			line: line,
+			ImportUserRow: dto.ImportUserRow{
+				Username: field(record, "username"),
+				Email:    field(record, "email"),
+				Role:     field(record, "role"),
+				Password: field(record, "password"),
+			},
+		})
+	}
+	return rows, nil
+}
+
+// parseUserJSON acepta un arreglo de filas o un objeto {"users": [...]}
+func parseUserJSON(r io.Reader) ([]importRow, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, importReadError(err)
+	}
+
+	var list []dto.ImportUserRow
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapper struct {
+			Users []dto.ImportUserRow `json:"users"`
+		}
+		err = json.Unmarshal(trimmed, &wrapper)
+		list = wrapper.Users
+	} else {
+		err = json.Unmarshal(trimmed, &list)
+	}
+	if err != nil {
+		return nil, apperrors.Validation("invalid_json", err.Error())
+	}
+
+	rows := make([]importRow, len(list))
+	for i, row := range list {
+		rows[i] = importRow{line: i + 1, ImportUserRow: row}
+	}
+	return rows, nil
+}
+
+func importReadError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return apperrors.Validation("invalid_csv", parseErr.Error())
+	}
+	return apperrors.From(err)
+}
+
+func isValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
+
+// Exportar usuarios (solo Andrei). Nunca incluye el hash de la contraseña
+func (s *UserService) ExportUsers(format, role, status, currentUserRole string) ([]byte, error) {
+	if currentUserRole != models.RoleAndrei {
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can export users")
+	}
+	if format != UserFormatCSV && format != UserFormatJSON {
+		return nil, apperrors.Validation("invalid_export_format", "Export format must be csv or json")
+	}
+
+	users, err := s.userDAO.FindForExport(role, status)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.UserListItem, len(users))
+	for i, user := range users {
+		items[i] = dto.UserListItem{
+			ID:        user.ID,
+			Username:  user.Username,
+			Email:     user.Email,
+			Role:      user.Role,
+			Status:    user.Status,
+			CreatedAt: user.CreatedAt,
+		}
+	}
+
+	if format == UserFormatJSON {
+		return json.Marshal(items)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(userExportColumns)
+	for _, item := range items {
+		writer.Write([]string{
+			strconv.FormatUint(uint64(item.ID), 10),
+			csvSafe(item.Username),
+			csvSafe(item.Email),
+			item.Role,
+			item.Status,
+			item.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// csvSafe evita que una hoja de cálculo interprete el valor como fórmula
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -1,25 +1,33 @@
 package services
 
 import (
+	"devops-chaos-backend/internal/apperrors"
 	"devops-chaos-backend/internal/dao"
 	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/events"
 	"devops-chaos-backend/internal/models"
 	"errors"
-	"math"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type UserService struct {
-	userDAO      *dao.UserDAO
-	statisticDAO *dao.StatisticDAO
-	captureDAO   *dao.CaptureDAO
+	userDAO    *dao.UserDAO
+	captureDAO *dao.CaptureDAO
+	teamDAO    *dao.TeamDAO
+	bus        *events.Bus
 }
 
-func NewUserService() *UserService {
+func NewUserService(bus *events.Bus) *UserService {
 	return &UserService{
-		userDAO:      dao.NewUserDAO(),
-		statisticDAO: dao.NewStatisticDAO(),
-		captureDAO:   dao.NewCaptureDAO(),
+		userDAO:    dao.NewUserDAO(),
+		captureDAO: dao.NewCaptureDAO(),
+		teamDAO:    dao.NewTeamDAO(),
+		bus:        bus,
 	}
 }
 
@@ -27,12 +35,12 @@ func NewUserService() *UserService {
 func (s *UserService) GetByID(id uint, currentUserRole string) (*dto.UserInfo, error) {
 	user, err := s.userDAO.FindByID(id)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
 	}
 
 	// Solo Andrei puede ver información completa de cualquier usuario
 	if currentUserRole != models.RoleAndrei && user.ID != id {
-		return nil, errors.New("unauthorized")
+		return nil, apperrors.Forbidden("user_access_denied", "Not allowed to view this user")
 	}
 
 	userInfo := &dto.UserInfo{
@@ -46,29 +54,13 @@ func (s *UserService) GetByID(id uint, currentUserRole string) (*dto.UserInfo, e
 	return userInfo, nil
 }
 
-// Listar usuarios con paginación (solo Andrei)
-func (s *UserService) GetUsers(page, limit int, role, currentUserRole string) (*dto.PaginatedResponse, error) {
+// Listar usuarios con filtros, orden y paginación (solo Andrei)
+func (s *UserService) GetUsers(params dto.ListParams, currentUserRole string) (*dto.PaginatedResponse, error) {
 	if currentUserRole != models.RoleAndrei {
-		return nil, errors.New("unauthorized: only Andrei can list users")
-	}
-
-	if page <= 0 {
-		page = 1
-	}
-	if limit <= 0 {
-		limit = 10
-	}
-
-	var users []models.User
-	var total int64
-	var err error
-
-	if role != "" {
-		users, total, err = s.userDAO.FindByRole(role, page, limit)
-	} else {
-		users, total, err = s.userDAO.FindAll(page, limit)
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can list users")
 	}
 
+	users, page, err := s.userDAO.List(dao.NewListQuery(params))
 	if err != nil {
 		return nil, err
 	}
@@ -86,83 +78,184 @@ func (s *UserService) GetUsers(page, limit int, role, currentUserRole string) (*
 		}
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	return &dto.PaginatedResponse{
-		Success:    true,
-		Message:    "Users retrieved successfully",
-		Data:       userItems,
-		Page:       page,
-		Limit:      limit,
-		TotalItems: total,
-		TotalPages: totalPages,
-	}, nil
+	return page.ToResponse("Users retrieved successfully", userItems), nil
 }
 
-// Actualizar usuario (solo Andrei)
-func (s *UserService) UpdateUser(userID uint, req *dto.UpdateUserRequest, currentUserRole string) error {
+// Actualizar usuario (solo Andrei). Rol y status se validan contra
+// models.StatusesByRole; cambiar de rol vuelve el status a active salvo que se
+// indique otro válido y arrastra los efectos de UserDAO.ApplyChange
+func (s *UserService) UpdateUser(userID uint, req *dto.UpdateUserRequest, currentUserID uint, currentUserRole string) error {
 	if currentUserRole != models.RoleAndrei {
-		return errors.New("unauthorized: only Andrei can update users")
+		return apperrors.Forbidden("insufficient_role", "Only Andrei can update users")
 	}
 
 	user, err := s.userDAO.FindByID(userID)
 	if err != nil {
-		return errors.New("user not found")
+		return apperrors.NotFoundOrInternal(err, "user_not_found", "User not found")
+	}
+	if user.AnonymizedAt != nil {
+		return apperrors.Conflict("user_anonymized", "Anonymized users cannot be edited")
 	}
 
-	// Actualizar campos si se proporcionan
+	change := &dao.UserChange{
+		User:     user,
+		Username: user.Username,
+		Email:    user.Email,
+		Role:     user.Role,
+		Status:   user.Status,
+	}
 	if req.Username != "" {
-		// Verificar que no exista otro usuario con ese username
-		existing, _ := s.userDAO.FindByUsername(req.Username)
-		if existing != nil && existing.ID != userID {
-			return errors.New("username already exists")
-		}
-		user.Username = req.Username
+		change.Username = req.Username
 	}
-
 	if req.Email != "" {
-		// Verificar que no exista otro usuario con ese email
-		existing, _ := s.userDAO.FindByEmail(req.Email)
-		if existing != nil && existing.ID != userID {
-			return errors.New("email already exists")
-		}
-		user.Email = req.Email
+		change.Email = req.Email
+	}
+	if err := s.checkIdentityAvailable(user, change); err != nil {
+		return err
 	}
 
 	if req.Role != "" {
-		user.Role = req.Role
+		if !models.IsValidRole(req.Role) {
+			return apperrors.Validation("invalid_role",
+				fmt.Sprintf("Role must be one of: %s", strings.Join(models.Roles, ", ")))
+		}
+		change.Role = req.Role
 	}
-
-	if req.Status != "" {
-		user.Status = req.Status
+	switch {
+	case req.Status != "":
+		change.Status = req.Status
+	case change.RoleChanged():
+		change.Status = models.StatusActive
+	}
+	if err := validateStatusChange(change); err != nil {
+		return err
 	}
 
-	return s.userDAO.Update(user)
+	emailChanged := change.Email != user.Email
+	if !change.RoleChanged() && !change.StatusChanged() && change.Username == user.Username && !emailChanged {
+		return nil
+	}
+
+	_, err = s.userDAO.ApplyChange(change, func(effects *dao.UserChangeEffects) dao.OutboxWriter {
+		return s.bus.Writer(func() []events.Event {
+			updated := events.UserUpdated{
+				UserID:       user.ID,
+				UpdatedByID:  currentUserID,
+				Changes:      make(map[string]events.ValueChange),
+				EmailChanged: emailChanged,
+				Effects:      userChangeEffects(effects),
+			}
+			if change.Username != user.Username {
+				updated.Changes["username"] = events.ValueChange{From: user.Username, To: change.Username}
+			}
+			if change.RoleChanged() {
+				updated.Changes["role"] = events.ValueChange{From: user.Role, To: change.Role}
+			}
+			if change.StatusChanged() {
+				updated.Changes["status"] = events.ValueChange{From: user.Status, To: change.Status}
+			}
+			// Cada captura liberada notifica igual que un rescate
+			all := []events.Event{updated}
+			for _, capture := range effects.ReleasedCaptures {
+				all = append(all, events.CaptureReleased{
+					CaptureID:    capture.ID,
+					DaemonID:     capture.DaemonID,
+					TargetID:     capture.TargetID,
+					ReleasedByID: currentUserID,
+				})
+			}
+			return all
+		})
+	})
+	switch {
+	case errors.Is(err, dao.ErrLastAndrei):
+		return apperrors.Conflict("last_andrei", "Cannot demote the last Andrei")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return apperrors.Conflict("user_changed", "User was modified meanwhile; reload and try again")
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return apperrors.Conflict("identity_taken", "Username or email already exists")
+	case err != nil:
+		return err
+	}
+	s.bus.Notify()
+	return nil
 }
 
-// Eliminar usuario (solo Andrei)
-func (s *UserService) DeleteUser(userID uint, currentUserRole string) error {
-	if currentUserRole != models.RoleAndrei {
-		return errors.New("unauthorized: only Andrei can delete users")
+// checkIdentityAvailable incluye a los usuarios en la papelera, que siguen
+// ocupando el índice único
+func (s *UserService) checkIdentityAvailable(user *models.User, change *dao.UserChange) error {
+	var usernames, emails []string
+	if change.Username != user.Username {
+		usernames = append(usernames, change.Username)
+	}
+	if change.Email != user.Email {
+		emails = append(emails, change.Email)
+	}
+	if len(usernames) == 0 && len(emails) == 0 {
+		return nil
 	}
 
-	user, err := s.userDAO.FindByID(userID)
+	takenUsernames, takenEmails, err := s.userDAO.FindTakenIdentities(usernames, emails)
 	if err != nil {
-		return errors.New("user not found")
+		return err
+	}
+	if len(usernames) > 0 && slices.Contains(takenUsernames, change.Username) {
+		return apperrors.Conflict("username_taken", "Username already exists")
+	}
+	if len(emails) > 0 && slices.Contains(takenEmails, change.Email) {
+		return apperrors.Conflict("email_taken", "Email already exists")
+	}
+	return nil
+}
+
+// validateStatusChange aplica la máquina de estados: el status debe existir
+// para el rol resultante, y capturar o castigar solo se hace con sus endpoints,
+// que dejan el registro de la captura o del castigo
+func validateStatusChange(change *dao.UserChange) error {
+	if !models.IsValidStatusForRole(change.Role, change.Status) {
+		return apperrors.Validation("invalid_status_for_role",
+			fmt.Sprintf("Status %q is not valid for role %s; allowed: %s",
+				change.Status, change.Role, strings.Join(models.StatusesByRole[change.Role], ", ")))
+	}
+	if !change.StatusChanged() {
+		return nil
 	}
 
-	// No permitir eliminar a Andrei
-	if user.Role == models.RoleAndrei {
-		return errors.New("cannot delete Andrei")
+	switch change.Status {
+	case models.StatusCaptured:
+		return apperrors.Validation("status_requires_capture",
+			"Network admins become captured through POST /api/users/:id/capture")
+	case models.StatusPunished:
+		return apperrors.Validation("status_requires_punishment",
+			"Daemons become punished through POST /api/punishments")
 	}
+	return nil
+}
 
-	return s.userDAO.Delete(userID)
+// userChangeEffects deja solo las relaciones que el cambio tocó
+func userChangeEffects(effects *dao.UserChangeEffects) map[string]int64 {
+	all := map[string]int64{
+		"statistics_removed": effects.StatisticsRemoved,
+		"statistics_added":   effects.StatisticsAdded,
+		"punishments_closed": effects.PunishmentsClosed,
+		"captures_released":  effects.CapturesReleased,
+		"squad_membership":   effects.SquadMemberships,
+		"squads_led":         effects.SquadsLed,
+		"team_membership":    effects.TeamMemberships,
+	}
+	touched := make(map[string]int64)
+	for relation, count := range all {
+		if count > 0 {
+			touched[relation] = count
+		}
+	}
+	return touched
 }
 
 // Obtener estadísticas generales de usuarios (solo Andrei)
 func (s *UserService) GetUserStats(currentUserRole string) (map[string]interface{}, error) {
 	if currentUserRole != models.RoleAndrei {
-		return nil, errors.New("unauthorized: only Andrei can view user stats")
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can view user stats")
 	}
 
 	totalUsers, err := s.userDAO.CountByRole("")
@@ -204,35 +297,37 @@ func (s *UserService) GetUserStats(currentUserRole string) (map[string]interface
 // Capturar Network Admin (para Daemons)
 func (s *UserService) CaptureNetworkAdmin(targetID uint, daemonID uint, daemonRole string) error {
 	if daemonRole != models.RoleDaemon {
-		return errors.New("unauthorized: only daemons can capture network admins")
+		return apperrors.Forbidden("insufficient_role", "Only daemons can capture network admins")
 	}
 
 	// Verificar que el objetivo sea un network admin
 	target, err := s.userDAO.FindByID(targetID)
 	if err != nil {
-		return errors.New("target user not found")
+		return apperrors.NotFoundOrInternal(err, "target_not_found", "Target user not found")
 	}
 
 	if target.Role != models.RoleNetworkAdmin {
-		return errors.New("can only capture network administrators")
+		return apperrors.Validation("invalid_capture_target", "Can only capture network administrators")
 	}
 
 	if target.Status == models.StatusCaptured {
-		return errors.New("network admin is already captured")
+		return apperrors.Conflict("already_captured", "Network admin is already captured")
 	}
 
-	// Calcular dificultad y puntos basado en el ID del target
-	difficulty := s.calculateCaptureDifficulty(targetID)
+	// Calcular dificultad y puntos basado en el ID del target y la protección de su equipo
+	protection, err := s.teamDAO.FindProtectionPoints(targetID)
+	if err != nil {
+		return err
+	}
+	difficulty := s.calculateCaptureDifficulty(targetID, protection)
 	points := s.calculateCapturePoints(difficulty)
 
-	// Actualizar status del network admin
-	target.Status = models.StatusCaptured
-	err = s.userDAO.Update(target)
+	daemon, err := s.userDAO.FindByID(daemonID)
 	if err != nil {
 		return err
 	}
 
-	// Crear registro de captura
+	// Crear registro de captura y marcar al network admin como capturado
 	capture := &models.Capture{
 		DaemonID:    daemonID,
 		TargetID:    targetID,
@@ -243,30 +338,35 @@ func (s *UserService) CaptureNetworkAdmin(targetID uint, daemonID uint, daemonRo
 		Difficulty:  difficulty,
 	}
 
-	err = s.captureDAO.Create(capture)
+	// Estadísticas, puntos, escuadrón y avisos reaccionan a CaptureCreated
+	err = s.captureDAO.CreateForTarget(capture, s.bus.Writer(func() []events.Event {
+		return []events.Event{events.CaptureCreated{
+			CaptureID:  capture.ID,
+			DaemonID:   daemon.ID,
+			DaemonName: daemon.Username,
+			TargetID:   target.ID,
+			TargetName: target.Username,
+			Difficulty: capture.Difficulty,
+			Points:     capture.Points,
+			CapturedAt: capture.CaptureDate,
+		}}
+	}))
 	if err != nil {
 		return err
 	}
 
-	// Incrementar capturas del daemon
-	err = s.statisticDAO.IncrementCaptures(daemonID)
-	if err != nil {
-		// Log error pero no fallar la operación
-	}
-
-	// Dar puntos al daemon
-	err = s.statisticDAO.AddPoints(daemonID, points)
-	if err != nil {
-		// Log error pero no fallar la operación
-	}
-
+	s.bus.Notify()
 	return nil
 }
 
 // Función auxiliar para calcular la dificultad de captura
-func (s *UserService) calculateCaptureDifficulty(targetID uint) string {
-	// Simulamos dificultad basada en el ID
-	difficulty := (targetID % 3) + 1
+func (s *UserService) calculateCaptureDifficulty(targetID uint, protectionPoints int) string {
+	// Simulamos dificultad basada en el ID; cada bloque de puntos de protección
+	// del equipo sube un nivel, hasta "hard"
+	difficulty := int(targetID%3) + 1 + protectionPoints/protectionPointsPerTier
+	if difficulty > 3 {
+		difficulty = 3
+	}
 	switch difficulty {
 	case 1:
 		return models.CaptureDifficultyEasy
@@ -297,11 +397,11 @@ func (s *UserService) calculateCapturePoints(difficulty string) int {
 func (s *UserService) GetDaemonCaptures(daemonID uint, currentUserRole string, currentUserID uint) ([]models.Capture, error) {
 	// Los daemons solo pueden ver sus propias capturas, Andrei puede ver todas
 	if currentUserRole == models.RoleDaemon && currentUserID != daemonID {
-		return nil, errors.New("unauthorized: can only view your own captures")
+		return nil, apperrors.Forbidden("not_owner", "Can only view your own captures")
 	}
 
 	if currentUserRole != models.RoleDaemon && currentUserRole != models.RoleAndrei {
-		return nil, errors.New("unauthorized: only daemons and Andrei can view captures")
+		return nil, apperrors.Forbidden("insufficient_role", "Only daemons and Andrei can view captures")
 	}
 
 	return s.captureDAO.FindByDaemonID(daemonID)
@@ -310,7 +410,7 @@ func (s *UserService) GetDaemonCaptures(daemonID uint, currentUserRole string, c
 // Obtener todas las capturas (solo Andrei)
 func (s *UserService) GetAllCaptures(currentUserRole string) ([]models.Capture, error) {
 	if currentUserRole != models.RoleAndrei {
-		return nil, errors.New("unauthorized: only Andrei can view all captures")
+		return nil, apperrors.Forbidden("insufficient_role", "Only Andrei can view all captures")
 	}
 
 	return s.captureDAO.FindAll()
@@ -319,7 +419,7 @@ func (s *UserService) GetAllCaptures(currentUserRole string) ([]models.Capture,
 // Obtener network admins disponibles para captura (para Daemons)
 func (s *UserService) GetNetworkAdminsForCapture(currentUserRole string) ([]models.User, error) {
 	if currentUserRole != models.RoleDaemon {
-		return nil, errors.New("unauthorized: only daemons can view capture targets")
+		return nil, apperrors.Forbidden("insufficient_role", "Only daemons can view capture targets")
 	}
 
 	// Obtener todos los network admins activos (no capturados)
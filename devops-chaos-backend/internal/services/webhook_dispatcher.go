@@ -0,0 +1,166 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/models"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const webhookErrorBodyLimit = 512
+
+// WebhookDispatcher envía las entregas pendientes del outbox
+type WebhookDispatcher struct {
+	webhookDAO *dao.WebhookDAO
+	config     *config.WebhookConfig
+	client     *http.Client
+}
+
+func NewWebhookDispatcher(cfg *config.WebhookConfig) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookDAO: dao.NewWebhookDAO(),
+		config:     cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			// Una redirección cuenta como fallo: la firma es para la URL registrada
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// DispatchDue toma un lote de entregas vencidas y las intenta una vez
+func (d *WebhookDispatcher) DispatchDue(ctx context.Context) {
+	// El lease cubre el peor caso del lote; si el proceso cae, otra pasada las retoma
+	lease := d.config.Timeout*time.Duration(d.config.BatchSize) + time.Minute
+
+	deliveries, err := d.webhookDAO.ClaimDue(time.Now(), lease, d.config.BatchSize)
+	if err != nil {
+		log.Printf("Failed to claim webhook deliveries: %v", err)
+		return
+	}
+
+	webhooks := make(map[uint]*models.Webhook)
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			return // El lease vence y se reintentan en el próximo arranque
+		}
+
+		delivery := &deliveries[i]
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = d.webhookDAO.FindByID(delivery.WebhookID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Printf("Failed to load webhook %d: %v", delivery.WebhookID, err)
+				continue
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		d.attempt(ctx, webhook, delivery)
+		if err := d.webhookDAO.SaveAttempt(delivery); err != nil {
+			log.Printf("Failed to save webhook delivery %d: %v", delivery.ID, err)
+		}
+	}
+}
+
+// attempt hace un intento de entrega y deja en delivery el resultado
+func (d *WebhookDispatcher) attempt(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) {
+	now := time.Now()
+	delivery.LastAttemptAt = &now
+
+	if webhook == nil || !webhook.Active {
+		delivery.Status = models.DeliveryStatusDead
+		delivery.LastError = "webhook deleted or inactive"
+		return
+	}
+
+	delivery.Attempts++
+	statusCode, err := d.send(ctx, webhook, delivery)
+	delivery.LastStatusCode = statusCode
+
+	if err == nil {
+		delivery.Status = models.DeliveryStatusSucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.config.MaxAttempts {
+		delivery.Status = models.DeliveryStatusDead
+		return
+	}
+	delivery.Status = models.DeliveryStatusPending
+	delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "devops-chaos-webhooks/1.0")
+	req.Header.Set("X-Chaos-Event", delivery.EventType)
+	req.Header.Set("X-Chaos-Event-Id", delivery.EventID)
+	req.Header.Set("X-Chaos-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Chaos-Signature", SignWebhookPayload(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return resp.StatusCode, nil
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+	return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+}
+
+// backoff exponencial desde BackoffBase con tope BackoffMax y ±20% de jitter
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	delay := d.config.BackoffBase
+	for i := 1; i < attempts && delay < d.config.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > d.config.BackoffMax {
+		delay = d.config.BackoffMax
+	}
+
+	jitter := time.Duration(rand.Int64N(int64(delay)/5*2+1)) - delay/5
+	return delay + jitter
+}
+
+// SignWebhookPayload arma el header X-Chaos-Signature: "t=<unix>,v1=<hex>", con
+// v1 = HMAC-SHA256(secret, "<unix>.<body>"). El receptor debe rechazar
+// timestamps viejos para evitar replays
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"devops-chaos-backend/internal/config"
+	"devops-chaos-backend/internal/models"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"event":"report.created"}`)
+
+	// Vectores calculados aparte con: printf '<t>.<body>' | openssl dgst -sha256 -hmac <secret>
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      []byte
+		want      string
+	}{
+		{"reference", "secret", "1700000000", body, "t=1700000000,v1=3b26063c5ec060cdf53ebb39ca40d6c8d727f899af46d304f94e08f63cc6ed7a"},
+		{"other secret", "otro-secreto", "1700000000", body, "t=1700000000,v1=cd5e63a1840794ed4e37fbf82627705c60cfad2323bcf94983cb397790972157"},
+		{"other timestamp", "secret", "1700000001", body, "t=1700000001,v1=d79da5d0d4efd7a745af4c1e80c9069d942430316a40ffd62798ac56b9099765"},
+		{"empty body", "secret", "1700000000", nil, "t=1700000000,v1=4bc5f74d868b97888288889c5d9d65df02526f94c1592a79fdf4fe8b26e311e5"},
+	}
+
+	for _, tt := range tests {
+		if got := SignWebhookPayload(tt.secret, tt.timestamp, tt.body); got != tt.want {
+			t.Errorf("%s: signature = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// verifySignature es lo que haría un receptor con el header X-Chaos-Signature
+func verifySignature(secret, header string, body []byte) bool {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || timestamp == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(body)))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func TestWebhookSendSignsBody(t *testing.T) {
+	var header string
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Chaos-Signature")
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := NewWebhookDispatcher(&config.WebhookConfig{Timeout: 5 * time.Second})
+	webhook := &models.Webhook{URL: server.URL, Secret: "secret"}
+	delivery := &models.WebhookDelivery{ID: 7, EventType: "report.created", EventID: "evt-1", Payload: `{"id":1}`}
+
+	before := time.Now().Unix()
+	if status, err := d.send(context.Background(), webhook, delivery); err != nil || status != http.StatusNoContent {
+		t.Fatalf("send = %d, %v", status, err)
+	}
+
+	if string(received) != delivery.Payload {
+		t.Fatalf("body = %q, want %q", received, delivery.Payload)
+	}
+	if !verifySignature("secret", header, received) {
+		t.Fatalf("signature %q does not verify", header)
+	}
+
+	ts, err := strconv.ParseInt(strings.TrimPrefix(strings.SplitN(header, ",", 2)[0], "t="), 10, 64)
+	if err != nil || ts < before || ts > time.Now().Unix() {
+		t.Fatalf("timestamp in %q is not the send time", header)
+	}
+
+	tampered := []struct {
+		name   string
+		secret string
+		body   string
+	}{
+		{"wrong secret", "other", delivery.Payload},
+		{"modified body", "secret", `{"id":2}`},
+	}
+	for _, tt := range tampered {
+		if verifySignature(tt.secret, header, []byte(tt.body)) {
+			t.Errorf("%s: signature should not verify", tt.name)
+		}
+	}
+}
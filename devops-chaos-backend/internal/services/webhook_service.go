@@ -0,0 +1,362 @@
+package services
+
+import (
+	"crypto/rand"
+	"devops-chaos-backend/internal/apperrors"
+	"devops-chaos-backend/internal/dao"
+	"devops-chaos-backend/internal/dto"
+	"devops-chaos-backend/internal/models"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type WebhookService struct {
+	webhookDAO *dao.WebhookDAO
+}
+
+func NewWebhookService() *WebhookService {
+	return &WebhookService{
+		webhookDAO: dao.NewWebhookDAO(),
+	}
+}
+
+// Registrar webhook; el secreto de firma se devuelve solo en esta respuesta
+func (s *WebhookService) CreateWebhook(req *dto.CreateWebhookRequest, userID uint) (*dto.WebhookResponse, error) {
+	target, err := validateWebhookURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	events, err := validateWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &models.Webhook{
+		URL:         target,
+		Description: strings.TrimSpace(req.Description),
+		Secret:      secret,
+		Events:      events,
+		Active:      true,
+		CreatedByID: userID,
+	}
+	if err := s.webhookDAO.Create(webhook); err != nil {
+		return nil, err
+	}
+
+	response := convertToWebhookResponse(webhook)
+	response.Secret = secret
+	return response, nil
+}
+
+// Listar webhooks
+func (s *WebhookService) GetWebhooks() ([]dto.WebhookResponse, error) {
+	webhooks, err := s.webhookDAO.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		response[i] = *convertToWebhookResponse(&webhooks[i])
+	}
+	return response, nil
+}
+
+// Obtener webhook por ID
+func (s *WebhookService) GetWebhook(id uint) (*dto.WebhookResponse, error) {
+	webhook, err := s.webhookDAO.FindByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "webhook_not_found", "Webhook not found")
+	}
+	return convertToWebhookResponse(webhook), nil
+}
+
+// Actualizar URL, descripción, eventos o estado
+func (s *WebhookService) UpdateWebhook(id uint, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	webhook, err := s.webhookDAO.FindByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "webhook_not_found", "Webhook not found")
+	}
+
+	if req.URL != nil {
+		target, err := validateWebhookURL(*req.URL)
+		if err != nil {
+			return nil, err
+		}
+		webhook.URL = target
+	}
+	if req.Description != nil {
+		webhook.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Events != nil {
+		events, err := validateWebhookEvents(req.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Events = events
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := s.webhookDAO.Update(webhook); err != nil {
+		return nil, err
+	}
+	return convertToWebhookResponse(webhook), nil
+}
+
+// Rotar el secreto de firma
+func (s *WebhookService) RotateSecret(id uint) (*dto.WebhookResponse, error) {
+	webhook, err := s.webhookDAO.FindByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "webhook_not_found", "Webhook not found")
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = secret
+
+	if err := s.webhookDAO.Update(webhook); err != nil {
+		return nil, err
+	}
+
+	response := convertToWebhookResponse(webhook)
+	response.Secret = secret
+	return response, nil
+}
+
+// Eliminar webhook
+func (s *WebhookService) DeleteWebhook(id uint) error {
+	if _, err := s.webhookDAO.FindByID(id); err != nil {
+		return apperrors.NotFoundOrInternal(err, "webhook_not_found", "Webhook not found")
+	}
+	return s.webhookDAO.Delete(id)
+}
+
+// Enviar un evento "ping" para probar el endpoint
+func (s *WebhookService) Ping(id uint) (*dto.WebhookDeliveryResponse, error) {
+	webhook, err := s.webhookDAO.FindByID(id)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "webhook_not_found", "Webhook not found")
+	}
+
+	eventID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.buildDeliveries([]models.Webhook{*webhook}, eventID, models.WebhookEventPing, map[string]string{"message": "pong"})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.webhookDAO.CreateDeliveries(deliveries); err != nil {
+		return nil, err
+	}
+	return convertToDeliveryResponse(&deliveries[0], false), nil
+}
+
+// Log de entregas de un webhook
+func (s *WebhookService) GetDeliveries(webhookID uint, params dto.ListParams) (*dto.PaginatedResponse, error) {
+	if _, err := s.webhookDAO.FindByID(webhookID); err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "webhook_not_found", "Webhook not found")
+	}
+
+	query := dao.NewListQuery(params).Scope(dao.DeliveriesOf(webhookID))
+	deliveries, page, err := s.webhookDAO.ListDeliveries(query)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		items[i] = *convertToDeliveryResponse(&deliveries[i], false)
+	}
+	return page.ToResponse("Webhook deliveries retrieved successfully", items), nil
+}
+
+// Detalle de una entrega, con el payload enviado
+func (s *WebhookService) GetDelivery(webhookID, deliveryID uint) (*dto.WebhookDeliveryResponse, error) {
+	delivery, err := s.webhookDAO.FindDelivery(webhookID, deliveryID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "delivery_not_found", "Delivery not found")
+	}
+	return convertToDeliveryResponse(delivery, true), nil
+}
+
+// Reenviar: encola una entrega nueva con el mismo evento; el log de la original se conserva
+func (s *WebhookService) Redeliver(webhookID, deliveryID uint) (*dto.WebhookDeliveryResponse, error) {
+	webhook, err := s.webhookDAO.FindByID(webhookID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "webhook_not_found", "Webhook not found")
+	}
+	if !webhook.Active {
+		return nil, apperrors.Conflict("webhook_inactive", "Webhook is inactive")
+	}
+
+	original, err := s.webhookDAO.FindDelivery(webhookID, deliveryID)
+	if err != nil {
+		return nil, apperrors.NotFoundOrInternal(err, "delivery_not_found", "Delivery not found")
+	}
+
+	deliveries := []models.WebhookDelivery{{
+		WebhookID:     webhookID,
+		EventID:       original.EventID, // Mismo ID para que el receptor deduplique
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        models.DeliveryStatusPending,
+		NextAttemptAt: time.Now(),
+	}}
+	if err := s.webhookDAO.CreateDeliveries(deliveries); err != nil {
+		return nil, err
+	}
+	return convertToDeliveryResponse(&deliveries[0], false), nil
+}
+
+// Enqueue encola el evento para cada webhook activo suscrito. eventID llega a
+// los receptores en X-Chaos-Event-Id para que descarten repetidos
+func (s *WebhookService) Enqueue(eventID, eventType string, data interface{}) error {
+	webhooks, err := s.webhookDAO.FindActive()
+	if err != nil {
+		return err
+	}
+
+	var subscribed []models.Webhook
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(eventType) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	deliveries, err := s.buildDeliveries(subscribed, eventID, eventType, data)
+	if err != nil {
+		return err
+	}
+	return s.webhookDAO.CreateDeliveries(deliveries)
+}
+
+func (s *WebhookService) buildDeliveries(webhooks []models.Webhook, eventID, eventType string, data interface{}) ([]models.WebhookDelivery, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(dto.WebhookEvent{
+		ID:        "evt_" + eventID,
+		Type:      eventType,
+		CreatedAt: now,
+		Data:      encoded,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]models.WebhookDelivery, len(webhooks))
+	for i, webhook := range webhooks {
+		deliveries[i] = models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       "evt_" + eventID,
+			EventType:     eventType,
+			Payload:       string(payload),
+			Status:        models.DeliveryStatusPending,
+			NextAttemptAt: now,
+		}
+	}
+	return deliveries, nil
+}
+
+func validateWebhookURL(raw string) (string, error) {
+	target, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return "", apperrors.Validation("invalid_webhook_url", "Webhook URL must be an absolute http or https URL")
+	}
+	if target.User != nil {
+		return "", apperrors.Validation("invalid_webhook_url", "Webhook URL must not embed credentials")
+	}
+	return target.String(), nil
+}
+
+func validateWebhookEvents(events []string) ([]string, error) {
+	valid := make(map[string]bool, len(models.WebhookEventTypes))
+	for _, event := range models.WebhookEventTypes {
+		valid[event] = true
+	}
+
+	seen := make(map[string]bool, len(events))
+	var result []string
+	for _, event := range events {
+		if !valid[event] {
+			return nil, apperrors.Validation("invalid_webhook_event", fmt.Sprintf("Unknown event type %q", event))
+		}
+		if !seen[event] {
+			seen[event] = true
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+	return "whsec_" + secret, nil
+}
+
+func randomHex(n int) (string, error) {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+func convertToWebhookResponse(webhook *models.Webhook) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:          webhook.ID,
+		URL:         webhook.URL,
+		Description: webhook.Description,
+		Events:      webhook.Events,
+		Active:      webhook.Active,
+		CreatedAt:   webhook.CreatedAt,
+		UpdatedAt:   webhook.UpdatedAt,
+	}
+}
+
+func convertToDeliveryResponse(delivery *models.WebhookDelivery, withPayload bool) *dto.WebhookDeliveryResponse {
+	response := &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == models.DeliveryStatusPending || delivery.Status == models.DeliveryStatusDelivering {
+		next := delivery.NextAttemptAt
+		response.NextAttemptAt = &next
+	}
+	if withPayload {
+		response.Payload = delivery.Payload
+	}
+	return response
+}
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound indica que no existe un blob con la llave pedida
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore guarda y recupera archivos binarios por llave
+type BlobStore interface {
+	// Put escribe el contenido de r bajo key y devuelve los bytes escritos
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Open abre el blob para lectura; el llamador debe cerrarlo
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete elimina el blob; no falla si ya no existe
+	Delete(ctx context.Context, key string) error
+}
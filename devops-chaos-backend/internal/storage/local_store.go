@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStore guarda los blobs como archivos bajo un directorio raíz
+type LocalBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore crea el directorio raíz si no existe
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(absRoot, 0o750); err != nil {
+		return nil, err
+	}
+	return &LocalBlobStore{root: absRoot}, nil
+}
+
+func (s *LocalBlobStore) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	path, err := s.resolve(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, err
+	}
+
+	// Escribir a un temporal y renombrar para no dejar archivos a medias
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, &contextReader{ctx: ctx, r: r})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+func (s *LocalBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return file, err
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// resolve traduce una llave a una ruta dentro de root, rechazando escapes con ".."
+func (s *LocalBlobStore) resolve(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, cleaned), nil
+}
+
+// contextReader corta la copia si se cancela el contexto
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image/gif"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// Tipos de evidencia aceptados como adjuntos
+const (
+	MIMETypePNG    = "image/png"
+	MIMETypeJPEG   = "image/jpeg"
+	MIMETypeGIF    = "image/gif"
+	MIMETypePDF    = "application/pdf"
+	MIMETypeText   = "text/plain"
+	MIMETypePcap   = "application/vnd.tcpdump.pcap"
+	MIMETypePcapNG = "application/x-pcapng"
+)
+
+var ErrMalformedFile = errors.New("malformed file")
+
+// DetectEvidenceType identifica el tipo real del archivo a partir de su contenido.
+// Devuelve el MIME normalizado y la extensión a usar, u ok=false si no está permitido.
+func DetectEvidenceType(data []byte, fileName string) (mimeType, ext string, ok bool) {
+	// Capturas de red: http.DetectContentType no las reconoce
+	if len(data) >= 4 {
+		switch binary.BigEndian.Uint32(data[:4]) {
+		case 0xa1b2c3d4, 0xd4c3b2a1, 0xa1b23c4d, 0x4d3cb2a1:
+			return MIMETypePcap, ".pcap", true
+		case 0x0a0d0d0a:
+			return MIMETypePcapNG, ".pcapng", true
+		}
+	}
+
+	sniffed := http.DetectContentType(data)
+	switch {
+	case sniffed == MIMETypePNG:
+		return MIMETypePNG, ".png", true
+	case sniffed == MIMETypeJPEG:
+		return MIMETypeJPEG, ".jpg", true
+	case sniffed == MIMETypeGIF:
+		return MIMETypeGIF, ".gif", true
+	case sniffed == MIMETypePDF:
+		return MIMETypePDF, ".pdf", true
+	case strings.HasPrefix(sniffed, MIMETypeText):
+		if strings.EqualFold(filepath.Ext(fileName), ".log") {
+			return MIMETypeText, ".log", true
+		}
+		return MIMETypeText, ".txt", true
+	default:
+		return "", "", false
+	}
+}
+
+// CanStripMetadata indica si StripMetadata sabe limpiar el tipo indicado
+func CanStripMetadata(mimeType string) bool {
+	switch mimeType {
+	case MIMETypePNG, MIMETypeJPEG, MIMETypeGIF, MIMETypeText, MIMETypePcap:
+		return true
+	default:
+		return false
+	}
+}
+
+// StripMetadata elimina metadatos embebidos (EXIF, XMP, comentarios, textos) que
+// podrían identificar a quien sube el archivo
+func StripMetadata(mimeType string, data []byte) ([]byte, error) {
+	switch mimeType {
+	case MIMETypeJPEG:
+		return stripJPEG(data)
+	case MIMETypePNG:
+		return stripPNG(data)
+	case MIMETypeGIF:
+		return stripGIF(data)
+	case MIMETypeText, MIMETypePcap:
+		return data, nil // Sin metadatos de autoría a nivel de archivo
+	default:
+		return nil, errors.New("metadata stripping not supported for " + mimeType)
+	}
+}
+
+// stripJPEG descarta los segmentos APP1-APP13, APP15 y COM; conserva JFIF (APP0) y Adobe (APP14)
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, ErrMalformedFile
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+	pos := 2
+
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil, ErrMalformedFile
+		}
+		marker := data[pos+1]
+
+		// Inicio de los datos de imagen: copiar el resto tal cual
+		if marker == 0xDA {
+			out.Write(data[pos:])
+			return out.Bytes(), nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, ErrMalformedFile
+		}
+
+		isMetadata := (marker >= 0xE1 && marker <= 0xED) || marker == 0xEF || marker == 0xFE
+		if !isMetadata {
+			out.Write(data[pos:end])
+		}
+		pos = end
+	}
+
+	return nil, ErrMalformedFile
+}
+
+// stripPNG descarta los chunks de texto, EXIF y fecha de modificación
+func stripPNG(data []byte) ([]byte, error) {
+	signature := []byte("\x89PNG\r\n\x1a\n")
+	if !bytes.HasPrefix(data, signature) {
+		return nil, ErrMalformedFile
+	}
+
+	dropped := map[string]bool{"tEXt": true, "zTXt": true, "iTXt": true, "eXIf": true, "tIME": true}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(signature)
+	pos := len(signature)
+
+	for pos+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		chunkType := string(data[pos+4 : pos+8])
+		end := pos + 12 + length
+		if end > len(data) {
+			return nil, ErrMalformedFile
+		}
+
+		if !dropped[chunkType] {
+			out.Write(data[pos:end])
+		}
+		pos = end
+
+		if chunkType == "IEND" {
+			return out.Bytes(), nil
+		}
+	}
+
+	return nil, ErrMalformedFile
+}
+
+// stripGIF re-codifica el GIF, lo que descarta comentarios y extensiones de aplicación
+func stripGIF(data []byte) ([]byte, error) {
+	decoded, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrMalformedFile
+	}
+
+	var out bytes.Buffer
+	if err := gif.EncodeAll(&out, decoded); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// ContentFingerprint normaliza título y descripción y devuelve un hash exacto
+// (SHA-256) y un SimHash de 64 bits para detectar casi-duplicados
+func ContentFingerprint(title, description string) (string, uint64) {
+	words := normalizeWords(title + " " + description)
+
+	sum := sha256.Sum256([]byte(strings.Join(words, " ")))
+	return hex.EncodeToString(sum[:]), simHash(words)
+}
+
+// HammingDistance cuenta los bits distintos entre dos SimHash
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// normalizeWords pasa a minúsculas y separa por cualquier carácter no alfanumérico
+func normalizeWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// simHash combina palabras y pares de palabras consecutivas como rasgos
+func simHash(words []string) uint64 {
+	var weights [64]int
+	addFeature := func(feature string) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		value := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if value&(1<<uint(bit)) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	for i, word := range words {
+		addFeature(word)
+		if i > 0 {
+			addFeature(words[i-1] + " " + word)
+		}
+	}
+
+	var result uint64
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			result |= 1 << uint(bit)
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package utils
+
+import "testing"
+
+func TestContentFingerprintNormalizes(t *testing.T) {
+	baseHash, baseSim := ContentFingerprint("Daemon patrol", "Seen near the bastion host")
+
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		same        bool
+	}{
+		{"case", "DAEMON PATROL", "seen NEAR the bastion host", true},
+		{"punctuation and spacing", "Daemon  patrol!", "Seen near the bastion-host...", true},
+		{"different word", "Daemon patrol", "Seen near the database host", false},
+		{"word order", "Patrol daemon", "Seen near the bastion host", false},
+	}
+
+	for _, tt := range tests {
+		hash, sim := ContentFingerprint(tt.title, tt.description)
+		if (hash == baseHash) != tt.same {
+			t.Errorf("%s: same hash = %v, want %v", tt.name, hash == baseHash, tt.same)
+		}
+		if tt.same && sim != baseSim {
+			t.Errorf("%s: simhash differs for normalized-equal content", tt.name)
+		}
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	tests := []struct {
+		a, b uint64
+		want int
+	}{
+		{0, 0, 0},
+		{0, 1, 1},
+		{0b1010, 0b0101, 4},
+		{0, ^uint64(0), 64},
+		{0xFF00, 0x00FF, 16},
+	}
+
+	for _, tt := range tests {
+		if got := HammingDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("HammingDistance(%#x, %#x) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
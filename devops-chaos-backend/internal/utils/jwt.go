@@ -15,7 +15,11 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID uint, username, role string) (string, error) {
+// Vigencia de los tokens emitidos al iniciar sesión
+const TokenTTL = 24 * time.Hour
+
+// GenerateToken firma un token para la sesión tokenID que vence en expiresAt
+func GenerateToken(userID uint, username, role, tokenID string, expiresAt time.Time) (string, error) {
 	secretKey := getJWTSecret()
 
 	claims := &Claims{
@@ -23,7 +27,8 @@ func GenerateToken(userID uint, username, role string) (string, error) {
 		Username: username,
 		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
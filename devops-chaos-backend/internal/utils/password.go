@@ -1,6 +1,14 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"math/big"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Sin caracteres que se confunden al leerlos (0/O, 1/l/I)
+const passwordAlphabet = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
 
 func HashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -14,3 +22,17 @@ func CheckPassword(hashedPassword, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
 }
+
+// GeneratePassword crea una contraseña aleatoria de length caracteres
+func GeneratePassword(length int) (string, error) {
+	password := make([]byte, length)
+	max := big.NewInt(int64(len(passwordAlphabet)))
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordAlphabet[n.Int64()]
+	}
+	return string(password), nil
+}
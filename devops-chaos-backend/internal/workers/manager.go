@@ -0,0 +1,96 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Worker es una tarea en segundo plano que corre hasta que se cancela su contexto
+type Worker interface {
+	Name() string
+	Run(ctx context.Context)
+}
+
+// Manager arranca y detiene de forma ordenada los workers registrados
+type Manager struct {
+	workers []Worker
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Register agrega un worker; debe llamarse antes de Start
+func (m *Manager) Register(worker Worker) {
+	m.workers = append(m.workers, worker)
+}
+
+// Start lanza cada worker en su propia goroutine
+func (m *Manager) Start(parent context.Context) {
+	ctx, cancel := context.WithCancel(parent)
+	m.cancel = cancel
+
+	for _, worker := range m.workers {
+		m.wg.Add(1)
+		go func(w Worker) {
+			defer m.wg.Done()
+			log.Printf("Worker %s started", w.Name())
+			w.Run(ctx)
+			log.Printf("Worker %s stopped", w.Name())
+		}(worker)
+	}
+}
+
+// Stop cancela los workers y espera a que terminen o a que venza ctx
+func (m *Manager) Stop(ctx context.Context) error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// periodicWorker ejecuta una función a intervalos regulares
+type periodicWorker struct {
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context)
+}
+
+// NewPeriodic crea un worker que ejecuta fn cada interval
+func NewPeriodic(name string, interval time.Duration, fn func(ctx context.Context)) Worker {
+	return &periodicWorker{name: name, interval: interval, fn: fn}
+}
+
+func (w *periodicWorker) Name() string {
+	return w.name
+}
+
+func (w *periodicWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.fn(ctx)
+		}
+	}
+}
@@ -46,7 +46,7 @@ const ReportsManagement: React.FC = () => {
         switch (userRole) {
           case 'network_admin': return 'resistance';
           case 'daemon': return 'capture';
-          case 'andrei': return 'anonymous'; // Andrei can create any type, defaulting to anonymous
+          case 'andrei': return 'resistance'; // Anonymous reports only go through /api/resistance/report
           default: return 'anonymous';
         }
       };